@@ -4,6 +4,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -23,6 +24,19 @@ type EnvConfig interface {
 	GetSupabaseURL() string
 	GetSupabaseKey() string
 	GetSupabaseBucket() string
+	GetAutoAssignMode() string
+	GetSLACheckInterval() time.Duration
+	GetSLADueSoonWindow() time.Duration
+	GetSLAEscalationGrace() time.Duration
+	GetLocationRetention() time.Duration
+	GetLocationMaxPingsPerWorker() int
+	GetRegionBoundariesPath() string
+	GetRoadNetworkPath() string
+	GetOwnRoadAuthorities() []string
+	GetRoadNameExtractPath() string
+	GetServiceAreaMode() string
+	GetRecurrenceWindow() time.Duration
+	GetRecurrenceRadiusMeters() float64
 }
 
 type envConfig struct {
@@ -105,3 +119,114 @@ func (e *envConfig) GetSupabaseKey() string {
 func (e *envConfig) GetSupabaseBucket() string {
 	return strings.TrimSpace(os.Getenv("SUPABASE_BUCKET_NAME"))
 }
+
+// GetAutoAssignMode returns AUTO_ASSIGN_MODE: "off", "suggest" (default) or
+// "apply".
+func (e *envConfig) GetAutoAssignMode() string {
+	mode := strings.ToLower(strings.TrimSpace(os.Getenv("AUTO_ASSIGN_MODE")))
+	if mode == "" {
+		return "suggest"
+	}
+	return mode
+}
+
+// GetSLACheckInterval returns SLA_CHECK_INTERVAL_MINUTES, five minutes by
+// default. Zero turns the deadline check off.
+func (e *envConfig) GetSLACheckInterval() time.Duration {
+	return durationEnv("SLA_CHECK_INTERVAL_MINUTES", time.Minute, 5)
+}
+
+// GetSLADueSoonWindow returns SLA_DUE_SOON_HOURS, how long before the deadline
+// the worker is reminded. Defaults to 24 hours.
+func (e *envConfig) GetSLADueSoonWindow() time.Duration {
+	return durationEnv("SLA_DUE_SOON_HOURS", time.Hour, 24)
+}
+
+// GetSLAEscalationGrace returns SLA_ESCALATION_GRACE_HOURS, how long a report
+// may stay overdue before supervisors are alerted. Defaults to 24 hours.
+func (e *envConfig) GetSLAEscalationGrace() time.Duration {
+	return durationEnv("SLA_ESCALATION_GRACE_HOURS", time.Hour, 24)
+}
+
+// GetLocationRetention returns LOCATION_RETENTION_DAYS, how long worker
+// location pings are kept. Defaults to 7 days.
+func (e *envConfig) GetLocationRetention() time.Duration {
+	return durationEnv("LOCATION_RETENTION_DAYS", 24*time.Hour, 7)
+}
+
+// GetLocationMaxPingsPerWorker returns LOCATION_MAX_PINGS_PER_WORKER, the
+// longest track kept for one worker. Defaults to 5000 pings.
+func (e *envConfig) GetLocationMaxPingsPerWorker() int {
+	return intEnv("LOCATION_MAX_PINGS_PER_WORKER", 5000)
+}
+
+// GetRegionBoundariesPath returns REGION_BOUNDARIES_PATH, a GeoJSON file or a
+// directory of them holding the administrative region boundaries. Empty keeps
+// the regions imported before.
+func (e *envConfig) GetRegionBoundariesPath() string {
+	return strings.TrimSpace(os.Getenv("REGION_BOUNDARIES_PATH"))
+}
+
+// GetRoadNetworkPath returns ROAD_NETWORK_PATH, a GeoJSON file or a directory
+// of them holding the road segments and their authority. Empty keeps the
+// segments imported before.
+func (e *envConfig) GetRoadNetworkPath() string {
+	return strings.TrimSpace(os.Getenv("ROAD_NETWORK_PATH"))
+}
+
+// GetOwnRoadAuthorities returns OWN_ROAD_AUTHORITIES, the comma separated road
+// authorities handled by this agency. Defaults to city roads.
+func (e *envConfig) GetOwnRoadAuthorities() []string {
+	var authorities []string
+	for _, authority := range strings.Split(os.Getenv("OWN_ROAD_AUTHORITIES"), ",") {
+		if authority = strings.ToLower(strings.TrimSpace(authority)); authority != "" {
+			authorities = append(authorities, authority)
+		}
+	}
+	if len(authorities) == 0 {
+		return []string{"city"}
+	}
+	return authorities
+}
+
+// GetRoadNameExtractPath returns ROAD_NAME_EXTRACT_PATH, an OSM PBF or GeoJSON
+// file, or a directory of them, with the named roads used to suggest and
+// normalise report road names. Empty turns reverse geocoding off.
+func (e *envConfig) GetRoadNameExtractPath() string {
+	return strings.TrimSpace(os.Getenv("ROAD_NAME_EXTRACT_PATH"))
+}
+
+// GetServiceAreaMode returns SERVICE_AREA_MODE: "reject" to refuse reports
+// outside every service area or "flag" (default) to accept and mark them.
+func (e *envConfig) GetServiceAreaMode() string {
+	mode := strings.ToLower(strings.TrimSpace(os.Getenv("SERVICE_AREA_MODE")))
+	if mode == "" {
+		return "flag"
+	}
+	return mode
+}
+
+// GetRecurrenceWindow returns RECURRENCE_WINDOW_DAYS, how long after a repair
+// a new report close to it counts as a recurrence. Defaults to 90 days. Zero
+// turns recurrence detection off.
+func (e *envConfig) GetRecurrenceWindow() time.Duration {
+	return durationEnv("RECURRENCE_WINDOW_DAYS", 24*time.Hour, 90)
+}
+
+// GetRecurrenceRadiusMeters returns RECURRENCE_RADIUS_METERS, how close a new
+// report must be to a repair to count as a recurrence. Defaults to 30 metres.
+func (e *envConfig) GetRecurrenceRadiusMeters() float64 {
+	return float64(intEnv("RECURRENCE_RADIUS_METERS", 30))
+}
+
+func durationEnv(key string, unit time.Duration, fallback int) time.Duration {
+	return time.Duration(intEnv(key, fallback)) * unit
+}
+
+func intEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || value < 0 {
+		return fallback
+	}
+	return value
+}
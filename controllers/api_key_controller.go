@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"dinacom-11.0-backend/models/dto"
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type APIKeyController interface {
+	CreateAPIKey(ctx *gin.Context)
+	GetAPIKeys(ctx *gin.Context)
+	RevokeAPIKey(ctx *gin.Context)
+	GetAPIKeyUsage(ctx *gin.Context)
+}
+
+type apiKeyController struct {
+	apiKeyService services.APIKeyService
+}
+
+func NewAPIKeyController(apiKeyService services.APIKeyService) APIKeyController {
+	return &apiKeyController{apiKeyService: apiKeyService}
+}
+
+// @Summary Create API Key
+// @Description Admin creates an API key for machine-to-machine access. The plaintext key is only returned once.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateAPIKeyRequest true "Create API Key Request"
+// @Security BearerAuth
+// @Success 200 {object} dto.CreateAPIKeyResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/api-keys [post]
+func (c *apiKeyController) CreateAPIKey(ctx *gin.Context) {
+	var req dto.CreateAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response, err := c.apiKeyService.CreateAPIKey(utils.GetAuditContext(ctx), req)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "API key created", response)
+}
+
+// @Summary Get API Keys
+// @Description Get all API keys with their usage (Admin only)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.APIKeyResponse
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/api-keys [get]
+func (c *apiKeyController) GetAPIKeys(ctx *gin.Context) {
+	keys, err := c.apiKeyService.GetAPIKeys()
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "API keys retrieved", keys)
+}
+
+// @Summary Revoke API Key
+// @Description Revoke an API key so it can no longer authenticate (Admin only)
+// @Tags Admin
+// @Produce json
+// @Param id path string true "API Key ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/api-keys/{id} [delete]
+func (c *apiKeyController) RevokeAPIKey(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid API key ID")
+		return
+	}
+
+	if err := c.apiKeyService.RevokeAPIKey(utils.GetAuditContext(ctx), id); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "API key revoked", nil)
+}
+
+// @Summary Get API Key Usage
+// @Description Get daily request counts of an API key (Admin only)
+// @Tags Admin
+// @Produce json
+// @Param id path string true "API Key ID"
+// @Param days query int false "Number of days" default(30)
+// @Security BearerAuth
+// @Success 200 {array} dto.APIKeyUsageResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/api-keys/{id}/usage [get]
+func (c *apiKeyController) GetAPIKeyUsage(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid API key ID")
+		return
+	}
+
+	days, _ := strconv.Atoi(ctx.DefaultQuery("days", "30"))
+	if days < 1 || days > 365 {
+		days = 30
+	}
+
+	usage, err := c.apiKeyService.GetAPIKeyUsage(id, days)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "API key usage retrieved", usage)
+}
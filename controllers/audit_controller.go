@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AuditController interface {
+	GetAuditLogs(ctx *gin.Context)
+}
+
+type auditController struct {
+	auditService  services.AuditService
+	regionService services.RegionService
+}
+
+func NewAuditController(auditService services.AuditService, regionService services.RegionService) AuditController {
+	return &auditController{auditService: auditService, regionService: regionService}
+}
+
+// @Summary Get Audit Logs
+// @Description Query security and audit events with filters, as JSON or CSV (Admin only)
+// @Tags Admin
+// @Produce json
+// @Produce text/csv
+// @Param action query string false "Action, e.g. login_failed"
+// @Param actor_type query string false "Actor type, user or api_key"
+// @Param actor_id query string false "Actor user or API key ID"
+// @Param target_type query string false "Target type, e.g. report"
+// @Param target_id query string false "Target ID"
+// @Param ip query string false "Client IP"
+// @Param request_id query string false "Request ID"
+// @Param from query string false "From (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "To (RFC3339 or YYYY-MM-DD)"
+// @Param region query string false "Region code; limits the log to reports inside it"
+// @Param format query string false "json or csv" default(json)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Security BearerAuth
+// @Success 200 {object} dto.PaginatedAuditLogsResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/audit [get]
+func (c *auditController) GetAuditLogs(ctx *gin.Context) {
+	filter := dto.AuditLogFilter{
+		Action:     ctx.Query("action"),
+		ActorType:  ctx.Query("actor_type"),
+		TargetType: ctx.Query("target_type"),
+		TargetID:   ctx.Query("target_id"),
+		IP:         ctx.Query("ip"),
+		RequestID:  ctx.Query("request_id"),
+	}
+
+	if actor := ctx.Query("actor_id"); actor != "" {
+		actorID, err := uuid.Parse(actor)
+		if err != nil {
+			utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid actor_id")
+			return
+		}
+		filter.ActorID = &actorID
+	}
+
+	var ok bool
+	if filter.RegionCodes, ok = regionFilter(ctx, c.regionService); !ok {
+		return
+	}
+
+	var err error
+	if filter.From, err = parseTimeQuery(ctx.Query("from"), false); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid from date")
+		return
+	}
+	if filter.To, err = parseTimeQuery(ctx.Query("to"), true); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid to date")
+		return
+	}
+
+	if ctx.Query("format") == "csv" {
+		logs, err := c.auditService.ExportAuditLogs(filter)
+		if err != nil {
+			utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rows := make([][]string, 0, len(logs))
+		for _, log := range logs {
+			actorID := ""
+			if log.ActorID != nil {
+				actorID = log.ActorID.String()
+			}
+			metadata := ""
+			if len(log.Metadata) > 0 {
+				raw, _ := json.Marshal(log.Metadata)
+				metadata = string(raw)
+			}
+			rows = append(rows, []string{
+				log.CreatedAt.Format(time.RFC3339), log.Action, log.ActorType, actorID, log.ActorRole,
+				log.TargetType, log.TargetID, log.IP, log.RequestID, metadata,
+			})
+		}
+
+		utils.SendCSVResponse(ctx, "audit_logs.csv",
+			[]string{"created_at", "action", "actor_type", "actor_id", "actor_role", "target_type", "target_id", "ip", "request_id", "metadata"},
+			rows)
+		return
+	}
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	response, err := c.auditService.GetAuditLogs(filter, page, limit)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Audit logs retrieved", response)
+}
@@ -21,14 +21,16 @@ type AuthController interface {
 	GetProfile(ctx *gin.Context)
 	GetAllUsers(ctx *gin.Context)
 	GetAllWorkers(ctx *gin.Context)
+	ChangeUserRole(ctx *gin.Context)
 }
 
 type authController struct {
-	authService services.AuthService
+	authService   services.AuthService
+	regionService services.RegionService
 }
 
-func NewAuthController(authService services.AuthService) AuthController {
-	return &authController{authService: authService}
+func NewAuthController(authService services.AuthService, regionService services.RegionService) AuthController {
+	return &authController{authService: authService, regionService: regionService}
 }
 
 // @Summary Register a new user
@@ -71,7 +73,7 @@ func (c *authController) VerifyOTP(ctx *gin.Context) {
 		return
 	}
 
-	token, err := c.authService.VerifyOTP(req)
+	token, err := c.authService.VerifyOTP(utils.GetAuditContext(ctx), req)
 	if err != nil {
 		utils.SendErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
@@ -96,7 +98,7 @@ func (c *authController) LoginUser(ctx *gin.Context) {
 		return
 	}
 
-	token, err := c.authService.LoginUser(req)
+	token, err := c.authService.LoginUser(utils.GetAuditContext(ctx), req)
 	if err != nil {
 		utils.SendErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
@@ -121,7 +123,7 @@ func (c *authController) LoginAdmin(ctx *gin.Context) {
 		return
 	}
 
-	token, err := c.authService.LoginAdmin(req)
+	token, err := c.authService.LoginAdmin(utils.GetAuditContext(ctx), req)
 	if err != nil {
 		utils.SendErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
@@ -146,7 +148,7 @@ func (c *authController) LoginWorker(ctx *gin.Context) {
 		return
 	}
 
-	token, err := c.authService.LoginWorker(req)
+	token, err := c.authService.LoginWorker(utils.GetAuditContext(ctx), req)
 	if err != nil {
 		utils.SendErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
@@ -181,15 +183,21 @@ func (c *authController) GetProfile(ctx *gin.Context) {
 }
 
 // @Summary Get All Users
-// @Description Get all users (Admin only)
+// @Description Get all users (Admin only). Region-scoped admins only see citizens who reported inside their jurisdiction
 // @Tags Admin
 // @Produce json
+// @Param region query string false "Region code"
 // @Security BearerAuth
 // @Success 200 {array} dto.UserResponse
 // @Failure 403 {object} map[string]string
 // @Router /api/auth/admin/users [get]
 func (c *authController) GetAllUsers(ctx *gin.Context) {
-	users, err := c.authService.GetAllUsers()
+	regionCodes, ok := regionFilter(ctx, c.regionService)
+	if !ok {
+		return
+	}
+
+	users, err := c.authService.GetAllUsers(regionCodes)
 	if err != nil {
 		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
@@ -232,7 +240,7 @@ func (c *authController) GoogleAuth(ctx *gin.Context) {
 		return
 	}
 
-	response, err := c.authService.GoogleAuth(req)
+	response, err := c.authService.GoogleAuth(utils.GetAuditContext(ctx), req)
 	if err != nil {
 		utils.SendErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
@@ -240,3 +248,35 @@ func (c *authController) GoogleAuth(ctx *gin.Context) {
 
 	utils.SendSuccessResponse(ctx, "Authentication successful", response)
 }
+
+// @Summary Change User Role
+// @Description Change the role of a user (Admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body dto.ChangeRoleRequest true "Change Role Request"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /api/auth/admin/users/{id}/role [patch]
+func (c *authController) ChangeUserRole(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req dto.ChangeRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.authService.ChangeUserRole(utils.GetAuditContext(ctx), userID, req.Role); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "User role changed", nil)
+}
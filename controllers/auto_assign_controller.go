@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	entity "dinacom-11.0-backend/models/entity"
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AutoAssignController interface {
+	GetCandidates(ctx *gin.Context)
+	GetSuggestions(ctx *gin.Context)
+	ApplySuggestion(ctx *gin.Context)
+	DismissSuggestion(ctx *gin.Context)
+}
+
+type autoAssignController struct {
+	autoAssignService services.AutoAssignService
+	regionService     services.RegionService
+}
+
+func NewAutoAssignController(autoAssignService services.AutoAssignService, regionService services.RegionService) AutoAssignController {
+	return &autoAssignController{autoAssignService: autoAssignService, regionService: regionService}
+}
+
+// @Summary Get Worker Candidates
+// @Description Rank workers for a report by distance from their base area, open workload, skills and shift, with the reasoning for each
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Report ID"
+// @Security BearerAuth
+// @Success 200 {array} dto.WorkerCandidateResponse
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/report/{id}/candidates [get]
+func (c *autoAssignController) GetCandidates(ctx *gin.Context) {
+	if !reportInScope(ctx, c.regionService, ctx.Param("id")) {
+		return
+	}
+
+	candidates, err := c.autoAssignService.GetCandidates(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Worker candidates retrieved", candidates)
+}
+
+// @Summary Get Assignment Suggestions
+// @Description Get workers picked by the auto-assignment engine for newly classified reports
+// @Tags Admin
+// @Produce json
+// @Param status query string false "pending, applied, dismissed or outdated" default(pending)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param region query string false "Region code. Admins limited to regions only see their own"
+// @Security BearerAuth
+// @Success 200 {object} dto.PaginatedSuggestionsResponse
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/assignment-suggestions [get]
+func (c *autoAssignController) GetSuggestions(ctx *gin.Context) {
+	status := ctx.DefaultQuery("status", entity.SUGGESTION_PENDING)
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	regionCodes, ok := regionFilter(ctx, c.regionService)
+	if !ok {
+		return
+	}
+
+	response, err := c.autoAssignService.GetSuggestions(status, regionCodes, page, limit)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Assignment suggestions retrieved", response)
+}
+
+// @Summary Apply Assignment Suggestion
+// @Description Assign the suggested worker. The engine's reasoning is stored with the assignment.
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Suggestion ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/assignment-suggestions/{id}/apply [patch]
+func (c *autoAssignController) ApplySuggestion(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid suggestion ID")
+		return
+	}
+
+	if !c.suggestionInScope(ctx, id) {
+		return
+	}
+
+	message, err := c.autoAssignService.ApplySuggestion(utils.GetAuditContext(ctx), id)
+	if err != nil {
+		sendReportError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, message, nil)
+}
+
+// @Summary Dismiss Assignment Suggestion
+// @Description Dismiss a pending suggestion without assigning
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Suggestion ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /api/admin/assignment-suggestions/{id}/dismiss [patch]
+func (c *autoAssignController) DismissSuggestion(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid suggestion ID")
+		return
+	}
+
+	if !c.suggestionInScope(ctx, id) {
+		return
+	}
+
+	if err := c.autoAssignService.DismissSuggestion(utils.GetAuditContext(ctx), id); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Assignment suggestion dismissed", nil)
+}
+
+// suggestionInScope answers with 404 for unknown suggestions and 403 when the
+// suggested report lies outside the admin's jurisdiction.
+func (c *autoAssignController) suggestionInScope(ctx *gin.Context, id uuid.UUID) bool {
+	reportID, err := c.autoAssignService.GetSuggestionReportID(id)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return false
+	}
+	return reportInScope(ctx, c.regionService, reportID)
+}
@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"time"
+
 	http_error "dinacom-11.0-backend/models/error"
 	"dinacom-11.0-backend/utils"
 
@@ -21,3 +23,24 @@ func RequestJSON[TRequest any](ctx *gin.Context) TRequest {
 func ResponseJSON[TResponse any, TMetaData any](ctx *gin.Context, metaData TMetaData, res TResponse, err error) {
 	utils.SendResponse(ctx, metaData, res, err)
 }
+
+// parseTimeQuery accepts RFC3339 or a plain date. A plain date used as an
+// upper bound is extended to the end of that day.
+func parseTimeQuery(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GeocodeController interface {
+	ReverseGeocode(ctx *gin.Context)
+}
+
+type geocodeController struct {
+	geocodeService services.GeocodeService
+}
+
+func NewGeocodeController(geocodeService services.GeocodeService) GeocodeController {
+	return &geocodeController{geocodeService: geocodeService}
+}
+
+// @Summary Reverse Geocode Road Name
+// @Description Suggest the names of the roads within 100 m of a position, nearest first, from the local road extract. road_name is the suggestion matching the typed name, or else the nearest road
+// @Tags Report
+// @Produce json
+// @Param latitude query number true "Latitude"
+// @Param longitude query number true "Longitude"
+// @Param road_name query string false "Road name typed by the citizen"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.ReverseGeocodeResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/geocode/reverse [get]
+func (c *geocodeController) ReverseGeocode(ctx *gin.Context) {
+	latitude, latErr := strconv.ParseFloat(ctx.Query("latitude"), 64)
+	longitude, lngErr := strconv.ParseFloat(ctx.Query("longitude"), 64)
+	if latErr != nil || lngErr != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, http_error.INVALID_COORDINATES.Error())
+		return
+	}
+
+	response, err := c.geocodeService.ReverseGeocode(latitude, longitude, ctx.Query("road_name"))
+	if err != nil {
+		switch {
+		case errors.Is(err, http_error.INVALID_COORDINATES):
+			utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		case errors.Is(err, http_error.GEOCODER_NOT_LOADED):
+			utils.SendErrorResponse(ctx, http.StatusServiceUnavailable, err.Error())
+		default:
+			utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Road names retrieved", response)
+}
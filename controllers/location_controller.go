@@ -0,0 +1,221 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type LocationController interface {
+	StartShift(ctx *gin.Context)
+	EndShift(ctx *gin.Context)
+	GetCurrentShift(ctx *gin.Context)
+	RecordPings(ctx *gin.Context)
+	GetLatestPositions(ctx *gin.Context)
+	GetWorkerTrack(ctx *gin.Context)
+}
+
+type locationController struct {
+	locationService services.LocationService
+}
+
+func NewLocationController(locationService services.LocationService) LocationController {
+	return &locationController{locationService: locationService}
+}
+
+// @Summary Start Shift
+// @Description Clock in. Location pings are only accepted when tracking_consent is true
+// @Tags Worker
+// @Accept json
+// @Produce json
+// @Param request body dto.StartShiftRequest true "Start Shift Request"
+// @Security BearerAuth
+// @Success 200 {object} dto.ShiftSessionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/worker/shift/start [post]
+func (c *locationController) StartShift(ctx *gin.Context) {
+	workerIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.StartShiftRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	session, err := c.locationService.StartShift(utils.GetAuditContext(ctx), workerIDVal.(uuid.UUID), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, http_error.SHIFT_ALREADY_STARTED) {
+			status = http.StatusConflict
+		}
+		utils.SendErrorResponse(ctx, status, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Shift started", session)
+}
+
+// @Summary End Shift
+// @Description Clock out and stop location tracking
+// @Tags Worker
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.ShiftSessionResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/worker/shift/end [post]
+func (c *locationController) EndShift(ctx *gin.Context) {
+	workerIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	session, err := c.locationService.EndShift(utils.GetAuditContext(ctx), workerIDVal.(uuid.UUID))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Shift ended", session)
+}
+
+// @Summary Get Current Shift
+// @Description Get the logged-in worker's shift in progress
+// @Tags Worker
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.ShiftSessionResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/worker/shift [get]
+func (c *locationController) GetCurrentShift(ctx *gin.Context) {
+	workerIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	session, err := c.locationService.GetCurrentShift(workerIDVal.(uuid.UUID))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Current shift retrieved", session)
+}
+
+// @Summary Post Location Pings
+// @Description Send up to 100 buffered location pings recorded during a tracked shift
+// @Tags Worker
+// @Accept json
+// @Produce json
+// @Param request body dto.LocationBatchRequest true "Location Batch Request"
+// @Security BearerAuth
+// @Success 200 {object} dto.LocationBatchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /api/worker/location [post]
+func (c *locationController) RecordPings(ctx *gin.Context) {
+	workerIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.LocationBatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.locationService.RecordPings(workerIDVal.(uuid.UUID), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, http_error.SHIFT_NOT_STARTED):
+			status = http.StatusBadRequest
+		case errors.Is(err, http_error.TRACKING_NOT_ENABLED):
+			status = http.StatusForbidden
+		}
+		utils.SendErrorResponse(ctx, status, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Location recorded", result)
+}
+
+// @Summary Get Worker Positions
+// @Description Get the last known position of every worker on a tracked shift (Admin only)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.WorkerPositionResponse
+// @Router /api/admin/workers/locations [get]
+func (c *locationController) GetLatestPositions(ctx *gin.Context) {
+	positions, err := c.locationService.GetLatestPositions()
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Worker positions retrieved", positions)
+}
+
+// @Summary Get Worker Track
+// @Description Get a worker's recorded positions in a time range, defaulting to the last 24 hours (Admin only)
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Worker ID"
+// @Param from query string false "From (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "To (RFC3339 or YYYY-MM-DD)"
+// @Security BearerAuth
+// @Success 200 {array} dto.LocationPingResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/workers/{id}/track [get]
+func (c *locationController) GetWorkerTrack(ctx *gin.Context) {
+	workerID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid worker ID")
+		return
+	}
+
+	from, err := parseTimeQuery(ctx.Query("from"), false)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid from date")
+		return
+	}
+	to, err := parseTimeQuery(ctx.Query("to"), true)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid to date")
+		return
+	}
+
+	if to == nil {
+		now := time.Now()
+		to = &now
+	}
+	if from == nil {
+		start := to.Add(-24 * time.Hour)
+		from = &start
+	}
+
+	track, err := c.locationService.GetTrack(workerID, *from, *to)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Worker track retrieved", track)
+}
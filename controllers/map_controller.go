@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"dinacom-11.0-backend/models/dto"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	geoJSONMaxAge = 60
+	tileMaxAge    = 300
+)
+
+type MapController interface {
+	GetReportsGeoJSON(ctx *gin.Context)
+	GetTile(ctx *gin.Context)
+	GetClusters(ctx *gin.Context)
+	GetHeatmap(ctx *gin.Context)
+}
+
+type mapController struct {
+	mapService services.MapService
+}
+
+func NewMapController(mapService services.MapService) MapController {
+	return &mapController{mapService: mapService}
+}
+
+// @Summary Get Reports GeoJSON
+// @Description Get the public map reports as a GeoJSON FeatureCollection with class, score, status and road name properties. Accepts the same filters as /api/get_report
+// @Tags Report
+// @Produce application/geo+json
+// @Param bbox query string false "min_lng,min_lat,max_lng,max_lat"
+// @Param lat query number false "Radius centre latitude"
+// @Param lng query number false "Radius centre longitude"
+// @Param radius_km query number false "Radius in km"
+// @Param polygon query string false "GeoJSON Polygon geometry"
+// @Param zoom query int false "Map zoom level; nearby reports are merged below zoom 16"
+// @Param limit query int false "Maximum number of reports" default(500)
+// @Success 200 {object} dto.GeoJSONFeatureCollection
+// @Success 304
+// @Failure 400 {object} map[string]string
+// @Router /api/reports.geojson [get]
+func (c *mapController) GetReportsGeoJSON(ctx *gin.Context) {
+	req, err := parseReportSearchQuery(ctx)
+	if err != nil {
+		sendSearchError(ctx, err)
+		return
+	}
+
+	body, err := c.mapService.GetReportsGeoJSON(req)
+	if err != nil {
+		sendSearchError(ctx, err)
+		return
+	}
+
+	utils.SendCachedData(ctx, "application/geo+json", body, geoJSONMaxAge)
+}
+
+// @Summary Get Report Vector Tile
+// @Description Get the public map reports inside a web mercator tile as a Mapbox Vector Tile with a "reports" point layer
+// @Tags Report
+// @Produce application/vnd.mapbox-vector-tile
+// @Param z path int true "Zoom level (0-22)"
+// @Param x path int true "Tile column"
+// @Param y path string true "Tile row, with the .mvt suffix"
+// @Success 200 {file} binary
+// @Success 304
+// @Failure 400 {object} map[string]string
+// @Router /api/tiles/{z}/{x}/{y}.mvt [get]
+func (c *mapController) GetTile(ctx *gin.Context) {
+	rawY, ok := strings.CutSuffix(ctx.Param("y"), ".mvt")
+	z, errZ := strconv.Atoi(ctx.Param("z"))
+	x, errX := strconv.Atoi(ctx.Param("x"))
+	y, errY := strconv.Atoi(rawY)
+	if !ok || errZ != nil || errX != nil || errY != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, http_error.INVALID_TILE.Error())
+		return
+	}
+
+	tile, err := c.mapService.GetTile(z, x, y)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, http_error.INVALID_TILE) {
+			status = http.StatusBadRequest
+		}
+		utils.SendErrorResponse(ctx, status, err.Error())
+		return
+	}
+
+	utils.SendCachedData(ctx, "application/vnd.mapbox-vector-tile", tile, tileMaxAge)
+}
+
+// @Summary Get Report Clusters
+// @Description Merge the public map reports in a bbox into clusters on a 64 pixel grid at the zoom level, with their count and most severe report
+// @Tags Report
+// @Produce json
+// @Param bbox query string true "min_lng,min_lat,max_lng,max_lat"
+// @Param zoom query int true "Map zoom level (0-22)"
+// @Success 200 {object} dto.ReportClustersResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/reports/clusters [get]
+func (c *mapController) GetClusters(ctx *gin.Context) {
+	req, err := parseAggregateQuery(ctx)
+	if err != nil {
+		sendSearchError(ctx, err)
+		return
+	}
+
+	clusters, err := c.mapService.GetClusters(req)
+	if err != nil {
+		sendSearchError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Report clusters retrieved successfully", clusters)
+}
+
+// @Summary Get Report Heatmap
+// @Description Sum the total scores of the public map reports in a bbox on a 16 pixel grid at the zoom level
+// @Tags Report
+// @Produce json
+// @Param bbox query string true "min_lng,min_lat,max_lng,max_lat"
+// @Param zoom query int true "Map zoom level (0-22)"
+// @Success 200 {object} dto.HeatmapResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/reports/heatmap [get]
+func (c *mapController) GetHeatmap(ctx *gin.Context) {
+	req, err := parseAggregateQuery(ctx)
+	if err != nil {
+		sendSearchError(ctx, err)
+		return
+	}
+
+	heatmap, err := c.mapService.GetHeatmap(req)
+	if err != nil {
+		sendSearchError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Report heatmap retrieved successfully", heatmap)
+}
+
+func parseAggregateQuery(ctx *gin.Context) (dto.ReportAggregateRequest, error) {
+	search, err := parseReportSearchQuery(ctx)
+	if err != nil {
+		return dto.ReportAggregateRequest{}, err
+	}
+	if search.BBox == nil {
+		return dto.ReportAggregateRequest{}, http_error.INVALID_BBOX
+	}
+	if search.Zoom == nil {
+		return dto.ReportAggregateRequest{}, http_error.INVALID_ZOOM
+	}
+	return dto.ReportAggregateRequest{BBox: search.BBox, Zoom: *search.Zoom}, nil
+}
@@ -0,0 +1,253 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type MaterialController interface {
+	GetMaterials(ctx *gin.Context)
+	GetActiveMaterials(ctx *gin.Context)
+	CreateMaterial(ctx *gin.Context)
+	UpdateMaterial(ctx *gin.Context)
+	DeleteMaterial(ctx *gin.Context)
+	GetReportCost(ctx *gin.Context)
+	GetCostReport(ctx *gin.Context)
+}
+
+type materialController struct {
+	materialService services.MaterialService
+	regionService   services.RegionService
+}
+
+func NewMaterialController(materialService services.MaterialService, regionService services.RegionService) MaterialController {
+	return &materialController{materialService: materialService, regionService: regionService}
+}
+
+// @Summary Get Materials
+// @Description List the materials catalogue, including inactive materials (Admin only)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.MaterialResponse
+// @Router /api/admin/materials [get]
+func (c *materialController) GetMaterials(ctx *gin.Context) {
+	materials, err := c.materialService.GetMaterials(false)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Materials retrieved successfully", materials)
+}
+
+// @Summary Get Active Materials
+// @Description List the materials a worker can record when finishing a report
+// @Tags Worker
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.MaterialResponse
+// @Router /api/worker/materials [get]
+func (c *materialController) GetActiveMaterials(ctx *gin.Context) {
+	materials, err := c.materialService.GetMaterials(true)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Materials retrieved successfully", materials)
+}
+
+// @Summary Create Material
+// @Description Add a material such as asphalt, cold-mix or cement with its unit and unit cost (Admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dto.MaterialRequest true "Material Request"
+// @Security BearerAuth
+// @Success 200 {object} dto.MaterialResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/materials [post]
+func (c *materialController) CreateMaterial(ctx *gin.Context) {
+	var req dto.MaterialRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	material, err := c.materialService.CreateMaterial(utils.GetAuditContext(ctx), req)
+	if err != nil {
+		sendMaterialError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Material created", material)
+}
+
+// @Summary Update Material
+// @Description Change a material. Costs already recorded keep their unit cost (Admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Material ID"
+// @Param request body dto.MaterialRequest true "Material Request"
+// @Security BearerAuth
+// @Success 200 {object} dto.MaterialResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/materials/{id} [put]
+func (c *materialController) UpdateMaterial(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid material ID")
+		return
+	}
+
+	var req dto.MaterialRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	material, err := c.materialService.UpdateMaterial(utils.GetAuditContext(ctx), id, req)
+	if err != nil {
+		sendMaterialError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Material updated", material)
+}
+
+// @Summary Delete Material
+// @Description Remove a material that was never used on a report (Admin only)
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Material ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/materials/{id} [delete]
+func (c *materialController) DeleteMaterial(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid material ID")
+		return
+	}
+
+	if err := c.materialService.DeleteMaterial(utils.GetAuditContext(ctx), id); err != nil {
+		sendMaterialError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Material deleted", nil)
+}
+
+// @Summary Get Report Cost
+// @Description Get the materials used on a report and its total cost (Admin only)
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Report ID"
+// @Security BearerAuth
+// @Success 200 {object} dto.ReportCostResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/report/{id}/materials [get]
+func (c *materialController) GetReportCost(ctx *gin.Context) {
+	if !reportInScope(ctx, c.regionService, ctx.Param("id")) {
+		return
+	}
+
+	cost, err := c.materialService.GetReportCost(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Report cost retrieved", cost)
+}
+
+// @Summary Get Material Costs
+// @Description Material quantities and cost per road, district or month of use, as JSON or CSV (Admin only)
+// @Tags Admin
+// @Produce json
+// @Produce text/csv
+// @Param group_by query string false "road, district or month" default(road)
+// @Param from query string false "Used from (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Used to (RFC3339 or YYYY-MM-DD)"
+// @Param format query string false "json or csv" default(json)
+// @Param region query string false "Region code. Admins limited to regions only see their own"
+// @Security BearerAuth
+// @Success 200 {array} dto.MaterialCostGroupResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/materials/costs [get]
+func (c *materialController) GetCostReport(ctx *gin.Context) {
+	from, err := parseTimeQuery(ctx.Query("from"), false)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid from date")
+		return
+	}
+	to, err := parseTimeQuery(ctx.Query("to"), true)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid to date")
+		return
+	}
+
+	regionCodes, ok := regionFilter(ctx, c.regionService)
+	if !ok {
+		return
+	}
+
+	groupBy := ctx.DefaultQuery("group_by", entity.COST_GROUP_ROAD)
+	groups, err := c.materialService.GetCostReport(groupBy, from, to, regionCodes)
+	if err != nil {
+		if errors.Is(err, http_error.INVALID_COST_GROUP) {
+			utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if ctx.Query("format") == "csv" {
+		rows := [][]string{}
+		for _, group := range groups {
+			for _, line := range group.Materials {
+				rows = append(rows, []string{
+					group.Group, strconv.FormatInt(group.Reports, 10), line.MaterialName, line.Unit,
+					strconv.FormatFloat(line.Quantity, 'f', -1, 64), strconv.FormatFloat(line.Cost, 'f', 2, 64),
+					strconv.FormatFloat(group.TotalCost, 'f', 2, 64),
+				})
+			}
+		}
+
+		utils.SendCSVResponse(ctx, "material_costs_by_"+groupBy+".csv",
+			[]string{groupBy, "reports", "material", "unit", "quantity", "cost", "group_total_cost"},
+			rows)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Material costs retrieved successfully", groups)
+}
+
+func sendMaterialError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, http_error.MATERIAL_NOT_FOUND):
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, http_error.MATERIAL_NAME_TAKEN), errors.Is(err, http_error.MATERIAL_IN_USE):
+		utils.SendErrorResponse(ctx, http.StatusConflict, err.Error())
+	default:
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
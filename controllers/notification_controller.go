@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type NotificationController interface {
+	GetMyNotifications(ctx *gin.Context)
+	MarkAsRead(ctx *gin.Context)
+	MarkAllAsRead(ctx *gin.Context)
+}
+
+type notificationController struct {
+	notificationService services.NotificationService
+}
+
+func NewNotificationController(notificationService services.NotificationService) NotificationController {
+	return &notificationController{notificationService: notificationService}
+}
+
+// @Summary Get My Notifications
+// @Description Get notifications of the logged-in user with pagination
+// @Tags Notification
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Security BearerAuth
+// @Success 200 {object} dto.PaginatedNotificationsResponse
+// @Failure 401 {object} map[string]string
+// @Router /api/notifications/me [get]
+func (c *notificationController) GetMyNotifications(ctx *gin.Context) {
+	userIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	response, err := c.notificationService.GetUserNotifications(userID, page, limit)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Notifications retrieved", response)
+}
+
+// @Summary Mark Notification as Read
+// @Description Mark one notification of the logged-in user as read
+// @Tags Notification
+// @Produce json
+// @Param id path string true "Notification ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/notifications/{id}/read [patch]
+func (c *notificationController) MarkAsRead(ctx *gin.Context) {
+	userIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid notification ID")
+		return
+	}
+
+	if err := c.notificationService.MarkAsRead(userID, id); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Notification marked as read", nil)
+}
+
+// @Summary Mark All Notifications as Read
+// @Description Mark every notification of the logged-in user as read
+// @Tags Notification
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /api/notifications/read-all [patch]
+func (c *notificationController) MarkAllAsRead(ctx *gin.Context) {
+	userIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	if err := c.notificationService.MarkAllAsRead(userID); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Notifications marked as read", nil)
+}
@@ -0,0 +1,303 @@
+package controllers
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strings"
+
+	"dinacom-11.0-backend/models/dto"
+	"dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Open311Controller serves the GeoReport v2 endpoints. Responses are not
+// wrapped like the rest of the API, as Open311 clients expect the bare lists
+// and errors the spec describes, in JSON or XML after the path's extension.
+type Open311Controller interface {
+	GetServices(ctx *gin.Context)
+	CreateRequest(ctx *gin.Context)
+	GetRequests(ctx *gin.Context)
+	GetRequest(ctx *gin.Context)
+	GetToken(ctx *gin.Context)
+}
+
+type open311Controller struct {
+	open311Service services.Open311Service
+	apiKeyService  services.APIKeyService
+}
+
+func NewOpen311Controller(open311Service services.Open311Service, apiKeyService services.APIKeyService) Open311Controller {
+	return &open311Controller{open311Service: open311Service, apiKeyService: apiKeyService}
+}
+
+// @Summary Open311 Services
+// @Description List the GeoReport v2 services: one per road damage class plus road_damage for damage not classified yet
+// @Tags Open311
+// @Produce json,xml
+// @Param format path string true "json or xml"
+// @Success 200 {array} dto.Open311Service
+// @Router /api/open311/v2/services.{format} [get]
+func (c *open311Controller) GetServices(ctx *gin.Context) {
+	format, ok := open311Format(ctx, ctx.Param("format"))
+	if !ok {
+		return
+	}
+
+	serviceList, err := c.open311Service.GetServices()
+	if err != nil {
+		sendOpen311Error(ctx, format, err)
+		return
+	}
+
+	renderOpen311(ctx, format, http.StatusOK, serviceList, dto.Open311ServiceList{Services: serviceList})
+}
+
+// @Summary Open311 Create Service Request
+// @Description File a road damage report as a GeoReport v2 service request. Needs an api_key with the reports:create permission and a location as lat and long. media_url is kept as the report photo
+// @Tags Open311
+// @Accept x-www-form-urlencoded
+// @Produce json,xml
+// @Param format path string true "json or xml"
+// @Param api_key formData string true "API key"
+// @Param service_code formData string true "Service code from the services list"
+// @Param lat formData number true "Latitude"
+// @Param long formData number true "Longitude"
+// @Param address_string formData string false "Road name"
+// @Param description formData string false "Description"
+// @Param media_url formData string false "Photo URL"
+// @Param account_id formData string false "Client account, echoed back"
+// @Success 201 {array} dto.Open311CreateResponse
+// @Failure 400 {array} dto.Open311Error
+// @Failure 403 {array} dto.Open311Error
+// @Router /api/open311/v2/requests.{format} [post]
+func (c *open311Controller) CreateRequest(ctx *gin.Context) {
+	format, ok := open311Format(ctx, ctx.Param("format"))
+	if !ok {
+		return
+	}
+	key, ok := c.authenticate(ctx, format, entity.PERMISSION_REPORTS_CREATE)
+	if !ok {
+		return
+	}
+
+	var req dto.Open311CreateRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		sendOpen311Error(ctx, format, http_error.BAD_REQUEST_ERROR)
+		return
+	}
+
+	response, err := c.open311Service.CreateRequest(key.ID, req)
+	if err != nil {
+		sendOpen311Error(ctx, format, err)
+		return
+	}
+
+	responses := []dto.Open311CreateResponse{*response}
+	renderOpen311(ctx, format, http.StatusCreated, responses, dto.Open311CreateResponseList{Requests: responses})
+}
+
+// @Summary Open311 Service Requests
+// @Description List GeoReport v2 service requests, newest first and at most 1000. Without dates the last 90 days are listed; with service_request_id the other filters are ignored. Needs an api_key with the reports:read permission
+// @Tags Open311
+// @Produce json,xml
+// @Param format path string true "json or xml"
+// @Param api_key query string true "API key"
+// @Param service_request_id query string false "Comma separated service request IDs"
+// @Param service_code query string false "Service code"
+// @Param start_date query string false "Requested from (RFC3339 or YYYY-MM-DD)"
+// @Param end_date query string false "Requested to (RFC3339 or YYYY-MM-DD)"
+// @Param status query string false "open or closed"
+// @Success 200 {array} dto.Open311ServiceRequest
+// @Failure 400 {array} dto.Open311Error
+// @Failure 403 {array} dto.Open311Error
+// @Router /api/open311/v2/requests.{format} [get]
+func (c *open311Controller) GetRequests(ctx *gin.Context) {
+	format, ok := open311Format(ctx, ctx.Param("format"))
+	if !ok {
+		return
+	}
+	if _, ok := c.authenticate(ctx, format, entity.PERMISSION_REPORTS_READ); !ok {
+		return
+	}
+
+	query := dto.Open311RequestsQuery{
+		ServiceCode: strings.TrimSpace(ctx.Query("service_code")),
+		Status:      strings.ToLower(strings.TrimSpace(ctx.Query("status"))),
+	}
+	for _, id := range strings.Split(ctx.Query("service_request_id"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			query.ServiceRequestIDs = append(query.ServiceRequestIDs, id)
+		}
+	}
+	var err error
+	if query.StartDate, err = parseTimeQuery(ctx.Query("start_date"), false); err != nil {
+		writeOpen311Error(ctx, format, http.StatusBadRequest, "Invalid start_date")
+		return
+	}
+	if query.EndDate, err = parseTimeQuery(ctx.Query("end_date"), true); err != nil {
+		writeOpen311Error(ctx, format, http.StatusBadRequest, "Invalid end_date")
+		return
+	}
+
+	requests, err := c.open311Service.GetRequests(query)
+	if err != nil {
+		sendOpen311Error(ctx, format, err)
+		return
+	}
+
+	renderOpen311(ctx, format, http.StatusOK, requests, dto.Open311ServiceRequestList{Requests: requests})
+}
+
+// @Summary Open311 Service Request
+// @Description Get one GeoReport v2 service request. Needs an api_key with the reports:read permission
+// @Tags Open311
+// @Produce json,xml
+// @Param id path string true "Service request ID followed by .json or .xml"
+// @Param api_key query string true "API key"
+// @Success 200 {array} dto.Open311ServiceRequest
+// @Failure 403 {array} dto.Open311Error
+// @Failure 404 {array} dto.Open311Error
+// @Router /api/open311/v2/requests/{id} [get]
+func (c *open311Controller) GetRequest(ctx *gin.Context) {
+	serviceRequestID, format, ok := splitOpen311Format(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+	if _, ok := c.authenticate(ctx, format, entity.PERMISSION_REPORTS_READ); !ok {
+		return
+	}
+
+	request, err := c.open311Service.GetRequest(serviceRequestID)
+	if err != nil {
+		sendOpen311Error(ctx, format, err)
+		return
+	}
+
+	requests := []dto.Open311ServiceRequest{*request}
+	renderOpen311(ctx, format, http.StatusOK, requests, dto.Open311ServiceRequestList{Requests: requests})
+}
+
+// @Summary Open311 Token
+// @Description Get the service request ID a token returned on creation stands for. Needs an api_key with the reports:read permission
+// @Tags Open311
+// @Produce json,xml
+// @Param token path string true "Token followed by .json or .xml"
+// @Param api_key query string true "API key"
+// @Success 200 {array} dto.Open311Token
+// @Failure 403 {array} dto.Open311Error
+// @Failure 404 {array} dto.Open311Error
+// @Router /api/open311/v2/tokens/{token} [get]
+func (c *open311Controller) GetToken(ctx *gin.Context) {
+	token, format, ok := splitOpen311Format(ctx, ctx.Param("token"))
+	if !ok {
+		return
+	}
+	if _, ok := c.authenticate(ctx, format, entity.PERMISSION_REPORTS_READ); !ok {
+		return
+	}
+
+	response, err := c.open311Service.GetToken(token)
+	if err != nil {
+		sendOpen311Error(ctx, format, err)
+		return
+	}
+
+	tokens := []dto.Open311Token{*response}
+	renderOpen311(ctx, format, http.StatusOK, tokens, dto.Open311TokenList{Requests: tokens})
+}
+
+// authenticate checks the api_key parameter, or the key in the headers the
+// auth middleware accepts, for the given permission.
+func (c *open311Controller) authenticate(ctx *gin.Context, format string, permission string) (*entity.APIKey, bool) {
+	rawKey := ctx.Request.FormValue("api_key")
+	if rawKey == "" {
+		rawKey = ctx.GetHeader("X-API-Key")
+	}
+	if authHeader := ctx.GetHeader("Authorization"); rawKey == "" && strings.HasPrefix(authHeader, "ApiKey ") {
+		rawKey = strings.TrimPrefix(authHeader, "ApiKey ")
+	}
+	if strings.TrimSpace(rawKey) == "" {
+		sendOpen311Error(ctx, format, http_error.API_KEY_REQUIRED)
+		return nil, false
+	}
+
+	key, err := c.apiKeyService.Authenticate(rawKey, ctx.ClientIP())
+	if err != nil {
+		sendOpen311Error(ctx, format, err)
+		return nil, false
+	}
+	if !key.HasPermission(permission) {
+		sendOpen311Error(ctx, format, http_error.API_KEY_PERMISSION_DENIED)
+		return nil, false
+	}
+	return key, true
+}
+
+// open311Format accepts json and xml and answers anything else with a JSON
+// not found error.
+func open311Format(ctx *gin.Context, format string) (string, bool) {
+	format = strings.ToLower(format)
+	if format != entity.OPEN311_FORMAT_JSON && format != entity.OPEN311_FORMAT_XML {
+		writeOpen311Error(ctx, entity.OPEN311_FORMAT_JSON, http.StatusNotFound, "format must be json or xml")
+		return "", false
+	}
+	return format, true
+}
+
+// splitOpen311Format splits "<id>.<format>" at the last dot, as service
+// request IDs contain dots themselves.
+func splitOpen311Format(ctx *gin.Context, value string) (string, string, bool) {
+	dot := strings.LastIndex(value, ".")
+	if dot < 0 {
+		_, ok := open311Format(ctx, "")
+		return "", "", ok
+	}
+	format, ok := open311Format(ctx, value[dot+1:])
+	return value[:dot], format, ok
+}
+
+func renderOpen311(ctx *gin.Context, format string, status int, jsonBody interface{}, xmlBody interface{}) {
+	if format == entity.OPEN311_FORMAT_JSON {
+		ctx.JSON(status, jsonBody)
+		return
+	}
+
+	body, err := xml.Marshal(xmlBody)
+	if err != nil {
+		writeOpen311Error(ctx, entity.OPEN311_FORMAT_JSON, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.Data(status, "application/xml; charset=utf-8", append([]byte(xml.Header), body...))
+}
+
+func sendOpen311Error(ctx *gin.Context, format string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, http_error.API_KEY_REQUIRED),
+		errors.Is(err, http_error.INVALID_API_KEY),
+		errors.Is(err, http_error.API_KEY_PERMISSION_DENIED):
+		status = http.StatusForbidden
+	case errors.Is(err, http_error.REPORT_NOT_FOUND),
+		errors.Is(err, http_error.INVALID_OPEN311_TOKEN):
+		status = http.StatusNotFound
+	case errors.Is(err, http_error.BAD_REQUEST_ERROR),
+		errors.Is(err, http_error.OPEN311_SERVICE_NOT_FOUND),
+		errors.Is(err, http_error.OPEN311_LOCATION_REQUIRED),
+		errors.Is(err, http_error.INVALID_MEDIA_URL),
+		errors.Is(err, http_error.INVALID_OPEN311_STATUS),
+		errors.Is(err, http_error.INVALID_COORDINATES),
+		errors.Is(err, http_error.COORDINATES_SWAPPED),
+		errors.Is(err, http_error.OUTSIDE_SERVICE_AREA):
+		status = http.StatusBadRequest
+	}
+	writeOpen311Error(ctx, format, status, err.Error())
+}
+
+func writeOpen311Error(ctx *gin.Context, format string, status int, description string) {
+	errs := []dto.Open311Error{{Code: status, Description: description}}
+	renderOpen311(ctx, format, status, errs, dto.Open311ErrorList{Errors: errs})
+}
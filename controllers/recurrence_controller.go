@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"net/http"
+
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecurrenceController interface {
+	GetWorkerRecurrences(ctx *gin.Context)
+	GetContractorRecurrences(ctx *gin.Context)
+}
+
+type recurrenceController struct {
+	recurrenceService services.RecurrenceService
+	regionService     services.RegionService
+}
+
+func NewRecurrenceController(recurrenceService services.RecurrenceService, regionService services.RegionService) RecurrenceController {
+	return &recurrenceController{recurrenceService: recurrenceService, regionService: regionService}
+}
+
+// @Summary Get Worker Recurrence Rates
+// @Description Repairs finished per worker and how many had damage reported again close by within the recurrence window. Crew members share the repairs they worked on (Admin or supervisor)
+// @Tags Admin
+// @Produce json
+// @Param from query string false "Finished from (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Finished to (RFC3339 or YYYY-MM-DD)"
+// @Param region query string false "Region code. Admins limited to regions only see their own"
+// @Security BearerAuth
+// @Success 200 {array} dto.WorkerRecurrenceResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/recurrences/workers [get]
+func (c *recurrenceController) GetWorkerRecurrences(ctx *gin.Context) {
+	from, err := parseTimeQuery(ctx.Query("from"), false)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid from date")
+		return
+	}
+	to, err := parseTimeQuery(ctx.Query("to"), true)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid to date")
+		return
+	}
+	regionCodes, ok := regionFilter(ctx, c.regionService)
+	if !ok {
+		return
+	}
+
+	rates, err := c.recurrenceService.GetWorkerRecurrences(from, to, regionCodes)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Worker recurrence rates retrieved successfully", rates)
+}
+
+// @Summary Get Contractor Recurrence Rates
+// @Description Repairs finished per contractor of the workers on them and how many had damage reported again close by. An empty contractor stands for in-house workers (Admin or supervisor)
+// @Tags Admin
+// @Produce json
+// @Param from query string false "Finished from (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Finished to (RFC3339 or YYYY-MM-DD)"
+// @Param region query string false "Region code. Admins limited to regions only see their own"
+// @Security BearerAuth
+// @Success 200 {array} dto.ContractorRecurrenceResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/recurrences/contractors [get]
+func (c *recurrenceController) GetContractorRecurrences(ctx *gin.Context) {
+	from, err := parseTimeQuery(ctx.Query("from"), false)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid from date")
+		return
+	}
+	to, err := parseTimeQuery(ctx.Query("to"), true)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid to date")
+		return
+	}
+	regionCodes, ok := regionFilter(ctx, c.regionService)
+	if !ok {
+		return
+	}
+
+	rates, err := c.recurrenceService.GetContractorRecurrences(from, to, regionCodes)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Contractor recurrence rates retrieved successfully", rates)
+}
@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"dinacom-11.0-backend/models/dto"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RegionController interface {
+	GetRegions(ctx *gin.Context)
+	ReloadBoundaries(ctx *gin.Context)
+	GetAdminRegions(ctx *gin.Context)
+	SetAdminRegions(ctx *gin.Context)
+}
+
+type regionController struct {
+	regionService services.RegionService
+}
+
+func NewRegionController(regionService services.RegionService) RegionController {
+	return &regionController{regionService: regionService}
+}
+
+// @Summary Get Regions
+// @Description List the administrative regions, optionally of one level or inside a parent region (Admin only)
+// @Tags Admin
+// @Produce json
+// @Param level query string false "province, regency, district or village"
+// @Param parent query string false "Parent region code"
+// @Security BearerAuth
+// @Success 200 {array} dto.RegionResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/regions [get]
+func (c *regionController) GetRegions(ctx *gin.Context) {
+	regions, err := c.regionService.GetRegions(ctx.Query("level"), ctx.Query("parent"))
+	if err != nil {
+		sendRegionError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Regions retrieved successfully", regions)
+}
+
+// @Summary Reload Region Boundaries
+// @Description Import the region boundary GeoJSON files again and place every report in its region anew in the background (Admin not limited to regions)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.RegionReloadResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /api/admin/regions/reload [post]
+func (c *regionController) ReloadBoundaries(ctx *gin.Context) {
+	if len(utils.GetRegionScope(ctx)) > 0 {
+		utils.SendErrorResponse(ctx, http.StatusForbidden, http_error.ONLY_UNSCOPED_ADMIN.Error())
+		return
+	}
+
+	response, err := c.regionService.ReloadBoundaries(utils.GetAuditContext(ctx))
+	if err != nil {
+		sendRegionError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Region boundaries reloaded", response)
+}
+
+// @Summary Get Admin Regions
+// @Description Get the regions an admin is limited to. An empty list means the admin sees every report (Admin only)
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Admin user ID"
+// @Security BearerAuth
+// @Success 200 {object} dto.AdminRegionsResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/users/{id}/regions [get]
+func (c *regionController) GetAdminRegions(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	response, err := c.regionService.GetAdminRegions(userID)
+	if err != nil {
+		sendRegionError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Admin regions retrieved successfully", response)
+}
+
+// @Summary Set Admin Regions
+// @Description Limit an admin to the reports of the given regions and the regions inside them. An empty list lifts the limit (Admin not limited to regions)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Admin user ID"
+// @Param request body dto.AdminRegionsRequest true "Admin Regions Request"
+// @Security BearerAuth
+// @Success 200 {object} dto.AdminRegionsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/users/{id}/regions [put]
+func (c *regionController) SetAdminRegions(ctx *gin.Context) {
+	if len(utils.GetRegionScope(ctx)) > 0 {
+		utils.SendErrorResponse(ctx, http.StatusForbidden, http_error.ONLY_UNSCOPED_ADMIN.Error())
+		return
+	}
+
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req dto.AdminRegionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response, err := c.regionService.SetAdminRegions(utils.GetAuditContext(ctx), userID, req)
+	if err != nil {
+		sendRegionError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Admin regions updated", response)
+}
+
+// regionFilter resolves the region query parameter against the admin's
+// jurisdiction. When it cannot be used the error response is already sent.
+func regionFilter(ctx *gin.Context, regionService services.RegionService) ([]string, bool) {
+	regionCodes, err := regionService.ResolveFilter(utils.GetRegionScope(ctx), ctx.Query("region"))
+	if err != nil {
+		sendRegionError(ctx, err)
+		return nil, false
+	}
+	return regionCodes, true
+}
+
+// reportInScope answers with 403 when the report lies outside the admin's
+// jurisdiction.
+func reportInScope(ctx *gin.Context, regionService services.RegionService, reportID string) bool {
+	inside, err := regionService.ReportInScope(utils.GetRegionScope(ctx), reportID)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return false
+	}
+	if !inside {
+		utils.SendErrorResponse(ctx, http.StatusForbidden, http_error.REPORT_OUT_OF_SCOPE.Error())
+		return false
+	}
+	return true
+}
+
+func sendRegionError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, http_error.REGION_NOT_FOUND), errors.Is(err, http_error.ACCOUNT_NOT_FOUND):
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, http_error.REGION_OUT_OF_SCOPE):
+		utils.SendErrorResponse(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, http_error.INVALID_REGION_LEVEL), errors.Is(err, http_error.INVALID_REGION_BOUNDARY),
+		errors.Is(err, http_error.REGION_BOUNDARIES_NOT_SET), errors.Is(err, http_error.REGIONS_ONLY_FOR_ADMINS):
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+	default:
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -2,10 +2,16 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
 	"dinacom-11.0-backend/services"
 	"dinacom-11.0-backend/utils"
 
@@ -16,6 +22,7 @@ import (
 type ReportController interface {
 	CreateReport(ctx *gin.Context)
 	GetReports(ctx *gin.Context)
+	SearchReports(ctx *gin.Context)
 	AssignWorker(ctx *gin.Context)
 	GetAssignedReports(ctx *gin.Context)
 	FinishReport(ctx *gin.Context)
@@ -23,18 +30,37 @@ type ReportController interface {
 	GetWorkerAssignedReports(ctx *gin.Context)
 	GetWorkerHistory(ctx *gin.Context)
 	VerifyReport(ctx *gin.Context)
+	GetPendingReports(ctx *gin.Context)
+	ClassifyReport(ctx *gin.Context)
+	DeleteReport(ctx *gin.Context)
+	RejectReport(ctx *gin.Context)
+	ForwardReport(ctx *gin.Context)
+	ReworkReport(ctx *gin.Context)
+	GetReportReworks(ctx *gin.Context)
+	AcceptAssignment(ctx *gin.Context)
+	DeclineAssignment(ctx *gin.Context)
+	ReassignWorker(ctx *gin.Context)
+	UnassignWorker(ctx *gin.Context)
+	GetReportAssignments(ctx *gin.Context)
+	GetReport(ctx *gin.Context)
+	AssignTeam(ctx *gin.Context)
+	SubmitProgress(ctx *gin.Context)
+	GetReportProgress(ctx *gin.Context)
+	GetMyReportProgress(ctx *gin.Context)
+	GetReportTimeline(ctx *gin.Context)
 }
 
 type reportController struct {
 	reportService services.ReportService
+	regionService services.RegionService
 }
 
-func NewReportController(reportService services.ReportService) ReportController {
-	return &reportController{reportService: reportService}
+func NewReportController(reportService services.ReportService, regionService services.RegionService) ReportController {
+	return &reportController{reportService: reportService, regionService: regionService}
 }
 
 // @Summary Create Report
-// @Description Submit a new report with image and location data
+// @Description Submit a new report with image and location data. road_name is optional; when left empty the road name found for the location is used
 // @Tags Report
 // @Accept multipart/form-data
 // @Produce json
@@ -81,31 +107,139 @@ func (c *reportController) CreateReport(ctx *gin.Context) {
 }
 
 // @Summary Get Reports
-// @Description Get all completed reports with non-good destruct class
+// @Description Get completed reports with non-good destruct class, most severe first, optionally inside a bounding box, a radius and a GeoJSON polygon. Below zoom 16 nearby reports are merged and count says how many each point stands for. X-Result-Truncated is true when the limit cut the result
 // @Tags Report
 // @Produce json
+// @Param bbox query string false "min_lng,min_lat,max_lng,max_lat"
+// @Param lat query number false "Circle centre latitude"
+// @Param lng query number false "Circle centre longitude"
+// @Param radius_km query number false "Circle radius in km (max 100)"
+// @Param polygon query string false "GeoJSON Polygon geometry"
+// @Param zoom query int false "Map zoom level (0-22)"
+// @Param limit query int false "Maximum reports (max 2000)" default(500)
 // @Success 200 {array} dto.ReportLocationResponse
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/get_report [get]
 func (c *reportController) GetReports(ctx *gin.Context) {
-	reports, err := c.reportService.GetReports()
+	req, err := parseReportSearchQuery(ctx)
 	if err != nil {
-		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.reportService.SearchReports(req)
+	if err != nil {
+		sendSearchError(ctx, err)
 		return
 	}
 
-	utils.SendSuccessResponse(ctx, "Reports retrieved", reports)
+	ctx.Header("X-Result-Truncated", strconv.FormatBool(result.Truncated))
+	utils.SendSuccessResponse(ctx, "Reports retrieved", result.Reports)
+}
+
+// @Summary Search Reports
+// @Description Same as GET /api/get_report with the filters in the body, for polygons too long for a URL
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Param request body dto.ReportSearchRequest true "Report Search Request"
+// @Success 200 {object} dto.ReportSearchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/get_report/search [post]
+func (c *reportController) SearchReports(ctx *gin.Context) {
+	var req dto.ReportSearchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.reportService.SearchReports(req)
+	if err != nil {
+		sendSearchError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Reports retrieved", result)
+}
+
+func parseReportSearchQuery(ctx *gin.Context) (dto.ReportSearchRequest, error) {
+	var req dto.ReportSearchRequest
+
+	if bbox := ctx.Query("bbox"); bbox != "" {
+		parts := strings.Split(bbox, ",")
+		if len(parts) != 4 {
+			return req, http_error.INVALID_BBOX
+		}
+		for _, part := range parts {
+			value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				return req, http_error.INVALID_BBOX
+			}
+			req.BBox = append(req.BBox, value)
+		}
+	}
+
+	for key, target := range map[string]**float64{"lat": &req.Latitude, "lng": &req.Longitude} {
+		if raw := ctx.Query(key); raw != "" {
+			value, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return req, http_error.INVALID_RADIUS
+			}
+			*target = &value
+		}
+	}
+	if raw := ctx.Query("radius_km"); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return req, http_error.INVALID_RADIUS
+		}
+		req.RadiusKm = value
+	}
+
+	if raw := ctx.Query("polygon"); raw != "" {
+		var polygon dto.GeoJSONGeometry
+		if err := json.Unmarshal([]byte(raw), &polygon); err != nil {
+			return req, http_error.INVALID_POLYGON
+		}
+		req.Polygon = &polygon
+	}
+
+	if raw := ctx.Query("zoom"); raw != "" {
+		zoom, err := strconv.Atoi(raw)
+		if err != nil {
+			return req, http_error.INVALID_ZOOM
+		}
+		req.Zoom = &zoom
+	}
+
+	req.Limit, _ = strconv.Atoi(ctx.Query("limit"))
+	return req, nil
+}
+
+func sendSearchError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, http_error.INVALID_BBOX), errors.Is(err, http_error.INVALID_RADIUS),
+		errors.Is(err, http_error.INVALID_POLYGON), errors.Is(err, http_error.INVALID_ZOOM),
+		errors.Is(err, http_error.AGGREGATE_AREA_TOO_LARGE):
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+	default:
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	}
 }
 
 // @Summary Assign Worker to Report
-// @Description Admin assigns a worker to a report
+// @Description Admin assigns a worker to a report. Workers on leave or at their job limit are refused unless force is set; off-shift workers only produce a warning. Without a deadline the matching SLA policy sets one.
 // @Tags Admin
 // @Accept json
 // @Produce json
 // @Param request body dto.AssignWorkerRequest true "Assign Worker Request"
+// @Param If-Match header string false "Report version from the ETag"
 // @Security BearerAuth
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Router /api/admin/report/assign [patch]
 func (c *reportController) AssignWorker(ctx *gin.Context) {
 	var req dto.AssignWorkerRequest
@@ -114,25 +248,88 @@ func (c *reportController) AssignWorker(ctx *gin.Context) {
 		return
 	}
 
-	message, err := c.reportService.AssignWorker(req)
+	if !reportInScope(ctx, c.regionService, req.ReportID) {
+		return
+	}
+
+	version, err := ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.Version = version
+
+	message, err := c.reportService.AssignWorker(utils.GetAuditContext(ctx), req)
+	if err != nil {
+		sendReportError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	setNextReportETag(ctx, req.Version)
+	utils.SendSuccessResponse(ctx, message, nil)
+}
+
+// @Summary Assign Team to Report
+// @Description Admin assigns a team to a report. The team leader holds the assignment and accepts and finishes it; the current members are recorded as the crew. Availability is checked for the leader.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dto.AssignTeamRequest true "Assign Team Request"
+// @Param If-Match header string false "Report version from the ETag"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/report/assign-team [patch]
+func (c *reportController) AssignTeam(ctx *gin.Context) {
+	var req dto.AssignTeamRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !reportInScope(ctx, c.regionService, req.ReportID) {
+		return
+	}
+
+	version, err := ifMatchVersion(ctx, req.Version)
 	if err != nil {
 		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
+	req.Version = version
+
+	message, err := c.reportService.AssignTeam(utils.GetAuditContext(ctx), req)
+	if err != nil {
+		if errors.Is(err, http_error.TEAM_NOT_FOUND) {
+			utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		sendReportError(ctx, http.StatusBadRequest, err)
+		return
+	}
 
+	setNextReportETag(ctx, req.Version)
 	utils.SendSuccessResponse(ctx, message, nil)
 }
 
 // @Summary Get Assigned Workers
-// @Description Get all workers with assigned reports
+// @Description Get all workers with assigned reports, including whether the worker has accepted the assignment
 // @Tags Admin
 // @Produce json
+// @Param region query string false "Region code. Admins limited to regions only see their own"
 // @Security BearerAuth
 // @Success 200 {array} dto.AssignedWorkerResponse
 // @Failure 500 {object} map[string]string
 // @Router /api/admin/report/assign [get]
 func (c *reportController) GetAssignedReports(ctx *gin.Context) {
-	reports, err := c.reportService.GetAssignedReports()
+	regionCodes, ok := regionFilter(ctx, c.regionService)
+	if !ok {
+		return
+	}
+
+	reports, err := c.reportService.GetAssignedReports(regionCodes)
 	if err != nil {
 		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
@@ -142,15 +339,17 @@ func (c *reportController) GetAssignedReports(ctx *gin.Context) {
 }
 
 // @Summary Finish Report by Worker
-// @Description Worker uploads after image and marks report as finished
+// @Description Worker uploads after image and marks report as finished. This records the done stage of the work order; see /api/worker/report/progress for the earlier stages
 // @Tags Worker
 // @Accept multipart/form-data
 // @Produce json
 // @Param files formData file true "After image file"
-// @Param json formData string true "JSON data" default({"report_id": "uuid-here"})
+// @Param json formData string true "JSON data" default({"report_id": "uuid-here", "materials": [{"material_id": "uuid-here", "quantity": 0.5}]})
+// @Param If-Match header string false "Report version from the ETag"
 // @Security BearerAuth
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Router /api/worker/report [patch]
 func (c *reportController) FinishReport(ctx *gin.Context) {
 	workerIDVal, exists := ctx.Get("user_id")
@@ -179,11 +378,23 @@ func (c *reportController) FinishReport(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.reportService.FinishReport(workerID, file, header, req.ReportID); err != nil {
+	version, err := ifMatchVersion(ctx, req.Version)
+	if err != nil {
 		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
+	req.Version = version
+
+	if err := c.reportService.FinishReport(workerID, file, header, req); err != nil {
+		if errors.Is(err, http_error.ONLY_TEAM_LEADER_FINISH) {
+			utils.SendErrorResponse(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+		sendReportError(ctx, http.StatusBadRequest, err)
+		return
+	}
 
+	setNextReportETag(ctx, req.Version)
 	utils.SendSuccessResponse(ctx, "Report finished successfully", nil)
 }
 
@@ -303,9 +514,11 @@ func (c *reportController) GetWorkerHistory(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request body dto.VerifyReportRequest true "Verify Report Request"
+// @Param If-Match header string false "Report version from the ETag"
 // @Security BearerAuth
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Router /api/admin/report/verify [patch]
 func (c *reportController) VerifyReport(ctx *gin.Context) {
 	var req dto.VerifyReportRequest
@@ -314,10 +527,672 @@ func (c *reportController) VerifyReport(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.reportService.VerifyReport(req.ReportID); err != nil {
+	if !reportInScope(ctx, c.regionService, req.ReportID) {
+		return
+	}
+
+	version, err := ifMatchVersion(ctx, req.Version)
+	if err != nil {
 		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
+	req.Version = version
+
+	if err := c.reportService.VerifyReport(utils.GetAuditContext(ctx), req); err != nil {
+		sendReportError(ctx, http.StatusBadRequest, err)
+		return
+	}
 
+	setNextReportETag(ctx, req.Version)
 	utils.SendSuccessResponse(ctx, "Report verified successfully", nil)
 }
+
+// @Summary Get Pending Reports
+// @Description Get reports waiting for damage classification (API key with reports:read)
+// @Tags Service
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param region query string false "Region code. Admins limited to regions only see their own"
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.PaginatedReportsResponse
+// @Failure 401 {object} map[string]string
+// @Router /api/service/report/pending [get]
+func (c *reportController) GetPendingReports(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	regionCodes, ok := regionFilter(ctx, c.regionService)
+	if !ok {
+		return
+	}
+
+	response, err := c.reportService.GetPendingReports(regionCodes, page, limit)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Pending reports retrieved", response)
+}
+
+// @Summary Classify Report
+// @Description Store the damage classification of a pending report (API key with reports:classify)
+// @Tags Service
+// @Accept json
+// @Produce json
+// @Param request body dto.ClassifyReportRequest true "Classify Report Request"
+// @Param If-Match header string false "Report version from the ETag"
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/service/report/classify [patch]
+func (c *reportController) ClassifyReport(ctx *gin.Context) {
+	var req dto.ClassifyReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !reportInScope(ctx, c.regionService, req.ReportID) {
+		return
+	}
+
+	version, err := ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.Version = version
+
+	if err := c.reportService.ClassifyReport(utils.GetAuditContext(ctx), req); err != nil {
+		sendReportError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	setNextReportETag(ctx, req.Version)
+	utils.SendSuccessResponse(ctx, "Report classified successfully", nil)
+}
+
+// @Summary Delete Report
+// @Description Admin deletes a report
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Report ID"
+// @Param If-Match header string false "Report version from the ETag"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/report/{id} [delete]
+func (c *reportController) DeleteReport(ctx *gin.Context) {
+	if !reportInScope(ctx, c.regionService, ctx.Param("id")) {
+		return
+	}
+
+	version, err := ifMatchVersion(ctx, nil)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.reportService.DeleteReport(utils.GetAuditContext(ctx), ctx.Param("id"), version); err != nil {
+		sendReportError(ctx, http.StatusNotFound, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Report deleted successfully", nil)
+}
+
+// @Summary Reject Report
+// @Description Admin rejects a spam, out-of-area or non-road report. The citizen is notified.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dto.RejectReportRequest true "Reject Report Request (reason_code: spam, out_of_area, not_road, duplicate, other)"
+// @Param If-Match header string false "Report version from the ETag"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/report/reject [patch]
+func (c *reportController) RejectReport(ctx *gin.Context) {
+	var req dto.RejectReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !reportInScope(ctx, c.regionService, req.ReportID) {
+		return
+	}
+
+	version, err := ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.Version = version
+
+	if err := c.reportService.RejectReport(utils.GetAuditContext(ctx), req); err != nil {
+		sendReportError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	setNextReportETag(ctx, req.Version)
+	utils.SendSuccessResponse(ctx, "Report rejected successfully", nil)
+}
+
+// @Summary Forward Report
+// @Description Admin flags a report on a road outside our authority for forwarding to the responsible agency. The authority defaults to the one of the snapped road segment. The citizen is notified.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dto.ForwardReportRequest true "Forward Report Request (authority: national, provincial, regency, city, village)"
+// @Param If-Match header string false "Report version from the ETag"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/report/forward [patch]
+func (c *reportController) ForwardReport(ctx *gin.Context) {
+	var req dto.ForwardReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !reportInScope(ctx, c.regionService, req.ReportID) {
+		return
+	}
+
+	version, err := ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.Version = version
+
+	if err := c.reportService.ForwardReport(utils.GetAuditContext(ctx), req); err != nil {
+		sendReportError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	setNextReportETag(ctx, req.Version)
+	utils.SendSuccessResponse(ctx, "Report forwarded successfully", nil)
+}
+
+// @Summary Send Report Back for Rework
+// @Description Admin rejects the worker's completion with notes and optionally a new deadline. The report returns to 'assigned' and the previous after image is archived.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dto.ReworkReportRequest true "Rework Report Request"
+// @Param If-Match header string false "Report version from the ETag"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/report/rework [patch]
+func (c *reportController) ReworkReport(ctx *gin.Context) {
+	var req dto.ReworkReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !reportInScope(ctx, c.regionService, req.ReportID) {
+		return
+	}
+
+	version, err := ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.Version = version
+
+	if err := c.reportService.ReworkReport(utils.GetAuditContext(ctx), req); err != nil {
+		sendReportError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	setNextReportETag(ctx, req.Version)
+	utils.SendSuccessResponse(ctx, "Report sent back for rework", nil)
+}
+
+// @Summary Get Report Rework History
+// @Description Get archived completions that were sent back for rework
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Report ID"
+// @Security BearerAuth
+// @Success 200 {array} dto.ReportReworkResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/report/{id}/reworks [get]
+func (c *reportController) GetReportReworks(ctx *gin.Context) {
+	if !reportInScope(ctx, c.regionService, ctx.Param("id")) {
+		return
+	}
+
+	reworks, err := c.reportService.GetReportReworks(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Report reworks retrieved", reworks)
+}
+
+// @Summary Get Work Order
+// @Description Get the work order of a report: its current stage and every update posted by the worker or crew
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Report ID"
+// @Security BearerAuth
+// @Success 200 {object} dto.WorkOrderResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/report/{id}/progress [get]
+func (c *reportController) GetReportProgress(ctx *gin.Context) {
+	if !reportInScope(ctx, c.regionService, ctx.Param("id")) {
+		return
+	}
+
+	workOrder, err := c.reportService.GetWorkOrder(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Work order retrieved", workOrder)
+}
+
+// @Summary Submit Work Order Update
+// @Description The assigned worker or any crew member posts a note with optional photos. The assigned worker or team leader may set a later stage (surveyed, materials_requested, in_progress, done); done needs a photo and finishes the report
+// @Tags Worker
+// @Accept multipart/form-data
+// @Produce json
+// @Param files formData file false "Image files (JPG, PNG, JPEG, max 32MB each, up to 10)"
+// @Param json formData string true "JSON data" default({"report_id": "report-id", "stage": "materials_requested", "note": "Asphalt ordered"})
+// @Param If-Match header string false "Report version from the ETag"
+// @Security BearerAuth
+// @Success 200 {object} dto.ReportProgressResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/worker/report/progress [post]
+func (c *reportController) SubmitProgress(ctx *gin.Context) {
+	workerIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	workerID := workerIDVal.(uuid.UUID)
+
+	var files []*multipart.FileHeader
+	if form, err := ctx.MultipartForm(); err == nil {
+		files = form.File["files"]
+	}
+
+	jsonData := ctx.PostForm("json")
+	if jsonData == "" {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "JSON data is required")
+		return
+	}
+
+	var req dto.ReportProgressRequest
+	if err := json.Unmarshal([]byte(jsonData), &req); err != nil || req.ReportID == "" {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if len(files) == 0 && req.Stage == "" && strings.TrimSpace(req.Note) == "" {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "A stage, note or photo is required")
+		return
+	}
+
+	version, err := ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.Version = version
+
+	progress, err := c.reportService.SubmitProgress(workerID, files, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, http_error.REPORT_NOT_FOUND):
+			utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, http_error.NOT_ASSIGNED_TO_REPORT), errors.Is(err, http_error.ONLY_TEAM_LEADER_FINISH), errors.Is(err, http_error.ONLY_ASSIGNEE_CHANGE_STAGE):
+			utils.SendErrorResponse(ctx, http.StatusForbidden, err.Error())
+		default:
+			sendReportError(ctx, http.StatusBadRequest, err)
+		}
+		return
+	}
+
+	if req.Stage != "" {
+		setNextReportETag(ctx, req.Version)
+	}
+	utils.SendSuccessResponse(ctx, "Progress submitted", progress)
+}
+
+// @Summary Get My Work Order
+// @Description The assigned worker or a crew member reads the work order of the report
+// @Tags Worker
+// @Produce json
+// @Param id path string true "Report ID"
+// @Security BearerAuth
+// @Success 200 {object} dto.WorkOrderResponse
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/worker/report/{id}/progress [get]
+func (c *reportController) GetMyReportProgress(ctx *gin.Context) {
+	workerIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	workerID := workerIDVal.(uuid.UUID)
+
+	if ctx.GetString("role") != entity.ROLE_ADMIN {
+		allowed, err := c.reportService.CanViewProgress(ctx.Param("id"), workerID)
+		if err != nil {
+			utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		if !allowed {
+			utils.SendErrorResponse(ctx, http.StatusForbidden, http_error.NOT_ASSIGNED_TO_REPORT.Error())
+			return
+		}
+	}
+
+	workOrder, err := c.reportService.GetWorkOrder(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Work order retrieved", workOrder)
+}
+
+// @Summary Get Report Timeline
+// @Description The reporting citizen sees the repair stages reached so far with their photos
+// @Tags User
+// @Produce json
+// @Param id path string true "Report ID"
+// @Security BearerAuth
+// @Success 200 {object} dto.ReportTimelineResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/user/report/{id}/timeline [get]
+func (c *reportController) GetReportTimeline(ctx *gin.Context) {
+	userIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	timeline, err := c.reportService.GetReportTimeline(userIDVal.(uuid.UUID), ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Report timeline retrieved", timeline)
+}
+
+// @Summary Accept Assignment
+// @Description Worker accepts a report assigned to them
+// @Tags Worker
+// @Accept json
+// @Produce json
+// @Param request body dto.AcceptAssignmentRequest true "Accept Assignment Request"
+// @Param If-Match header string false "Report version from the ETag"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/worker/report/accept [patch]
+func (c *reportController) AcceptAssignment(ctx *gin.Context) {
+	workerIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	workerID := workerIDVal.(uuid.UUID)
+
+	var req dto.AcceptAssignmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	version, err := ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.Version = version
+
+	if err := c.reportService.AcceptAssignment(utils.GetAuditContext(ctx), workerID, req); err != nil {
+		sendReportError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	setNextReportETag(ctx, req.Version)
+	utils.SendSuccessResponse(ctx, "Assignment accepted", nil)
+}
+
+// @Summary Decline Assignment
+// @Description Worker declines a report assigned to them, before accepting it, with a reason. The report returns to the unassigned queue. Accepted assignments can only be released by an admin.
+// @Tags Worker
+// @Accept json
+// @Produce json
+// @Param request body dto.DeclineAssignmentRequest true "Decline Assignment Request"
+// @Param If-Match header string false "Report version from the ETag"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/worker/report/decline [patch]
+func (c *reportController) DeclineAssignment(ctx *gin.Context) {
+	workerIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	workerID := workerIDVal.(uuid.UUID)
+
+	var req dto.DeclineAssignmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	version, err := ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.Version = version
+
+	if err := c.reportService.DeclineAssignment(utils.GetAuditContext(ctx), workerID, req); err != nil {
+		sendReportError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	setNextReportETag(ctx, req.Version)
+	utils.SendSuccessResponse(ctx, "Assignment declined", nil)
+}
+
+// @Summary Reassign Report
+// @Description Admin moves an assigned report to another worker. The previous assignment is kept in the history. Unavailable workers are refused unless force is set. Without a deadline the matching SLA policy sets one.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dto.ReassignWorkerRequest true "Reassign Worker Request"
+// @Param If-Match header string false "Report version from the ETag"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/report/reassign [patch]
+func (c *reportController) ReassignWorker(ctx *gin.Context) {
+	var req dto.ReassignWorkerRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !reportInScope(ctx, c.regionService, req.ReportID) {
+		return
+	}
+
+	version, err := ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.Version = version
+
+	message, err := c.reportService.ReassignWorker(utils.GetAuditContext(ctx), req)
+	if err != nil {
+		sendReportError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	setNextReportETag(ctx, req.Version)
+	utils.SendSuccessResponse(ctx, message, nil)
+}
+
+// @Summary Unassign Report
+// @Description Admin removes the worker from an assigned report
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dto.UnassignWorkerRequest true "Unassign Worker Request"
+// @Param If-Match header string false "Report version from the ETag"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/report/unassign [patch]
+func (c *reportController) UnassignWorker(ctx *gin.Context) {
+	var req dto.UnassignWorkerRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !reportInScope(ctx, c.regionService, req.ReportID) {
+		return
+	}
+
+	version, err := ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.Version = version
+
+	if err := c.reportService.UnassignWorker(utils.GetAuditContext(ctx), req); err != nil {
+		sendReportError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	setNextReportETag(ctx, req.Version)
+	utils.SendSuccessResponse(ctx, "Worker unassigned successfully", nil)
+}
+
+// @Summary Get Report Assignment History
+// @Description Get every assignment of a report including declined, reassigned and unassigned ones
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Report ID"
+// @Security BearerAuth
+// @Success 200 {array} dto.ReportAssignmentResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/report/{id}/assignments [get]
+func (c *reportController) GetReportAssignments(ctx *gin.Context) {
+	if !reportInScope(ctx, c.regionService, ctx.Param("id")) {
+		return
+	}
+
+	assignments, err := c.reportService.GetReportAssignments(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Report assignments retrieved", assignments)
+}
+
+// @Summary Get Report
+// @Description Get a single report. The ETag carries the report version to send back in If-Match.
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Report ID"
+// @Security BearerAuth
+// @Success 200 {object} dto.UserReportResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/report/{id} [get]
+func (c *reportController) GetReport(ctx *gin.Context) {
+	if !reportInScope(ctx, c.regionService, ctx.Param("id")) {
+		return
+	}
+
+	report, err := c.reportService.GetReport(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	ctx.Header("ETag", reportETag(report.Version))
+	utils.SendSuccessResponse(ctx, "Report retrieved", report)
+}
+
+// ifMatchVersion returns the report version the client edited. An If-Match
+// header ("3", W/"3" or 3) takes precedence over the version in the body; "*"
+// leaves the body version untouched.
+func ifMatchVersion(ctx *gin.Context, bodyVersion *int) (*int, error) {
+	value := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if value == "" || value == "*" {
+		return bodyVersion, nil
+	}
+
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(value, "W/"), `"`))
+	if err != nil || version < 1 {
+		return nil, http_error.INVALID_IF_MATCH
+	}
+	return &version, nil
+}
+
+func reportETag(version int) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// setNextReportETag exposes the version after a successful mutation. Every
+// mutation bumps the version exactly once, so it is only known when the client
+// pinned the version it started from.
+func setNextReportETag(ctx *gin.Context, version *int) {
+	if version != nil {
+		ctx.Header("ETag", reportETag(*version+1))
+	}
+}
+
+// sendReportError answers version conflicts with 409 Conflict and any other
+// error with the given status.
+func sendReportError(ctx *gin.Context, code int, err error) {
+	if errors.Is(err, http_error.REPORT_VERSION_CONFLICT) {
+		utils.ResponseFAILED[any](ctx, nil, err)
+		return
+	}
+	utils.SendErrorResponse(ctx, code, err.Error())
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	http_error "dinacom-11.0-backend/models/error"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name        string
+		ifMatch     string
+		bodyVersion *int
+		want        *int
+		wantErr     error
+	}{
+		{"no header uses the body", "", intPtr(4), intPtr(4), nil},
+		{"no header or body", "", nil, nil, nil},
+		{"wildcard uses the body", "*", intPtr(4), intPtr(4), nil},
+		{"quoted", `"3"`, nil, intPtr(3), nil},
+		{"weak", `W/"3"`, nil, intPtr(3), nil},
+		{"bare number", "3", nil, intPtr(3), nil},
+		{"surrounding spaces", `  "7" `, nil, intPtr(7), nil},
+		{"header wins over the body", `"5"`, intPtr(4), intPtr(5), nil},
+		{"not a number", `"abc"`, intPtr(4), nil, http_error.INVALID_IF_MATCH},
+		{"zero", `"0"`, nil, nil, http_error.INVALID_IF_MATCH},
+		{"negative", "-1", nil, nil, http_error.INVALID_IF_MATCH},
+		{"list of tags", `"3", "4"`, nil, nil, http_error.INVALID_IF_MATCH},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest(http.MethodPatch, "/", nil)
+			if tt.ifMatch != "" {
+				ctx.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			got, err := ifMatchVersion(ctx, tt.bodyVersion)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("ifMatchVersion() error = %v, want %v", err, tt.wantErr)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("ifMatchVersion() = %v, want %v", versionValue(got), versionValue(tt.want))
+			}
+		})
+	}
+}
+
+func versionValue(v *int) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoadController interface {
+	GetQueues(ctx *gin.Context)
+	GetQueue(ctx *gin.Context)
+	ReloadNetwork(ctx *gin.Context)
+	GetRoads(ctx *gin.Context)
+	GetRoad(ctx *gin.Context)
+}
+
+type roadController struct {
+	roadService   services.RoadService
+	reportService services.ReportService
+	regionService services.RegionService
+}
+
+func NewRoadController(roadService services.RoadService, reportService services.ReportService, regionService services.RegionService) RoadController {
+	return &roadController{roadService: roadService, reportService: reportService, regionService: regionService}
+}
+
+// @Summary Get Agency Queues
+// @Description Count the open reports waiting for the agency of each road authority. Own marks the queues handled by us, the others hold reports to forward (Admin only)
+// @Tags Admin
+// @Produce json
+// @Param region query string false "Region code. Admins limited to regions only see their own"
+// @Security BearerAuth
+// @Success 200 {array} dto.RoadQueueResponse
+// @Failure 403 {object} map[string]string
+// @Router /api/admin/roads/queues [get]
+func (c *roadController) GetQueues(ctx *gin.Context) {
+	regionCodes, ok := regionFilter(ctx, c.regionService)
+	if !ok {
+		return
+	}
+
+	queues, err := c.roadService.GetQueues(regionCodes)
+	if err != nil {
+		sendRoadError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Agency queues retrieved", queues)
+}
+
+// @Summary Get Agency Queue
+// @Description List the open reports on roads of one authority, most severe first. The unknown queue holds reports not near any imported road (Admin only)
+// @Tags Admin
+// @Produce json
+// @Param authority path string true "national, provincial, regency, city, village or unknown"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param region query string false "Region code. Admins limited to regions only see their own"
+// @Security BearerAuth
+// @Success 200 {object} dto.PaginatedReportsResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/roads/queues/{authority} [get]
+func (c *roadController) GetQueue(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	regionCodes, ok := regionFilter(ctx, c.regionService)
+	if !ok {
+		return
+	}
+
+	response, err := c.reportService.GetAuthorityQueue(ctx.Param("authority"), regionCodes, page, limit)
+	if err != nil {
+		sendRoadError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Agency queue retrieved", response)
+}
+
+// @Summary Reload Road Network
+// @Description Import the road network GeoJSON files again and snap every report to its road anew in the background (Admin not limited to regions)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.RoadReloadResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /api/admin/roads/reload [post]
+func (c *roadController) ReloadNetwork(ctx *gin.Context) {
+	if len(utils.GetRegionScope(ctx)) > 0 {
+		utils.SendErrorResponse(ctx, http.StatusForbidden, http_error.ONLY_UNSCOPED_ADMIN.Error())
+		return
+	}
+
+	response, err := c.roadService.ReloadNetwork(utils.GetAuditContext(ctx))
+	if err != nil {
+		sendRoadError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Road network reloaded", response)
+}
+
+// @Summary Get Road Conditions
+// @Description List the roads with reports, worst condition first. Roads are road segments or, for reports not near an imported road, normalised road names. The condition index is 100 without known damage and drops with every open damage report and recent recurrence after repair
+// @Tags Report
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Param region query string false "Region code"
+// @Success 200 {object} dto.PaginatedRoadConditionsResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/roads [get]
+func (c *roadController) GetRoads(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	regionCodes, ok := regionFilter(ctx, c.regionService)
+	if !ok {
+		return
+	}
+
+	response, err := c.roadService.GetConditions(regionCodes, page, limit)
+	if err != nil {
+		sendRoadError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Road conditions retrieved", response)
+}
+
+// @Summary Get Road Condition
+// @Description Get the report counts by class, open and closed reports, average repair time, recurrences after repair and the monthly condition index of a road
+// @Tags Report
+// @Produce json
+// @Param id path string true "Road segment ID or name:<normalised-road-name> as listed"
+// @Param months query int false "Months of history, up to 36" default(12)
+// @Param region query string false "Region code"
+// @Success 200 {object} dto.RoadConditionDetailResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/roads/{id} [get]
+func (c *roadController) GetRoad(ctx *gin.Context) {
+	months, _ := strconv.Atoi(ctx.DefaultQuery("months", "12"))
+
+	regionCodes, ok := regionFilter(ctx, c.regionService)
+	if !ok {
+		return
+	}
+
+	response, err := c.roadService.GetCondition(ctx.Param("id"), regionCodes, months)
+	if err != nil {
+		sendRoadError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Road condition retrieved", response)
+}
+
+func sendRoadError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, http_error.ROAD_NOT_FOUND):
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, http_error.INVALID_ROAD_AUTHORITY), errors.Is(err, http_error.INVALID_ROAD_SEGMENT),
+		errors.Is(err, http_error.ROAD_NETWORK_NOT_SET):
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+	default:
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
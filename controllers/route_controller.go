@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"dinacom-11.0-backend/models/dto"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RouteController interface {
+	GetMyRoute(ctx *gin.Context)
+	GetWorkerRoute(ctx *gin.Context)
+}
+
+type routeController struct {
+	routeService services.RouteService
+}
+
+func NewRouteController(routeService services.RouteService) RouteController {
+	return &routeController{routeService: routeService}
+}
+
+// @Summary Get My Route
+// @Description Get the logged-in worker's open assignments in an optimised visiting order that respects deadlines where possible, as JSON, GeoJSON or GPX
+// @Tags Worker
+// @Produce json
+// @Produce application/geo+json
+// @Produce application/gpx+xml
+// @Param lat query number false "Current latitude, defaults to the worker's base"
+// @Param lng query number false "Current longitude, defaults to the worker's base"
+// @Param format query string false "json, geojson or gpx" default(json)
+// @Security BearerAuth
+// @Success 200 {object} dto.WorkerRouteResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/worker/route [get]
+func (c *routeController) GetMyRoute(ctx *gin.Context) {
+	workerIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	c.sendRoute(ctx, workerIDVal.(uuid.UUID))
+}
+
+// @Summary Get Worker Route
+// @Description Get a worker's open assignments in an optimised visiting order, as JSON, GeoJSON or GPX (Admin only)
+// @Tags Admin
+// @Produce json
+// @Produce application/geo+json
+// @Produce application/gpx+xml
+// @Param id path string true "Worker ID"
+// @Param lat query number false "Start latitude, defaults to the worker's base"
+// @Param lng query number false "Start longitude, defaults to the worker's base"
+// @Param format query string false "json, geojson or gpx" default(json)
+// @Security BearerAuth
+// @Success 200 {object} dto.WorkerRouteResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/workers/{id}/route [get]
+func (c *routeController) GetWorkerRoute(ctx *gin.Context) {
+	workerID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid worker ID")
+		return
+	}
+
+	c.sendRoute(ctx, workerID)
+}
+
+func (c *routeController) sendRoute(ctx *gin.Context, workerID uuid.UUID) {
+	latitude, longitude, err := parsePositionQuery(ctx)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	route, err := c.routeService.GetWorkerRoute(workerID, latitude, longitude)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, http_error.ROUTE_START_REQUIRED) {
+			status = http.StatusBadRequest
+		}
+		utils.SendErrorResponse(ctx, status, err.Error())
+		return
+	}
+
+	switch ctx.Query("format") {
+	case "geojson":
+		ctx.Header("Content-Disposition", `attachment; filename="route.geojson"`)
+		ctx.Header("Content-Type", "application/geo+json")
+		ctx.JSON(http.StatusOK, toRouteGeoJSON(route))
+	case "gpx":
+		body, err := xml.MarshalIndent(toRouteGPX(route), "", "  ")
+		if err != nil {
+			utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+		ctx.Header("Content-Disposition", `attachment; filename="route.gpx"`)
+		ctx.Data(http.StatusOK, "application/gpx+xml", append([]byte(xml.Header), body...))
+	default:
+		utils.SendSuccessResponse(ctx, "Route retrieved", route)
+	}
+}
+
+// parsePositionQuery reads the optional lat and lng query parameters, which
+// must be given together.
+func parsePositionQuery(ctx *gin.Context) (*float64, *float64, error) {
+	latValue, lngValue := ctx.Query("lat"), ctx.Query("lng")
+	if latValue == "" && lngValue == "" {
+		return nil, nil, nil
+	}
+
+	latitude, latErr := strconv.ParseFloat(latValue, 64)
+	longitude, lngErr := strconv.ParseFloat(lngValue, 64)
+	if latErr != nil || lngErr != nil || latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return nil, nil, http_error.INVALID_COORDINATES
+	}
+	return &latitude, &longitude, nil
+}
+
+func toRouteGeoJSON(route *dto.WorkerRouteResponse) dto.GeoJSONFeatureCollection {
+	line := [][]float64{{route.StartLongitude, route.StartLatitude}}
+	features := []dto.GeoJSONFeature{{
+		Type:       "Feature",
+		Geometry:   dto.GeoJSONGeometry{Type: "Point", Coordinates: []float64{route.StartLongitude, route.StartLatitude}},
+		Properties: map[string]interface{}{"kind": "start", "started_from": route.StartedFrom},
+	}}
+
+	for _, stop := range route.Stops {
+		line = append(line, []float64{stop.Longitude, stop.Latitude})
+		features = append(features, dto.GeoJSONFeature{
+			Type:     "Feature",
+			ID:       stop.ReportID,
+			Geometry: dto.GeoJSONGeometry{Type: "Point", Coordinates: []float64{stop.Longitude, stop.Latitude}},
+			Properties: map[string]interface{}{
+				"kind":              "stop",
+				"sequence":          stop.Sequence,
+				"road_name":         stop.RoadName,
+				"destruct_class":    stop.DestructClass,
+				"deadline":          stop.Deadline,
+				"estimated_arrival": stop.EstimatedArrival,
+				"late":              stop.Late,
+			},
+		})
+	}
+
+	features = append(features, dto.GeoJSONFeature{
+		Type:     "Feature",
+		Geometry: dto.GeoJSONGeometry{Type: "LineString", Coordinates: line},
+		Properties: map[string]interface{}{
+			"kind":                       "route",
+			"total_distance_km":          route.TotalDistanceKm,
+			"estimated_duration_minutes": route.EstimatedDurationMinutes,
+			"late_stops":                 route.LateStops,
+		},
+	})
+
+	return dto.GeoJSONFeatureCollection{Type: "FeatureCollection", Features: features}
+}
+
+func toRouteGPX(route *dto.WorkerRouteResponse) dto.GPX {
+	points := []dto.GPXPoint{{Lat: route.StartLatitude, Lon: route.StartLongitude, Name: "Start"}}
+	var waypoints []dto.GPXPoint
+	for _, stop := range route.Stops {
+		point := dto.GPXPoint{
+			Lat:  stop.Latitude,
+			Lon:  stop.Longitude,
+			Name: fmt.Sprintf("%d. %s", stop.Sequence, stop.RoadName),
+			Desc: "Report " + stop.ReportID,
+		}
+		points = append(points, point)
+		waypoints = append(waypoints, point)
+	}
+
+	return dto.GPX{
+		Version:   "1.1",
+		Creator:   "dinacom-11.0-backend",
+		Xmlns:     "http://www.topografix.com/GPX/1/1",
+		Waypoints: waypoints,
+		Route:     dto.GPXRoute{Name: "Repair route", Points: points},
+	}
+}
@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"dinacom-11.0-backend/models/dto"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ServiceAreaController interface {
+	GetServiceAreas(ctx *gin.Context)
+	CreateServiceArea(ctx *gin.Context)
+	UpdateServiceArea(ctx *gin.Context)
+	DeleteServiceArea(ctx *gin.Context)
+}
+
+type serviceAreaController struct {
+	serviceAreaService services.ServiceAreaService
+}
+
+func NewServiceAreaController(serviceAreaService services.ServiceAreaService) ServiceAreaController {
+	return &serviceAreaController{serviceAreaService: serviceAreaService}
+}
+
+// @Summary Get Service Areas
+// @Description List the service areas, including inactive ones (Admin only)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.ServiceAreaResponse
+// @Router /api/admin/service-areas [get]
+func (c *serviceAreaController) GetServiceAreas(ctx *gin.Context) {
+	areas, err := c.serviceAreaService.GetServiceAreas()
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Service areas retrieved successfully", areas)
+}
+
+// @Summary Create Service Area
+// @Description Add a GeoJSON Polygon or MultiPolygon new reports must fall in. Reports outside every active area are rejected or flagged depending on SERVICE_AREA_MODE (Admin not limited to regions)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dto.ServiceAreaRequest true "Service Area Request"
+// @Security BearerAuth
+// @Success 200 {object} dto.ServiceAreaResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/service-areas [post]
+func (c *serviceAreaController) CreateServiceArea(ctx *gin.Context) {
+	if len(utils.GetRegionScope(ctx)) > 0 {
+		utils.SendErrorResponse(ctx, http.StatusForbidden, http_error.ONLY_UNSCOPED_ADMIN.Error())
+		return
+	}
+
+	var req dto.ServiceAreaRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	area, err := c.serviceAreaService.CreateServiceArea(utils.GetAuditContext(ctx), req)
+	if err != nil {
+		sendServiceAreaError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Service area created", area)
+}
+
+// @Summary Update Service Area
+// @Description Change the name, boundary or active flag of a service area. Existing reports keep their flag (Admin not limited to regions)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Service area ID"
+// @Param request body dto.ServiceAreaRequest true "Service Area Request"
+// @Security BearerAuth
+// @Success 200 {object} dto.ServiceAreaResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/service-areas/{id} [put]
+func (c *serviceAreaController) UpdateServiceArea(ctx *gin.Context) {
+	if len(utils.GetRegionScope(ctx)) > 0 {
+		utils.SendErrorResponse(ctx, http.StatusForbidden, http_error.ONLY_UNSCOPED_ADMIN.Error())
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid service area ID")
+		return
+	}
+
+	var req dto.ServiceAreaRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	area, err := c.serviceAreaService.UpdateServiceArea(utils.GetAuditContext(ctx), id, req)
+	if err != nil {
+		sendServiceAreaError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Service area updated", area)
+}
+
+// @Summary Delete Service Area
+// @Description Remove a service area. Without any active area every valid location is accepted (Admin not limited to regions)
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Service area ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/service-areas/{id} [delete]
+func (c *serviceAreaController) DeleteServiceArea(ctx *gin.Context) {
+	if len(utils.GetRegionScope(ctx)) > 0 {
+		utils.SendErrorResponse(ctx, http.StatusForbidden, http_error.ONLY_UNSCOPED_ADMIN.Error())
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid service area ID")
+		return
+	}
+
+	if err := c.serviceAreaService.DeleteServiceArea(utils.GetAuditContext(ctx), id); err != nil {
+		sendServiceAreaError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Service area deleted", nil)
+}
+
+func sendServiceAreaError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, http_error.SERVICE_AREA_NOT_FOUND):
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, http_error.SERVICE_AREA_NAME_TAKEN):
+		utils.SendErrorResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, http_error.INVALID_SERVICE_AREA):
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+	default:
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,237 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"dinacom-11.0-backend/models/dto"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SLAController interface {
+	GetOverdueReports(ctx *gin.Context)
+	GetWorkerCompliance(ctx *gin.Context)
+	GetRoadCompliance(ctx *gin.Context)
+	GetPolicies(ctx *gin.Context)
+	CreatePolicy(ctx *gin.Context)
+	UpdatePolicy(ctx *gin.Context)
+	DeletePolicy(ctx *gin.Context)
+}
+
+type slaController struct {
+	slaService    services.SLAService
+	regionService services.RegionService
+}
+
+func NewSLAController(slaService services.SLAService, regionService services.RegionService) SLAController {
+	return &slaController{slaService: slaService, regionService: regionService}
+}
+
+// @Summary Get Overdue Reports
+// @Description List assigned reports past their deadline, with escalation state (Admin or supervisor)
+// @Tags Admin
+// @Produce json
+// @Param region query string false "Region code. Admins limited to regions only see their own"
+// @Security BearerAuth
+// @Success 200 {array} dto.OverdueReportResponse
+// @Router /api/admin/sla/overdue [get]
+func (c *slaController) GetOverdueReports(ctx *gin.Context) {
+	regionCodes, ok := regionFilter(ctx, c.regionService)
+	if !ok {
+		return
+	}
+
+	reports, err := c.slaService.GetOverdueReports(regionCodes)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Overdue reports retrieved successfully", reports)
+}
+
+// @Summary Get Worker SLA Compliance
+// @Description Deadline compliance of finished reports per worker, plus their open overdue reports (Admin or supervisor)
+// @Tags Admin
+// @Produce json
+// @Param from query string false "Finished from (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Finished to (RFC3339 or YYYY-MM-DD)"
+// @Param region query string false "Region code. Admins limited to regions only see their own"
+// @Security BearerAuth
+// @Success 200 {array} dto.WorkerSLAResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/sla/workers [get]
+func (c *slaController) GetWorkerCompliance(ctx *gin.Context) {
+	from, err := parseTimeQuery(ctx.Query("from"), false)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid from date")
+		return
+	}
+	to, err := parseTimeQuery(ctx.Query("to"), true)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid to date")
+		return
+	}
+	regionCodes, ok := regionFilter(ctx, c.regionService)
+	if !ok {
+		return
+	}
+
+	compliance, err := c.slaService.GetWorkerCompliance(from, to, regionCodes)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Worker SLA compliance retrieved successfully", compliance)
+}
+
+// @Summary Get Road SLA Compliance
+// @Description Deadline compliance of finished reports per road, plus open overdue reports (Admin or supervisor)
+// @Tags Admin
+// @Produce json
+// @Param from query string false "Finished from (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Finished to (RFC3339 or YYYY-MM-DD)"
+// @Param region query string false "Region code. Admins limited to regions only see their own"
+// @Security BearerAuth
+// @Success 200 {array} dto.RoadSLAResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/sla/roads [get]
+func (c *slaController) GetRoadCompliance(ctx *gin.Context) {
+	from, err := parseTimeQuery(ctx.Query("from"), false)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid from date")
+		return
+	}
+	to, err := parseTimeQuery(ctx.Query("to"), true)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid to date")
+		return
+	}
+	regionCodes, ok := regionFilter(ctx, c.regionService)
+	if !ok {
+		return
+	}
+
+	compliance, err := c.slaService.GetRoadCompliance(from, to, regionCodes)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Road SLA compliance retrieved successfully", compliance)
+}
+
+// @Summary Get SLA Policies
+// @Description List the policies that set default deadlines from destruct class and total score (Admin or supervisor)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.SLAPolicyResponse
+// @Router /api/admin/sla/policies [get]
+func (c *slaController) GetPolicies(ctx *gin.Context) {
+	policies, err := c.slaService.GetPolicies()
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "SLA policies retrieved successfully", policies)
+}
+
+// @Summary Create SLA Policy
+// @Description Add a policy giving reports of a destruct class (empty for any) at or above a total score a target resolution time (Admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dto.SLAPolicyRequest true "SLA Policy Request"
+// @Security BearerAuth
+// @Success 200 {object} dto.SLAPolicyResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/sla/policies [post]
+func (c *slaController) CreatePolicy(ctx *gin.Context) {
+	var req dto.SLAPolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	policy, err := c.slaService.CreatePolicy(utils.GetAuditContext(ctx), req)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "SLA policy created", policy)
+}
+
+// @Summary Update SLA Policy
+// @Description Change an SLA policy. Deadlines already set are not recalculated (Admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "SLA Policy ID"
+// @Param request body dto.SLAPolicyRequest true "SLA Policy Request"
+// @Security BearerAuth
+// @Success 200 {object} dto.SLAPolicyResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/sla/policies/{id} [put]
+func (c *slaController) UpdatePolicy(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid SLA policy ID")
+		return
+	}
+
+	var req dto.SLAPolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	policy, err := c.slaService.UpdatePolicy(utils.GetAuditContext(ctx), id, req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, http_error.SLA_POLICY_NOT_FOUND) {
+			status = http.StatusNotFound
+		}
+		utils.SendErrorResponse(ctx, status, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "SLA policy updated", policy)
+}
+
+// @Summary Delete SLA Policy
+// @Description Remove an SLA policy. Deadlines it already set are kept (Admin only)
+// @Tags Admin
+// @Produce json
+// @Param id path string true "SLA Policy ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/sla/policies/{id} [delete]
+func (c *slaController) DeletePolicy(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid SLA policy ID")
+		return
+	}
+
+	if err := c.slaService.DeletePolicy(utils.GetAuditContext(ctx), id); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, http_error.SLA_POLICY_NOT_FOUND) {
+			status = http.StatusNotFound
+		}
+		utils.SendErrorResponse(ctx, status, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "SLA policy deleted", nil)
+}
@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"dinacom-11.0-backend/models/dto"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TeamController interface {
+	GetTeams(ctx *gin.Context)
+	GetTeam(ctx *gin.Context)
+	CreateTeam(ctx *gin.Context)
+	UpdateTeam(ctx *gin.Context)
+	DeleteTeam(ctx *gin.Context)
+	GetMyTeam(ctx *gin.Context)
+}
+
+type teamController struct {
+	teamService services.TeamService
+}
+
+func NewTeamController(teamService services.TeamService) TeamController {
+	return &teamController{teamService: teamService}
+}
+
+// @Summary Get Teams
+// @Description List the worker teams with their leader and members
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.TeamResponse
+// @Router /api/admin/teams [get]
+func (c *teamController) GetTeams(ctx *gin.Context) {
+	teams, err := c.teamService.GetTeams()
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Teams retrieved successfully", teams)
+}
+
+// @Summary Get Team
+// @Description Get a worker team with its leader and members
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Team ID"
+// @Security BearerAuth
+// @Success 200 {object} dto.TeamResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/teams/{id} [get]
+func (c *teamController) GetTeam(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+
+	team, err := c.teamService.GetTeam(id)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Team retrieved successfully", team)
+}
+
+// @Summary Create Team
+// @Description Create a worker team. The leader is added to the members; a worker can belong to one team only
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dto.TeamRequest true "Team Request"
+// @Security BearerAuth
+// @Success 200 {object} dto.TeamResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/teams [post]
+func (c *teamController) CreateTeam(ctx *gin.Context) {
+	var req dto.TeamRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	team, err := c.teamService.CreateTeam(utils.GetAuditContext(ctx), req)
+	if err != nil {
+		sendTeamError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Team created", team)
+}
+
+// @Summary Update Team
+// @Description Change a team's name, leader and members. Open assignments keep the crew they were given
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Team ID"
+// @Param request body dto.TeamRequest true "Team Request"
+// @Security BearerAuth
+// @Success 200 {object} dto.TeamResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/teams/{id} [put]
+func (c *teamController) UpdateTeam(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+
+	var req dto.TeamRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	team, err := c.teamService.UpdateTeam(utils.GetAuditContext(ctx), id, req)
+	if err != nil {
+		sendTeamError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Team updated", team)
+}
+
+// @Summary Delete Team
+// @Description Remove a team that has no open assignments. Crew history is kept
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Team ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/admin/teams/{id} [delete]
+func (c *teamController) DeleteTeam(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+
+	if err := c.teamService.DeleteTeam(utils.GetAuditContext(ctx), id); err != nil {
+		sendTeamError(ctx, err)
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Team deleted", nil)
+}
+
+// @Summary Get My Team
+// @Description Get the team the logged-in worker belongs to
+// @Tags Worker
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.TeamResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/worker/team [get]
+func (c *teamController) GetMyTeam(ctx *gin.Context) {
+	workerIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	team, err := c.teamService.GetWorkerTeam(workerIDVal.(uuid.UUID))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Team retrieved successfully", team)
+}
+
+func sendTeamError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, http_error.TEAM_NOT_FOUND), errors.Is(err, http_error.WORKER_NOT_FOUND):
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, http_error.TEAM_NAME_TAKEN), errors.Is(err, http_error.WORKER_IN_OTHER_TEAM), errors.Is(err, http_error.TEAM_HAS_OPEN_REPORTS):
+		utils.SendErrorResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, http_error.ONLY_WORKER_CAN_ASSIGN):
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+	default:
+		utils.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,352 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	"dinacom-11.0-backend/services"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WorkerController interface {
+	GetWorkerProfile(ctx *gin.Context)
+	UpdateWorkerProfile(ctx *gin.Context)
+	GetMyWorkerProfile(ctx *gin.Context)
+	GetWorkerSchedule(ctx *gin.Context)
+	UpdateWorkerShifts(ctx *gin.Context)
+	CreateWorkerLeave(ctx *gin.Context)
+	DeleteWorkerLeave(ctx *gin.Context)
+	GetAvailability(ctx *gin.Context)
+	GetMySchedule(ctx *gin.Context)
+	CreateMyLeave(ctx *gin.Context)
+	DeleteMyLeave(ctx *gin.Context)
+}
+
+type workerController struct {
+	workerService services.WorkerService
+}
+
+func NewWorkerController(workerService services.WorkerService) WorkerController {
+	return &workerController{workerService: workerService}
+}
+
+// @Summary Get Worker Profile
+// @Description Get the base area, skills and job limit used for assignment (Admin only)
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Worker ID"
+// @Security BearerAuth
+// @Success 200 {object} dto.WorkerProfileResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/workers/{id}/profile [get]
+func (c *workerController) GetWorkerProfile(ctx *gin.Context) {
+	workerID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid worker ID")
+		return
+	}
+
+	profile, err := c.workerService.GetWorkerProfile(workerID)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Worker profile retrieved", profile)
+}
+
+// @Summary Update Worker Profile
+// @Description Set the base area, skills (destruct classes) and job limit used for assignment (Admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Worker ID"
+// @Param request body dto.WorkerProfileRequest true "Worker Profile Request"
+// @Security BearerAuth
+// @Success 200 {object} dto.WorkerProfileResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/workers/{id}/profile [put]
+func (c *workerController) UpdateWorkerProfile(ctx *gin.Context) {
+	workerID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid worker ID")
+		return
+	}
+
+	var req dto.WorkerProfileRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	profile, err := c.workerService.UpdateWorkerProfile(utils.GetAuditContext(ctx), workerID, req)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Worker profile updated", profile)
+}
+
+// @Summary Get My Worker Profile
+// @Description Get the logged-in worker's base area, skills and job limit
+// @Tags Worker
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.WorkerProfileResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/worker/profile [get]
+func (c *workerController) GetMyWorkerProfile(ctx *gin.Context) {
+	workerIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	workerID := workerIDVal.(uuid.UUID)
+
+	profile, err := c.workerService.GetWorkerProfile(workerID)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Worker profile retrieved", profile)
+}
+
+// @Summary Get Worker Schedule
+// @Description Get a worker's weekly shifts, upcoming leave and current job load (Admin only)
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Worker ID"
+// @Security BearerAuth
+// @Success 200 {object} dto.WorkerScheduleResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/workers/{id}/schedule [get]
+func (c *workerController) GetWorkerSchedule(ctx *gin.Context) {
+	workerID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid worker ID")
+		return
+	}
+
+	schedule, err := c.workerService.GetWorkerSchedule(workerID)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Worker schedule retrieved", schedule)
+}
+
+// @Summary Update Worker Shifts
+// @Description Replace a worker's weekly recurring shifts. Weekday 0 is Sunday; a shift ending before it starts runs past midnight. (Admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Worker ID"
+// @Param request body dto.UpdateWorkerShiftsRequest true "Update Worker Shifts Request"
+// @Security BearerAuth
+// @Success 200 {object} dto.WorkerScheduleResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/workers/{id}/shifts [put]
+func (c *workerController) UpdateWorkerShifts(ctx *gin.Context) {
+	workerID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid worker ID")
+		return
+	}
+
+	var req dto.UpdateWorkerShiftsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	schedule, err := c.workerService.UpdateWorkerShifts(utils.GetAuditContext(ctx), workerID, req)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Worker shifts updated", schedule)
+}
+
+// @Summary Add Worker Leave
+// @Description Record leave or sick days for a worker (Admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Worker ID"
+// @Param request body dto.WorkerLeaveRequest true "Worker Leave Request (type: leave, sick)"
+// @Security BearerAuth
+// @Success 200 {object} dto.WorkerLeaveResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/workers/{id}/leaves [post]
+func (c *workerController) CreateWorkerLeave(ctx *gin.Context) {
+	workerID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid worker ID")
+		return
+	}
+
+	c.createLeave(ctx, workerID)
+}
+
+// @Summary Delete Worker Leave
+// @Description Remove a leave entry of a worker (Admin only)
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Worker ID"
+// @Param leave_id path string true "Leave ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/workers/{id}/leaves/{leave_id} [delete]
+func (c *workerController) DeleteWorkerLeave(ctx *gin.Context) {
+	workerID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid worker ID")
+		return
+	}
+
+	c.deleteLeave(ctx, workerID, ctx.Param("leave_id"))
+}
+
+// @Summary Get Worker Availability
+// @Description Get every worker's shifts and leave per day in a date range (at most 31 days) with their current job load (Admin only)
+// @Tags Admin
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to today"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to six days after from"
+// @Security BearerAuth
+// @Success 200 {array} dto.WorkerAvailabilityRangeResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/admin/workers/availability [get]
+func (c *workerController) GetAvailability(ctx *gin.Context) {
+	from, err := parseTimeQuery(ctx.Query("from"), false)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid from date")
+		return
+	}
+	to, err := parseTimeQuery(ctx.Query("to"), false)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid to date")
+		return
+	}
+
+	if from == nil {
+		now := time.Now()
+		from = &now
+	}
+	if to == nil {
+		end := from.AddDate(0, 0, 6)
+		to = &end
+	}
+
+	availability, err := c.workerService.GetAvailability(*from, *to)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Worker availability retrieved", availability)
+}
+
+// @Summary Get My Schedule
+// @Description Get the logged-in worker's weekly shifts, upcoming leave and job load
+// @Tags Worker
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.WorkerScheduleResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/worker/schedule [get]
+func (c *workerController) GetMySchedule(ctx *gin.Context) {
+	workerIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	workerID := workerIDVal.(uuid.UUID)
+
+	schedule, err := c.workerService.GetWorkerSchedule(workerID)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Worker schedule retrieved", schedule)
+}
+
+// @Summary Report Leave or Sick Days
+// @Description The logged-in worker reports leave or sick days. Admins are notified.
+// @Tags Worker
+// @Accept json
+// @Produce json
+// @Param request body dto.WorkerLeaveRequest true "Worker Leave Request (type: leave, sick)"
+// @Security BearerAuth
+// @Success 200 {object} dto.WorkerLeaveResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/worker/leaves [post]
+func (c *workerController) CreateMyLeave(ctx *gin.Context) {
+	workerIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	c.createLeave(ctx, workerIDVal.(uuid.UUID))
+}
+
+// @Summary Delete My Leave
+// @Description The logged-in worker removes one of their leave entries
+// @Tags Worker
+// @Produce json
+// @Param id path string true "Leave ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/worker/leaves/{id} [delete]
+func (c *workerController) DeleteMyLeave(ctx *gin.Context) {
+	workerIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		utils.SendErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	c.deleteLeave(ctx, workerIDVal.(uuid.UUID), ctx.Param("id"))
+}
+
+func (c *workerController) createLeave(ctx *gin.Context, workerID uuid.UUID) {
+	var req dto.WorkerLeaveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	leave, err := c.workerService.CreateLeave(utils.GetAuditContext(ctx), workerID, req)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Leave recorded", leave)
+}
+
+func (c *workerController) deleteLeave(ctx *gin.Context, workerID uuid.UUID, leaveIDParam string) {
+	leaveID, err := uuid.Parse(leaveIDParam)
+	if err != nil {
+		utils.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid leave ID")
+		return
+	}
+
+	if err := c.workerService.DeleteLeave(utils.GetAuditContext(ctx), workerID, leaveID); err != nil {
+		utils.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(ctx, "Leave deleted", nil)
+}
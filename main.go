@@ -23,7 +23,13 @@ import (
 // @in header
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description API key issued by an admin for machine-to-machine access.
 func main() {
 	appProvider := provider.NewAppProvider()
+	appProvider.ProvideScheduler().Start()
 	router.RunRouter(appProvider)
 }
@@ -4,14 +4,41 @@ import (
 	"net/http"
 	"strings"
 
+	entity "dinacom-11.0-backend/models/entity"
+	"dinacom-11.0-backend/services"
 	"dinacom-11.0-backend/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+// AuthMiddleware accepts either a user JWT or an admin-issued API key. API keys
+// are sent as "X-API-Key: <key>" or "Authorization: ApiKey <key>" and
+// authenticate the request with the service role, setting "api_key_id"
+// rather than "user_id". Admins limited to regions get them as
+// "region_codes".
+func AuthMiddleware(apiKeyService services.APIKeyService, regionService services.RegionService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
+		apiKey := c.GetHeader("X-API-Key")
+		if apiKey == "" && strings.HasPrefix(authHeader, "ApiKey ") {
+			apiKey = strings.TrimPrefix(authHeader, "ApiKey ")
+		}
+
+		if apiKey != "" {
+			key, err := apiKeyService.Authenticate(apiKey, c.ClientIP())
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid, expired or revoked API key"})
+				return
+			}
+
+			c.Set("role", entity.ROLE_SERVICE)
+			c.Set("api_key_id", key.ID)
+			c.Set("permissions", key.PermissionList())
+
+			c.Next()
+			return
+		}
+
 		if authHeader == "" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
 			return
@@ -39,6 +66,15 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Set("role", claims.Role)
 		c.Set("email", claims.Email)
 
+		if claims.Role == entity.ROLE_ADMIN {
+			regionCodes, err := regionService.GetScope(claims.UserID)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to load admin regions"})
+				return
+			}
+			c.Set("region_codes", regionCodes)
+		}
+
 		c.Next()
 	}
 }
@@ -62,3 +98,26 @@ func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
 		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden: insufficient permissions"})
 	}
 }
+
+// PermissionMiddleware restricts API key requests to keys holding the given
+// permission. JWT requests are left to RoleMiddleware.
+func PermissionMiddleware(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get("role")
+		if role != entity.ROLE_SERVICE {
+			c.Next()
+			return
+		}
+
+		permissions, _ := c.Get("permissions")
+		granted, _ := permissions.([]string)
+		for _, p := range granted {
+			if p == permission {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden: API key lacks permission " + permission})
+	}
+}
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditContext identifies who performed an action and from which request.
+// ActorID is nil for anonymous requests (e.g. failed logins) and background jobs.
+// Requests authenticated with an API key carry APIKeyID instead.
+type AuditContext struct {
+	ActorID   *uuid.UUID
+	APIKeyID  *uuid.UUID
+	ActorRole string
+	IP        string
+	RequestID string
+}
+
+type AuditLogFilter struct {
+	Action     string
+	ActorType  string
+	ActorID    *uuid.UUID
+	TargetType string
+	TargetID   string
+	IP         string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	// RegionCodes limits the log to events on reports inside the regions.
+	RegionCodes []string
+}
+
+type AuditLogResponse struct {
+	ID         uuid.UUID              `json:"id"`
+	Action     string                 `json:"action"`
+	ActorType  string                 `json:"actor_type"`
+	ActorID    *uuid.UUID             `json:"actor_id"`
+	ActorRole  string                 `json:"actor_role"`
+	TargetType string                 `json:"target_type"`
+	TargetID   string                 `json:"target_id"`
+	IP         string                 `json:"ip"`
+	RequestID  string                 `json:"request_id"`
+	Metadata   map[string]interface{} `json:"metadata"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+type PaginatedAuditLogsResponse struct {
+	Logs       []AuditLogResponse `json:"logs"`
+	TotalCount int64              `json:"total_count"`
+	Page       int                `json:"page"`
+	Limit      int                `json:"limit"`
+	TotalPages int                `json:"total_pages"`
+}
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
@@ -30,4 +30,7 @@ type UserResponse struct {
 	Email    string    `json:"email"`
 	Role     string    `json:"role"`
 	Verified bool      `json:"verified"`
+
+	RejectedReportCount *int64 `json:"rejected_report_count,omitempty"`
+	ReworkCount         *int64 `json:"rework_count,omitempty"`
 }
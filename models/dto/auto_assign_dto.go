@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkerCandidateResponse is one worker ranked for a report. Ineligible
+// workers are listed after eligible ones with the reason they were skipped.
+type WorkerCandidateResponse struct {
+	WorkerID   uuid.UUID `json:"worker_id"`
+	WorkerName string    `json:"worker_name"`
+	Eligible   bool      `json:"eligible"`
+	Score      float64   `json:"score"`
+	DistanceKm float64   `json:"distance_km"`
+	OpenJobs   int64     `json:"open_jobs"`
+	Reasoning  string    `json:"reasoning"`
+}
+
+type AssignmentSuggestionResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	ReportID   string     `json:"report_id"`
+	RoadName   string     `json:"road_name"`
+	WorkerID   uuid.UUID  `json:"worker_id"`
+	WorkerName string     `json:"worker_name"`
+	Score      float64    `json:"score"`
+	DistanceKm float64    `json:"distance_km"`
+	OpenJobs   int64      `json:"open_jobs"`
+	Reasoning  string     `json:"reasoning"`
+	Status     string     `json:"status"`
+	ReviewedBy *uuid.UUID `json:"reviewed_by"`
+	ReviewedAt *time.Time `json:"reviewed_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type PaginatedSuggestionsResponse struct {
+	Mode        string                         `json:"mode"`
+	Suggestions []AssignmentSuggestionResponse `json:"suggestions"`
+	TotalCount  int64                          `json:"total_count"`
+	Page        int                            `json:"page"`
+	Limit       int                            `json:"limit"`
+	TotalPages  int                            `json:"total_pages"`
+}
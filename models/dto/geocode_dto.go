@@ -0,0 +1,16 @@
+package dto
+
+type RoadNameSuggestion struct {
+	RoadName       string  `json:"road_name"`
+	Highway        string  `json:"highway,omitempty"`
+	DistanceMeters float64 `json:"distance_meters"`
+}
+
+// ReverseGeocodeResponse holds the canonical name of the road at a position.
+// Matched tells whether it is the road the given name referred to rather
+// than just the nearest one.
+type ReverseGeocodeResponse struct {
+	RoadName    string               `json:"road_name"`
+	Matched     bool                 `json:"matched"`
+	Suggestions []RoadNameSuggestion `json:"suggestions"`
+}
@@ -12,4 +12,4 @@ type ErrorResponse struct {
 	Error    error  `json:"errors"`
 	Message  any    `json:"message"`
 	MetaData any    `json:"meta_data"`
-}
\ No newline at end of file
+}
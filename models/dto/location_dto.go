@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StartShiftRequest must state whether the worker agrees to share their
+// location for this shift; nothing is tracked without it.
+type StartShiftRequest struct {
+	TrackingConsent *bool `json:"tracking_consent" binding:"required"`
+}
+
+type ShiftSessionResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	WorkerID        uuid.UUID  `json:"worker_id"`
+	TrackingConsent bool       `json:"tracking_consent"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at"`
+}
+
+type LocationPingRequest struct {
+	Latitude   *float64  `json:"latitude" binding:"required,gte=-90,lte=90"`
+	Longitude  *float64  `json:"longitude" binding:"required,gte=-180,lte=180"`
+	AccuracyM  float64   `json:"accuracy_m" binding:"gte=0"`
+	RecordedAt time.Time `json:"recorded_at" binding:"required"`
+}
+
+type LocationBatchRequest struct {
+	Pings []LocationPingRequest `json:"pings" binding:"required,min=1,max=100,dive"`
+}
+
+// LocationBatchResponse counts the pings stored. Pings recorded before the
+// shift started or in the future are rejected.
+type LocationBatchResponse struct {
+	Accepted int `json:"accepted"`
+	Rejected int `json:"rejected"`
+}
+
+type LocationPingResponse struct {
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	AccuracyM  float64   `json:"accuracy_m"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// WorkerPositionResponse is the last known position of a worker on a tracked
+// shift. Position fields are empty until the first ping arrives.
+type WorkerPositionResponse struct {
+	WorkerID       uuid.UUID  `json:"worker_id"`
+	WorkerName     string     `json:"worker_name"`
+	SessionID      uuid.UUID  `json:"session_id"`
+	ShiftStartedAt time.Time  `json:"shift_started_at"`
+	Latitude       *float64   `json:"latitude"`
+	Longitude      *float64   `json:"longitude"`
+	AccuracyM      *float64   `json:"accuracy_m"`
+	RecordedAt     *time.Time `json:"recorded_at"`
+	Stale          bool       `json:"stale"`
+}
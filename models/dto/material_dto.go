@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MaterialRequest struct {
+	Name     string  `json:"name" binding:"required"`
+	Unit     string  `json:"unit" binding:"required"`
+	UnitCost float64 `json:"unit_cost" binding:"gte=0"`
+	Active   *bool   `json:"active"`
+}
+
+type MaterialResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Unit      string    `json:"unit"`
+	UnitCost  float64   `json:"unit_cost"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MaterialUsageRequest is a quantity of a catalogue material, in the
+// material's unit, used on a report.
+type MaterialUsageRequest struct {
+	MaterialID uuid.UUID `json:"material_id" binding:"required"`
+	Quantity   float64   `json:"quantity" binding:"required,gt=0"`
+}
+
+type MaterialUsageResponse struct {
+	MaterialID   uuid.UUID `json:"material_id"`
+	MaterialName string    `json:"material_name"`
+	Unit         string    `json:"unit"`
+	Quantity     float64   `json:"quantity"`
+	UnitCost     float64   `json:"unit_cost"`
+	Cost         float64   `json:"cost"`
+	RecordedBy   uuid.UUID `json:"recorded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ReportCostResponse struct {
+	ReportID  string                  `json:"report_id"`
+	TotalCost float64                 `json:"total_cost"`
+	Materials []MaterialUsageResponse `json:"materials"`
+}
+
+type MaterialCostLine struct {
+	MaterialID   uuid.UUID `json:"material_id"`
+	MaterialName string    `json:"material_name"`
+	Unit         string    `json:"unit"`
+	Quantity     float64   `json:"quantity"`
+	Cost         float64   `json:"cost"`
+}
+
+// MaterialCostGroupResponse is the material cost of the reports sharing a
+// road, district or month.
+type MaterialCostGroupResponse struct {
+	Group     string             `json:"group"`
+	Reports   int64              `json:"reports"`
+	TotalCost float64            `json:"total_cost"`
+	Materials []MaterialCostLine `json:"materials"`
+}
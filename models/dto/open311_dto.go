@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// The Open311 types follow the GeoReport v2 field names. JSON responses are
+// bare arrays; the XML ones are wrapped in the list elements the spec names.
+
+type Open311Service struct {
+	ServiceCode string `json:"service_code" xml:"service_code"`
+	ServiceName string `json:"service_name" xml:"service_name"`
+	Description string `json:"description" xml:"description"`
+	Metadata    bool   `json:"metadata" xml:"metadata"`
+	Type        string `json:"type" xml:"type"`
+	Keywords    string `json:"keywords" xml:"keywords"`
+	Group       string `json:"group" xml:"group"`
+}
+
+type Open311ServiceList struct {
+	XMLName  xml.Name         `xml:"services"`
+	Services []Open311Service `xml:"service"`
+}
+
+type Open311ServiceRequest struct {
+	ServiceRequestID  string  `json:"service_request_id" xml:"service_request_id"`
+	Status            string  `json:"status" xml:"status"`
+	StatusNotes       string  `json:"status_notes" xml:"status_notes"`
+	ServiceName       string  `json:"service_name" xml:"service_name"`
+	ServiceCode       string  `json:"service_code" xml:"service_code"`
+	Description       string  `json:"description" xml:"description"`
+	AgencyResponsible string  `json:"agency_responsible" xml:"agency_responsible"`
+	ServiceNotice     string  `json:"service_notice" xml:"service_notice"`
+	RequestedDatetime string  `json:"requested_datetime" xml:"requested_datetime"`
+	UpdatedDatetime   string  `json:"updated_datetime" xml:"updated_datetime"`
+	ExpectedDatetime  string  `json:"expected_datetime" xml:"expected_datetime"`
+	Address           string  `json:"address" xml:"address"`
+	AddressID         string  `json:"address_id" xml:"address_id"`
+	Zipcode           string  `json:"zipcode" xml:"zipcode"`
+	Lat               float64 `json:"lat" xml:"lat"`
+	Long              float64 `json:"long" xml:"long"`
+	MediaURL          string  `json:"media_url" xml:"media_url"`
+}
+
+type Open311ServiceRequestList struct {
+	XMLName  xml.Name                `xml:"service_requests"`
+	Requests []Open311ServiceRequest `xml:"request"`
+}
+
+// Open311CreateRequest is the form posted to create a service request. Only
+// a location given as lat and long is supported.
+type Open311CreateRequest struct {
+	APIKey        string   `form:"api_key"`
+	ServiceCode   string   `form:"service_code" binding:"required"`
+	Lat           *float64 `form:"lat"`
+	Long          *float64 `form:"long"`
+	AddressString string   `form:"address_string" binding:"max=255"`
+	Description   string   `form:"description" binding:"max=4000"`
+	MediaURL      string   `form:"media_url" binding:"max=2048"`
+	AccountID     string   `form:"account_id"`
+}
+
+type Open311CreateResponse struct {
+	ServiceRequestID string `json:"service_request_id" xml:"service_request_id"`
+	Token            string `json:"token" xml:"token"`
+	ServiceNotice    string `json:"service_notice" xml:"service_notice"`
+	AccountID        string `json:"account_id" xml:"account_id"`
+}
+
+type Open311CreateResponseList struct {
+	XMLName  xml.Name                `xml:"service_requests"`
+	Requests []Open311CreateResponse `xml:"request"`
+}
+
+type Open311Token struct {
+	ServiceRequestID string `json:"service_request_id" xml:"service_request_id"`
+	Token            string `json:"token" xml:"token"`
+}
+
+type Open311TokenList struct {
+	XMLName  xml.Name       `xml:"service_requests"`
+	Requests []Open311Token `xml:"request"`
+}
+
+// Open311RequestsQuery filters the service request list. Dates are on when
+// the request was made.
+type Open311RequestsQuery struct {
+	ServiceRequestIDs []string
+	ServiceCode       string
+	Status            string
+	StartDate         *time.Time
+	EndDate           *time.Time
+}
+
+type Open311Error struct {
+	Code        int    `json:"code" xml:"code"`
+	Description string `json:"description" xml:"description"`
+}
+
+type Open311ErrorList struct {
+	XMLName xml.Name       `xml:"errors"`
+	Errors  []Open311Error `xml:"error"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "github.com/google/uuid"
+
+// RecurrenceRateResponse counts the repairs finished in the period and how
+// many had damage reported again close by. RecurrenceRate is a percentage of
+// Repairs.
+type RecurrenceRateResponse struct {
+	Repairs        int64   `json:"repairs"`
+	Recurred       int64   `json:"recurred"`
+	RecurrenceRate float64 `json:"recurrence_rate"`
+}
+
+type WorkerRecurrenceResponse struct {
+	WorkerID   uuid.UUID `json:"worker_id"`
+	WorkerName string    `json:"worker_name"`
+	Contractor string    `json:"contractor"`
+	RecurrenceRateResponse
+}
+
+// ContractorRecurrenceResponse is empty for repairs by in-house workers.
+type ContractorRecurrenceResponse struct {
+	Contractor string `json:"contractor"`
+	RecurrenceRateResponse
+}
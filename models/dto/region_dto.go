@@ -0,0 +1,26 @@
+package dto
+
+import "github.com/google/uuid"
+
+type RegionResponse struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	Level      string `json:"level"`
+	ParentCode string `json:"parent_code"`
+}
+
+// AdminRegionsRequest sets the regions an admin is limited to. An empty list
+// lifts the limit.
+type AdminRegionsRequest struct {
+	RegionCodes []string `json:"region_codes"`
+}
+
+type AdminRegionsResponse struct {
+	UserID  uuid.UUID        `json:"user_id"`
+	Regions []RegionResponse `json:"regions"`
+}
+
+type RegionReloadResponse struct {
+	Files   int `json:"files"`
+	Regions int `json:"regions"`
+}
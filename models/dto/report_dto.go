@@ -5,21 +5,43 @@ import "github.com/google/uuid"
 type ReportRequest struct {
 	Longitude   float64 `json:"longitude" binding:"required"`
 	Latitude    float64 `json:"latitude" binding:"required"`
-	RoadName    string  `json:"road_name" binding:"required"`
+	RoadName    string  `json:"road_name"` // optional, suggested from the location when empty
+	District    string  `json:"district"`
 	Description string  `json:"description"`
 }
 
 type ReportResponse struct {
-	ID             string    `json:"id"`
-	UserID         uuid.UUID `json:"user_id"`
-	Longitude      float64   `json:"longitude"`
-	Latitude       float64   `json:"latitude"`
-	RoadName       string    `json:"road_name"`
-	BeforeImageURL string    `json:"before_image_url"`
-	AfterImageURL  string    `json:"after_image_url"`
-	Description    string    `json:"description"`
-	DestructClass  string    `json:"destruct_class"`
-	LocationScore  float64   `json:"location_score"`
-	TotalScore     float64   `json:"total_score"`
-	Status         string    `json:"status"`
+	ID                 string     `json:"id"`
+	UserID             uuid.UUID  `json:"user_id"`
+	SourceAPIKeyID     *uuid.UUID `json:"source_api_key_id,omitempty"`
+	Longitude          float64    `json:"longitude"`
+	Latitude           float64    `json:"latitude"`
+	RoadName           string     `json:"road_name"`
+	CanonicalRoadName  string     `json:"canonical_road_name"`
+	District           string     `json:"district"`
+	ProvinceCode       string     `json:"province_code"`
+	RegencyCode        string     `json:"regency_code"`
+	DistrictCode       string     `json:"district_code"`
+	VillageCode        string     `json:"village_code"`
+	RoadSegmentID      string     `json:"road_segment_id"`
+	RoadAuthority      string     `json:"road_authority"`
+	OutsideServiceArea bool       `json:"outside_service_area"`
+	RecurrenceOf       *string    `json:"recurrence_of"`
+	RecurrenceWorkerID *uuid.UUID `json:"recurrence_worker_id"`
+	BeforeImageURL     string     `json:"before_image_url"`
+	AfterImageURL      string     `json:"after_image_url"`
+	Description        string     `json:"description"`
+	DestructClass      string     `json:"destruct_class"`
+	LocationScore      float64    `json:"location_score"`
+	TotalScore         float64    `json:"total_score"`
+	Status             string     `json:"status"`
+}
+
+type ClassifyReportRequest struct {
+	ReportID      string  `json:"report_id" binding:"required"`
+	DestructClass string  `json:"destruct_class" binding:"required"`
+	LocationScore float64 `json:"location_score"`
+	TotalScore    float64 `json:"total_score"`
+	District      string  `json:"district"`
+	Version       *int    `json:"version"`
 }
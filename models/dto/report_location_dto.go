@@ -1,9 +1,75 @@
 package dto
 
+// ReportLocationResponse is a report on the public map. When results are
+// simplified for a zoom level it stands for Count nearby reports and shows
+// the most severe of them.
 type ReportLocationResponse struct {
 	ID            string  `json:"id"`
 	Longitude     float64 `json:"longitude"`
 	Latitude      float64 `json:"latitude"`
 	DestructClass string  `json:"destruct_class"`
 	TotalScore    float64 `json:"total_score"`
+	RoadName      string  `json:"road_name"`
+	Status        string  `json:"status"`
+	Count         int     `json:"count,omitempty"`
+}
+
+// ReportSearchRequest filters the public map reports. BBox is
+// [min_lng, min_lat, max_lng, max_lat]; a radius needs latitude and longitude;
+// polygon is a GeoJSON Polygon. Filters given together must all match.
+type ReportSearchRequest struct {
+	BBox      []float64        `json:"bbox"`
+	Latitude  *float64         `json:"latitude"`
+	Longitude *float64         `json:"longitude"`
+	RadiusKm  float64          `json:"radius_km"`
+	Polygon   *GeoJSONGeometry `json:"polygon"`
+	Zoom      *int             `json:"zoom"`
+	Limit     int              `json:"limit"`
+}
+
+type ReportSearchResponse struct {
+	Reports    []ReportLocationResponse `json:"reports"`
+	Returned   int                      `json:"returned"`
+	Truncated  bool                     `json:"truncated"`
+	Simplified bool                     `json:"simplified"`
+}
+
+// ReportAggregateRequest selects the public map area to aggregate. BBox is
+// [min_lng, min_lat, max_lng, max_lat].
+type ReportAggregateRequest struct {
+	BBox []float64 `json:"bbox"`
+	Zoom int       `json:"zoom"`
+}
+
+// ReportClusterResponse stands for Count nearby reports. ID, DestructClass
+// and MaxScore belong to the most severe of them.
+type ReportClusterResponse struct {
+	ID            string  `json:"id"`
+	Latitude      float64 `json:"latitude"`
+	Longitude     float64 `json:"longitude"`
+	Count         int64   `json:"count"`
+	MaxScore      float64 `json:"max_score"`
+	DestructClass string  `json:"destruct_class"`
+}
+
+type ReportClustersResponse struct {
+	Zoom     int                     `json:"zoom"`
+	Total    int64                   `json:"total"`
+	Clusters []ReportClusterResponse `json:"clusters"`
+}
+
+// HeatmapCellResponse is one heatmap grid cell, centred on its reports and
+// weighted by the sum of their total scores.
+type HeatmapCellResponse struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Count     int64   `json:"count"`
+	Weight    float64 `json:"weight"`
+}
+
+type HeatmapResponse struct {
+	Zoom       int                   `json:"zoom"`
+	CellPixels int                   `json:"cell_pixels"`
+	MaxWeight  float64               `json:"max_weight"`
+	Cells      []HeatmapCellResponse `json:"cells"`
 }
@@ -0,0 +1,66 @@
+package dto
+
+import "time"
+
+type RoadReloadResponse struct {
+	Files    int `json:"files"`
+	Segments int `json:"segments"`
+}
+
+// RoadQueueResponse is the number of open reports waiting for the agency
+// responsible for a road authority. Own marks the queues this agency handles.
+type RoadQueueResponse struct {
+	Authority string `json:"authority"`
+	Agency    string `json:"agency,omitempty"`
+	Own       bool   `json:"own"`
+	Open      int64  `json:"open"`
+}
+
+// ForwardReportRequest flags a report on a road outside our authority for
+// forwarding. Authority defaults to the one of the road the report is on.
+type ForwardReportRequest struct {
+	ReportID  string `json:"report_id" binding:"required"`
+	Authority string `json:"authority"`
+	Note      string `json:"note"`
+	Version   *int   `json:"version"`
+}
+
+// RoadConditionResponse sums up the reports on one road segment or, for
+// reports not snapped to a segment, one normalised road name. The condition
+// index runs from 100 for a road without known damage down towards 0.
+type RoadConditionResponse struct {
+	ID             string         `json:"id"`
+	SegmentID      string         `json:"segment_id,omitempty"`
+	Name           string         `json:"name"`
+	Authority      string         `json:"authority,omitempty"`
+	ConditionIndex float64        `json:"condition_index"`
+	Reports        int            `json:"reports"`
+	ClassCounts    map[string]int `json:"class_counts"`
+	Open           int            `json:"open"`
+	Closed         int            `json:"closed"`
+	OpenRatio      float64        `json:"open_ratio"`
+	AvgRepairHours float64        `json:"avg_repair_hours"`
+	Recurrences    int            `json:"recurrences"`
+	RecurrenceRate float64        `json:"recurrence_rate"`
+	LastReportAt   *time.Time     `json:"last_report_at"`
+}
+
+type RoadConditionPoint struct {
+	Month          string  `json:"month"`
+	ConditionIndex float64 `json:"condition_index"`
+	Open           int     `json:"open"`
+	Recurrences    int     `json:"recurrences"`
+}
+
+type RoadConditionDetailResponse struct {
+	RoadConditionResponse
+	History []RoadConditionPoint `json:"history"`
+}
+
+type PaginatedRoadConditionsResponse struct {
+	Roads      []RoadConditionResponse `json:"roads"`
+	TotalCount int64                   `json:"total_count"`
+	Page       int                     `json:"page"`
+	Limit      int                     `json:"limit"`
+	TotalPages int                     `json:"total_pages"`
+}
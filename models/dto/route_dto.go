@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RouteStopResponse struct {
+	Sequence         int        `json:"sequence"`
+	ReportID         string     `json:"report_id"`
+	RoadName         string     `json:"road_name"`
+	Latitude         float64    `json:"latitude"`
+	Longitude        float64    `json:"longitude"`
+	DestructClass    string     `json:"destruct_class"`
+	TotalScore       float64    `json:"total_score"`
+	Deadline         *time.Time `json:"deadline"`
+	LegDistanceKm    float64    `json:"leg_distance_km"`
+	CumulativeKm     float64    `json:"cumulative_km"`
+	EstimatedArrival time.Time  `json:"estimated_arrival"`
+	Late             bool       `json:"late"`
+}
+
+// WorkerRouteResponse is the suggested visiting order of a worker's open
+// assignments. StartedFrom is current_position, last_known_position or base.
+type WorkerRouteResponse struct {
+	WorkerID                 uuid.UUID           `json:"worker_id"`
+	StartLatitude            float64             `json:"start_latitude"`
+	StartLongitude           float64             `json:"start_longitude"`
+	StartedFrom              string              `json:"started_from"`
+	TotalDistanceKm          float64             `json:"total_distance_km"`
+	EstimatedDurationMinutes int                 `json:"estimated_duration_minutes"`
+	LateStops                int                 `json:"late_stops"`
+	Stops                    []RouteStopResponse `json:"stops"`
+	GeneratedAt              time.Time           `json:"generated_at"`
+}
+
+type GPX struct {
+	XMLName   xml.Name   `xml:"gpx"`
+	Version   string     `xml:"version,attr"`
+	Creator   string     `xml:"creator,attr"`
+	Xmlns     string     `xml:"xmlns,attr"`
+	Waypoints []GPXPoint `xml:"wpt"`
+	Route     GPXRoute   `xml:"rte"`
+}
+
+type GPXRoute struct {
+	Name   string     `xml:"name"`
+	Points []GPXPoint `xml:"rtept"`
+}
+
+type GPXPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Name string  `xml:"name,omitempty"`
+	Desc string  `xml:"desc,omitempty"`
+}
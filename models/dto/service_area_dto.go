@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ServiceAreaRequest struct {
+	Name     string          `json:"name" binding:"required"`
+	Geometry GeoJSONGeometry `json:"geometry" binding:"required"`
+	Active   *bool           `json:"active"`
+}
+
+type ServiceAreaResponse struct {
+	ID        uuid.UUID       `json:"id"`
+	Name      string          `json:"name"`
+	Geometry  GeoJSONGeometry `json:"geometry"`
+	MinLat    float64         `json:"min_lat"`
+	MinLng    float64         `json:"min_lng"`
+	MaxLat    float64         `json:"max_lat"`
+	MaxLng    float64         `json:"max_lng"`
+	Active    bool            `json:"active"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
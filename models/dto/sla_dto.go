@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SLAComplianceResponse summarises how many finished reports met their
+// deadline. ComplianceRate is a percentage of Completed.
+type SLAComplianceResponse struct {
+	Completed      int64   `json:"completed"`
+	OnTime         int64   `json:"on_time"`
+	Late           int64   `json:"late"`
+	ComplianceRate float64 `json:"compliance_rate"`
+	AvgDelayHours  float64 `json:"avg_delay_hours"`
+	OpenOverdue    int64   `json:"open_overdue"`
+}
+
+type WorkerSLAResponse struct {
+	WorkerID   uuid.UUID `json:"worker_id"`
+	WorkerName string    `json:"worker_name"`
+	SLAComplianceResponse
+}
+
+type RoadSLAResponse struct {
+	RoadName string `json:"road_name"`
+	SLAComplianceResponse
+}
+
+type OverdueReportResponse struct {
+	ReportID     string     `json:"report_id"`
+	WorkerID     *uuid.UUID `json:"worker_id"`
+	WorkerName   string     `json:"worker_name"`
+	RoadName     string     `json:"road_name"`
+	Deadline     *time.Time `json:"deadline"`
+	OverdueHours float64    `json:"overdue_hours"`
+	OverdueAt    *time.Time `json:"overdue_at"`
+	EscalatedAt  *time.Time `json:"escalated_at"`
+}
+
+type SLAPolicyRequest struct {
+	Name            string  `json:"name" binding:"required"`
+	DestructClass   string  `json:"destruct_class"`
+	MinTotalScore   float64 `json:"min_total_score" binding:"gte=0"`
+	ResolutionHours int     `json:"resolution_hours" binding:"required,gt=0"`
+	Active          *bool   `json:"active"`
+}
+
+type SLAPolicyResponse struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	DestructClass   string    `json:"destruct_class"`
+	MinTotalScore   float64   `json:"min_total_score"`
+	ResolutionHours int       `json:"resolution_hours"`
+	Active          bool      `json:"active"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ReportSLAPolicyResponse is the policy that set a report's deadline.
+type ReportSLAPolicyResponse struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	ResolutionHours int       `json:"resolution_hours"`
+}
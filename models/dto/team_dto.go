@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TeamRequest sets a team's name, leader and members. The leader is added to
+// the members when missing.
+type TeamRequest struct {
+	Name      string      `json:"name" binding:"required"`
+	LeaderID  uuid.UUID   `json:"leader_id" binding:"required"`
+	MemberIDs []uuid.UUID `json:"member_ids"`
+}
+
+type TeamMemberResponse struct {
+	WorkerID   uuid.UUID `json:"worker_id"`
+	WorkerName string    `json:"worker_name"`
+	IsLeader   bool      `json:"is_leader"`
+}
+
+type TeamResponse struct {
+	ID         uuid.UUID            `json:"id"`
+	Name       string               `json:"name"`
+	LeaderID   uuid.UUID            `json:"leader_id"`
+	LeaderName string               `json:"leader_name"`
+	Members    []TeamMemberResponse `json:"members"`
+	CreatedAt  time.Time            `json:"created_at"`
+	UpdatedAt  time.Time            `json:"updated_at"`
+}
+
+type AssignTeamRequest struct {
+	ReportID   string     `json:"report_id" binding:"required"`
+	TeamID     uuid.UUID  `json:"team_id" binding:"required"`
+	AdminNotes string     `json:"admin_notes"`
+	Deadline   *time.Time `json:"deadline"`
+	Version    *int       `json:"version"`
+	Force      bool       `json:"force"`
+}
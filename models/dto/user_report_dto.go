@@ -1,22 +1,42 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type UserReportResponse struct {
-	ID             string     `json:"id"`
-	Longitude      float64    `json:"longitude"`
-	Latitude       float64    `json:"latitude"`
-	RoadName       string     `json:"road_name"`
-	BeforeImageURL string     `json:"before_image_url"`
-	AfterImageURL  string     `json:"after_image_url"`
-	Description    string     `json:"description"`
-	DestructClass  string     `json:"destruct_class"`
-	LocationScore  float64    `json:"location_score"`
-	TotalScore     float64    `json:"total_score"`
-	Status         string     `json:"status"`
-	AdminNotes     string     `json:"admin_notes"`
-	Deadline       *time.Time `json:"deadline"`
-	CreatedAt      time.Time  `json:"created_at"`
+	ID                 string                   `json:"id"`
+	Longitude          float64                  `json:"longitude"`
+	Latitude           float64                  `json:"latitude"`
+	RoadName           string                   `json:"road_name"`
+	District           string                   `json:"district,omitempty"`
+	BeforeImageURL     string                   `json:"before_image_url"`
+	AfterImageURL      string                   `json:"after_image_url"`
+	Description        string                   `json:"description"`
+	DestructClass      string                   `json:"destruct_class"`
+	LocationScore      float64                  `json:"location_score"`
+	TotalScore         float64                  `json:"total_score"`
+	Status             string                   `json:"status"`
+	AdminNotes         string                   `json:"admin_notes"`
+	Deadline           *time.Time               `json:"deadline"`
+	SLAPolicy          *ReportSLAPolicyResponse `json:"sla_policy,omitempty"`
+	RejectReason       string                   `json:"reject_reason,omitempty"`
+	RejectNote         string                   `json:"reject_note,omitempty"`
+	ReworkCount        int                      `json:"rework_count"`
+	RequestedClass     string                   `json:"requested_class,omitempty"`
+	RecurrenceOf       *string                  `json:"recurrence_of,omitempty"`
+	RecurrenceWorkerID *uuid.UUID               `json:"recurrence_worker_id,omitempty"`
+	FinishedAt         *time.Time               `json:"finished_at"`
+	AssignmentStatus   string                   `json:"assignment_status,omitempty"`
+	WorkStage          string                   `json:"work_stage,omitempty"`
+	WorkStageAt        *time.Time               `json:"work_stage_at,omitempty"`
+	TeamID             *uuid.UUID               `json:"team_id,omitempty"`
+	OverdueAt          *time.Time               `json:"overdue_at,omitempty"`
+	EscalatedAt        *time.Time               `json:"escalated_at,omitempty"`
+	Version            int                      `json:"version"`
+	CreatedAt          time.Time                `json:"created_at"`
 }
 
 type PaginatedReportsResponse struct {
@@ -29,4 +49,31 @@ type PaginatedReportsResponse struct {
 
 type VerifyReportRequest struct {
 	ReportID string `json:"report_id" binding:"required"`
+	Version  *int   `json:"version"`
+}
+
+type RejectReportRequest struct {
+	ReportID   string `json:"report_id" binding:"required"`
+	ReasonCode string `json:"reason_code" binding:"required"`
+	Note       string `json:"note"`
+	Version    *int   `json:"version"`
+}
+
+type ReworkReportRequest struct {
+	ReportID string     `json:"report_id" binding:"required"`
+	Notes    string     `json:"notes" binding:"required"`
+	Deadline *time.Time `json:"deadline"`
+	Version  *int       `json:"version"`
+}
+
+type ReportReworkResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	WorkerID         uuid.UUID  `json:"worker_id"`
+	AfterImageURL    string     `json:"after_image_url"`
+	FinishedAt       *time.Time `json:"finished_at"`
+	Notes            string     `json:"notes"`
+	PreviousDeadline *time.Time `json:"previous_deadline"`
+	NewDeadline      *time.Time `json:"new_deadline"`
+	RequestedBy      *uuid.UUID `json:"requested_by"`
+	CreatedAt        time.Time  `json:"created_at"`
 }
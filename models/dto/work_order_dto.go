@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReportProgressRequest posts a note on a work order. Setting stage moves the
+// work order forward; stage done finishes the report, needs a photo and
+// records the materials used.
+type ReportProgressRequest struct {
+	ReportID  string                 `json:"report_id" binding:"required"`
+	Stage     string                 `json:"stage"`
+	Note      string                 `json:"note"`
+	Materials []MaterialUsageRequest `json:"materials"`
+	Version   *int                   `json:"version"`
+}
+
+type ReportProgressResponse struct {
+	ID         uuid.UUID `json:"id"`
+	WorkerID   uuid.UUID `json:"worker_id"`
+	WorkerName string    `json:"worker_name"`
+	Stage      string    `json:"stage,omitempty"`
+	Note       string    `json:"note"`
+	PhotoURLs  []string  `json:"photo_urls"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WorkOrderResponse is the full work order seen by the admin and the crew.
+type WorkOrderResponse struct {
+	ReportID    string                   `json:"report_id"`
+	Status      string                   `json:"status"`
+	WorkStage   string                   `json:"work_stage"`
+	WorkStageAt *time.Time               `json:"work_stage_at"`
+	Progress    []ReportProgressResponse `json:"progress"`
+}
+
+// WorkStageSummaryResponse is one reached stage as shown to the citizen, without
+// worker names or notes.
+type WorkStageSummaryResponse struct {
+	Stage     string    `json:"stage"`
+	Label     string    `json:"label"`
+	ReachedAt time.Time `json:"reached_at"`
+	PhotoURLs []string  `json:"photo_urls"`
+}
+
+type ReportTimelineResponse struct {
+	ReportID  string                     `json:"report_id"`
+	Status    string                     `json:"status"`
+	WorkStage string                     `json:"work_stage"`
+	Stages    []WorkStageSummaryResponse `json:"stages"`
+}
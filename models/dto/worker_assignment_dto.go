@@ -11,16 +11,73 @@ type AssignWorkerRequest struct {
 	WorkerID   uuid.UUID  `json:"worker_id" binding:"required"`
 	AdminNotes string     `json:"admin_notes"`
 	Deadline   *time.Time `json:"deadline"`
+	Version    *int       `json:"version"`
+	Force      bool       `json:"force"`
 }
 
 type AssignedWorkerResponse struct {
-	WorkerName string  `json:"worker_name"`
-	RoadName   string  `json:"road_name"`
-	Longitude  float64 `json:"longitude"`
-	Latitude   float64 `json:"latitude"`
-	Status     string  `json:"status"`
+	ReportID         string     `json:"report_id"`
+	WorkerID         *uuid.UUID `json:"worker_id"`
+	WorkerName       string     `json:"worker_name"`
+	TeamID           *uuid.UUID `json:"team_id,omitempty"`
+	RoadName         string     `json:"road_name"`
+	Longitude        float64    `json:"longitude"`
+	Latitude         float64    `json:"latitude"`
+	Status           string     `json:"status"`
+	AssignmentStatus string     `json:"assignment_status"`
+	WorkStage        string     `json:"work_stage"`
+	Deadline         *time.Time `json:"deadline"`
+	OverdueAt        *time.Time `json:"overdue_at,omitempty"`
+	EscalatedAt      *time.Time `json:"escalated_at,omitempty"`
+	Version          int        `json:"version"`
 }
 
 type WorkerReportRequest struct {
+	ReportID  string                 `json:"report_id" binding:"required"`
+	Materials []MaterialUsageRequest `json:"materials"`
+	Version   *int                   `json:"version"`
+}
+
+type AcceptAssignmentRequest struct {
+	ReportID string `json:"report_id" binding:"required"`
+	Version  *int   `json:"version"`
+}
+
+type DeclineAssignmentRequest struct {
+	ReportID string `json:"report_id" binding:"required"`
+	Reason   string `json:"reason" binding:"required"`
+	Version  *int   `json:"version"`
+}
+
+type ReassignWorkerRequest struct {
+	ReportID   string     `json:"report_id" binding:"required"`
+	WorkerID   uuid.UUID  `json:"worker_id" binding:"required"`
+	Reason     string     `json:"reason" binding:"required"`
+	AdminNotes string     `json:"admin_notes"`
+	Deadline   *time.Time `json:"deadline"`
+	Version    *int       `json:"version"`
+	Force      bool       `json:"force"`
+}
+
+type UnassignWorkerRequest struct {
 	ReportID string `json:"report_id" binding:"required"`
+	Reason   string `json:"reason" binding:"required"`
+	Version  *int   `json:"version"`
+}
+
+type ReportAssignmentResponse struct {
+	ID          uuid.UUID   `json:"id"`
+	WorkerID    uuid.UUID   `json:"worker_id"`
+	WorkerName  string      `json:"worker_name"`
+	TeamID      *uuid.UUID  `json:"team_id,omitempty"`
+	CrewIDs     []uuid.UUID `json:"crew_ids,omitempty"`
+	AssignedBy  *uuid.UUID  `json:"assigned_by"`
+	Status      string      `json:"status"`
+	AdminNotes  string      `json:"admin_notes"`
+	Deadline    *time.Time  `json:"deadline"`
+	Reason      string      `json:"reason"`
+	Reasoning   string      `json:"reasoning,omitempty"`
+	RespondedAt *time.Time  `json:"responded_at"`
+	EndedAt     *time.Time  `json:"ended_at"`
+	CreatedAt   time.Time   `json:"created_at"`
 }
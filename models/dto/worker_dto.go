@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WorkerProfileRequest struct {
+	BaseLatitude      *float64 `json:"base_latitude" binding:"required,gte=-90,lte=90"`
+	BaseLongitude     *float64 `json:"base_longitude" binding:"required,gte=-180,lte=180"`
+	BaseRadiusKm      float64  `json:"base_radius_km" binding:"gte=0"`
+	Skills            []string `json:"skills"`
+	MaxConcurrentJobs int      `json:"max_concurrent_jobs" binding:"gte=0"`
+	AutoAssign        *bool    `json:"auto_assign"`
+	Contractor        string   `json:"contractor" binding:"max=100"`
+}
+
+type WorkerProfileResponse struct {
+	WorkerID          uuid.UUID `json:"worker_id"`
+	WorkerName        string    `json:"worker_name"`
+	BaseLatitude      float64   `json:"base_latitude"`
+	BaseLongitude     float64   `json:"base_longitude"`
+	BaseRadiusKm      float64   `json:"base_radius_km"`
+	Skills            []string  `json:"skills"`
+	MaxConcurrentJobs int       `json:"max_concurrent_jobs"`
+	AutoAssign        bool      `json:"auto_assign"`
+	Contractor        string    `json:"contractor"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type WorkerShiftRequest struct {
+	Weekday   *int   `json:"weekday" binding:"required,gte=0,lte=6"`
+	StartTime string `json:"start_time" binding:"required"`
+	EndTime   string `json:"end_time" binding:"required"`
+}
+
+// UpdateWorkerShiftsRequest replaces the whole weekly schedule. An empty list
+// means the worker has no fixed hours and is treated as always on shift.
+type UpdateWorkerShiftsRequest struct {
+	Shifts []WorkerShiftRequest `json:"shifts" binding:"dive"`
+}
+
+type WorkerShiftResponse struct {
+	Weekday   int    `json:"weekday"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+type WorkerLeaveRequest struct {
+	Type      string `json:"type" binding:"required"`
+	StartDate string `json:"start_date" binding:"required"`
+	EndDate   string `json:"end_date" binding:"required"`
+	Reason    string `json:"reason"`
+}
+
+type WorkerLeaveResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	WorkerID  uuid.UUID  `json:"worker_id"`
+	Type      string     `json:"type"`
+	StartDate string     `json:"start_date"`
+	EndDate   string     `json:"end_date"`
+	Reason    string     `json:"reason"`
+	CreatedBy *uuid.UUID `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type WorkerScheduleResponse struct {
+	WorkerID          uuid.UUID             `json:"worker_id"`
+	WorkerName        string                `json:"worker_name"`
+	MaxConcurrentJobs int                   `json:"max_concurrent_jobs"`
+	OpenJobs          int64                 `json:"open_jobs"`
+	Shifts            []WorkerShiftResponse `json:"shifts"`
+	Leaves            []WorkerLeaveResponse `json:"leaves"`
+}
+
+// WorkerAvailabilityResponse is a worker's availability at one moment. Reasons
+// make the worker unavailable; warnings do not.
+type WorkerAvailabilityResponse struct {
+	WorkerID          uuid.UUID `json:"worker_id"`
+	Available         bool      `json:"available"`
+	OnShift           bool      `json:"on_shift"`
+	LeaveType         string    `json:"leave_type,omitempty"`
+	OpenJobs          int64     `json:"open_jobs"`
+	MaxConcurrentJobs int       `json:"max_concurrent_jobs"`
+	Reasons           []string  `json:"reasons"`
+	Warnings          []string  `json:"warnings"`
+}
+
+type WorkerAvailabilityDay struct {
+	Date      string                `json:"date"`
+	Shifts    []WorkerShiftResponse `json:"shifts"`
+	LeaveType string                `json:"leave_type,omitempty"`
+	Available bool                  `json:"available"`
+}
+
+type WorkerAvailabilityRangeResponse struct {
+	WorkerID          uuid.UUID               `json:"worker_id"`
+	WorkerName        string                  `json:"worker_name"`
+	OpenJobs          int64                   `json:"open_jobs"`
+	MaxConcurrentJobs int                     `json:"max_concurrent_jobs"`
+	AtCapacity        bool                    `json:"at_capacity"`
+	Days              []WorkerAvailabilityDay `json:"days"`
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type APIKey struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name        string     `gorm:"type:varchar(100);not null" json:"name"`
+	Prefix      string     `gorm:"type:varchar(20);not null;index" json:"prefix"`
+	KeyHash     string     `gorm:"column:key_hash;type:varchar(64);not null;unique" json:"-"`
+	Permissions string     `gorm:"type:text" json:"permissions"` // comma separated, see API_KEY_PERMISSIONS
+	CreatedBy   uuid.UUID  `gorm:"type:uuid" json:"created_by"`
+	ExpiresAt   *time.Time `gorm:"type:timestamp" json:"expires_at"`
+	LastUsedAt  *time.Time `gorm:"type:timestamp" json:"last_used_at"`
+	LastUsedIP  string     `gorm:"column:last_used_ip;type:varchar(64)" json:"last_used_ip"`
+	UsageCount  int64      `gorm:"default:0" json:"usage_count"`
+	RevokedAt   *time.Time `gorm:"type:timestamp" json:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (k *APIKey) PermissionList() []string {
+	if k.Permissions == "" {
+		return []string{}
+	}
+	return strings.Split(k.Permissions, ",")
+}
+
+func (k *APIKey) HasPermission(permission string) bool {
+	for _, p := range k.PermissionList() {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyUsage counts requests per key per day for the admin panel.
+type APIKeyUsage struct {
+	APIKeyID uuid.UUID `gorm:"column:api_key_id;type:uuid;primary_key" json:"api_key_id"`
+	Day      time.Time `gorm:"type:date;primary_key" json:"day"`
+	Count    int64     `gorm:"default:0" json:"count"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AssignmentSuggestion is the worker the auto-assignment engine picked for a
+// report, kept with the reasoning so dispatchers can review or apply it.
+type AssignmentSuggestion struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ReportID   string     `gorm:"type:text;not null;index" json:"report_id"`
+	WorkerID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"worker_id"`
+	Score      float64    `gorm:"type:numeric" json:"score"`
+	DistanceKm float64    `gorm:"type:numeric" json:"distance_km"`
+	OpenJobs   int64      `json:"open_jobs"`
+	Reasoning  string     `gorm:"type:text" json:"reasoning"`
+	Status     string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	ReviewedBy *uuid.UUID `gorm:"type:uuid" json:"reviewed_by"`
+	ReviewedAt *time.Time `gorm:"type:timestamp" json:"reviewed_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
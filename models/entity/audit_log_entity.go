@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditLog struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Action     string     `gorm:"type:varchar(50);not null;index" json:"action"`
+	ActorType  string     `gorm:"type:varchar(20);index" json:"actor_type"` // user or api_key, telling what ActorID refers to
+	ActorID    *uuid.UUID `gorm:"type:uuid;index" json:"actor_id"`
+	ActorRole  string     `gorm:"type:varchar(20)" json:"actor_role"`
+	TargetType string     `gorm:"type:varchar(30);index:idx_audit_target" json:"target_type"`
+	TargetID   string     `gorm:"type:text;index:idx_audit_target" json:"target_id"`
+	IP         string     `gorm:"column:ip;type:varchar(64)" json:"ip"`
+	RequestID  string     `gorm:"type:varchar(64);index" json:"request_id"`
+	Metadata   string     `gorm:"type:text" json:"metadata"` // JSON object
+	CreatedAt  time.Time  `gorm:"index" json:"created_at"`
+}
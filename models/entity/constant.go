@@ -7,12 +7,273 @@ const (
 	STATUS_FINISH_BY_WORKER = "finish by worker"
 	STATUS_FINISHED         = "finished"
 	STATUS_COMPLETED        = "complete"
+	STATUS_REJECTED         = "rejected"
+
+	// Assignment Status
+	ASSIGNMENT_PENDING    = "pending"
+	ASSIGNMENT_ACCEPTED   = "accepted"
+	ASSIGNMENT_DECLINED   = "declined"
+	ASSIGNMENT_REASSIGNED = "reassigned"
+	ASSIGNMENT_UNASSIGNED = "unassigned"
+	ASSIGNMENT_COMPLETED  = "completed"
+
+	// Work Order Stages
+	WORK_STAGE_SURVEYED            = "surveyed"
+	WORK_STAGE_MATERIALS_REQUESTED = "materials_requested"
+	WORK_STAGE_IN_PROGRESS         = "in_progress"
+	WORK_STAGE_DONE                = "done"
 
 	// Roles
 	ROLE_ADMIN  = "admin"
 	ROLE_WORKER = "worker"
 	ROLE_USER   = "user"
 
+	// ROLE_SERVICE is assigned to requests authenticated with an API key
+	ROLE_SERVICE = "service"
+
+	// ROLE_SUPERVISOR receives escalations of overdue reports
+	ROLE_SUPERVISOR = "supervisor"
+
+	// ROLE_SYSTEM marks actions taken by background jobs in the audit log
+	ROLE_SYSTEM = "system"
+
 	// Destruct Class
 	DESTRUCT_CLASS_GOOD = "good"
+
+	// API Key Permissions
+	PERMISSION_REPORTS_READ     = "reports:read"
+	PERMISSION_REPORTS_CLASSIFY = "reports:classify"
+	PERMISSION_ASSIGNMENTS_READ = "assignments:read"
+	PERMISSION_WORKERS_READ     = "workers:read"
+	PERMISSION_REPORTS_CREATE   = "reports:create"
+)
+
+const (
+	// Audit Actions
+	AUDIT_LOGIN                 = "login"
+	AUDIT_LOGIN_FAILED          = "login_failed"
+	AUDIT_ROLE_CHANGE           = "role_change"
+	AUDIT_REPORT_ASSIGN         = "report_assign"
+	AUDIT_REPORT_VERIFY         = "report_verify"
+	AUDIT_REPORT_CLASSIFY       = "report_classify"
+	AUDIT_REPORT_DELETE         = "report_delete"
+	AUDIT_REPORT_REJECT         = "report_reject"
+	AUDIT_REPORT_REWORK         = "report_rework"
+	AUDIT_REPORT_ACCEPT         = "report_accept"
+	AUDIT_REPORT_DECLINE        = "report_decline"
+	AUDIT_REPORT_REASSIGN       = "report_reassign"
+	AUDIT_REPORT_UNASSIGN       = "report_unassign"
+	AUDIT_REPORT_AUTO_ASSIGN    = "report_auto_assign"
+	AUDIT_SUGGESTION_DISMISS    = "suggestion_dismiss"
+	AUDIT_WORKER_PROFILE_UPDATE = "worker_profile_update"
+	AUDIT_WORKER_SHIFTS_UPDATE  = "worker_shifts_update"
+	AUDIT_WORKER_LEAVE_CREATE   = "worker_leave_create"
+	AUDIT_WORKER_LEAVE_DELETE   = "worker_leave_delete"
+	AUDIT_REPORT_OVERDUE        = "report_overdue"
+	AUDIT_REPORT_ESCALATE       = "report_escalate"
+	AUDIT_SLA_POLICY_CREATE     = "sla_policy_create"
+	AUDIT_SLA_POLICY_UPDATE     = "sla_policy_update"
+	AUDIT_SLA_POLICY_DELETE     = "sla_policy_delete"
+	AUDIT_SHIFT_START           = "shift_start"
+	AUDIT_SHIFT_END             = "shift_end"
+	AUDIT_TEAM_CREATE           = "team_create"
+	AUDIT_TEAM_UPDATE           = "team_update"
+	AUDIT_TEAM_DELETE           = "team_delete"
+	AUDIT_MATERIAL_CREATE       = "material_create"
+	AUDIT_MATERIAL_UPDATE       = "material_update"
+	AUDIT_MATERIAL_DELETE       = "material_delete"
+	AUDIT_REGION_RELOAD         = "region_reload"
+	AUDIT_ADMIN_REGIONS_UPDATE  = "admin_regions_update"
+	AUDIT_ROAD_RELOAD           = "road_reload"
+	AUDIT_REPORT_FORWARD        = "report_forward"
+	AUDIT_SERVICE_AREA_CREATE   = "service_area_create"
+	AUDIT_SERVICE_AREA_UPDATE   = "service_area_update"
+	AUDIT_SERVICE_AREA_DELETE   = "service_area_delete"
+	AUDIT_API_KEY_CREATE        = "api_key_create"
+	AUDIT_API_KEY_REVOKE        = "api_key_revoke"
+
+	// Audit Actors
+	AUDIT_ACTOR_USER    = "user"
+	AUDIT_ACTOR_API_KEY = "api_key"
+
+	// Audit Targets
+	AUDIT_TARGET_USER         = "user"
+	AUDIT_TARGET_REPORT       = "report"
+	AUDIT_TARGET_API_KEY      = "api_key"
+	AUDIT_TARGET_SLA_POLICY   = "sla_policy"
+	AUDIT_TARGET_TEAM         = "team"
+	AUDIT_TARGET_MATERIAL     = "material"
+	AUDIT_TARGET_REGION       = "region"
+	AUDIT_TARGET_ROAD         = "road"
+	AUDIT_TARGET_SERVICE_AREA = "service_area"
+)
+
+const (
+	// Report Rejection Reasons
+	REJECT_REASON_SPAM        = "spam"
+	REJECT_REASON_OUT_OF_AREA = "out_of_area"
+	REJECT_REASON_NOT_ROAD    = "not_road"
+	REJECT_REASON_DUPLICATE   = "duplicate"
+	REJECT_REASON_OTHER       = "other"
+
+	// Notification Types
+	NOTIFICATION_REPORT_REJECTED      = "report_rejected"
+	NOTIFICATION_REPORT_REWORK        = "report_rework"
+	NOTIFICATION_REPORT_ASSIGNED      = "report_assigned"
+	NOTIFICATION_REPORT_DECLINED      = "report_declined"
+	NOTIFICATION_REPORT_REVOKED       = "report_unassigned"
+	NOTIFICATION_ASSIGNMENT_SUGGESTED = "assignment_suggested"
+	NOTIFICATION_WORKER_LEAVE         = "worker_leave"
+	NOTIFICATION_DEADLINE_SOON        = "deadline_soon"
+	NOTIFICATION_REPORT_OVERDUE       = "report_overdue"
+	NOTIFICATION_REPORT_ESCALATED     = "report_escalated"
+	NOTIFICATION_REPORT_PROGRESS      = "report_progress"
+	NOTIFICATION_REPORT_STAGE         = "report_stage"
+	NOTIFICATION_REPORT_FORWARDED     = "report_forwarded"
+	NOTIFICATION_REPORT_RECURRENCE    = "report_recurrence"
+)
+
+var REJECT_REASONS = map[string]string{
+	REJECT_REASON_SPAM:        "Spam or abusive content",
+	REJECT_REASON_OUT_OF_AREA: "Outside of our service area",
+	REJECT_REASON_NOT_ROAD:    "Not a road damage report",
+	REJECT_REASON_DUPLICATE:   "Duplicate of an existing report",
+	REJECT_REASON_OTHER:       "Other",
+}
+
+var USER_ROLES = map[string]bool{
+	ROLE_ADMIN:      true,
+	ROLE_WORKER:     true,
+	ROLE_USER:       true,
+	ROLE_SUPERVISOR: true,
+}
+
+// WORK_STAGES lists the work order stages in the order a repair goes through
+// them.
+var WORK_STAGES = []string{
+	WORK_STAGE_SURVEYED,
+	WORK_STAGE_MATERIALS_REQUESTED,
+	WORK_STAGE_IN_PROGRESS,
+	WORK_STAGE_DONE,
+}
+
+// WORK_STAGE_LABELS are the descriptions shown to the reporting citizen.
+var WORK_STAGE_LABELS = map[string]string{
+	WORK_STAGE_SURVEYED:            "The damage has been surveyed",
+	WORK_STAGE_MATERIALS_REQUESTED: "Materials have been requested",
+	WORK_STAGE_IN_PROGRESS:         "Repair is in progress",
+	WORK_STAGE_DONE:                "Repair is done",
+}
+
+// WorkStageIndex returns the position of the stage in WORK_STAGES, or -1 when
+// the stage is unknown or empty.
+func WorkStageIndex(stage string) int {
+	for i, s := range WORK_STAGES {
+		if s == stage {
+			return i
+		}
+	}
+	return -1
+}
+
+var API_KEY_PERMISSIONS = map[string]bool{
+	PERMISSION_REPORTS_READ:     true,
+	PERMISSION_REPORTS_CLASSIFY: true,
+	PERMISSION_ASSIGNMENTS_READ: true,
+	PERMISSION_WORKERS_READ:     true,
+	PERMISSION_REPORTS_CREATE:   true,
+}
+
+const (
+	// Open311 GeoReport v2. Reports not classified as damage yet belong to the
+	// generic service, classified ones to a service per destruct class.
+	OPEN311_SERVICE_ROAD_DAMAGE = "road_damage"
+	OPEN311_STATUS_OPEN         = "open"
+	OPEN311_STATUS_CLOSED       = "closed"
+	OPEN311_FORMAT_JSON         = "json"
+	OPEN311_FORMAT_XML          = "xml"
+
+	// Service Area Modes, what happens to reports outside every service area
+	SERVICE_AREA_MODE_REJECT = "reject"
+	SERVICE_AREA_MODE_FLAG   = "flag"
+
+	// Auto Assignment Modes
+	AUTO_ASSIGN_OFF     = "off"
+	AUTO_ASSIGN_SUGGEST = "suggest"
+	AUTO_ASSIGN_APPLY   = "apply"
+
+	// Assignment Suggestion Status
+	SUGGESTION_PENDING   = "pending"
+	SUGGESTION_APPLIED   = "applied"
+	SUGGESTION_DISMISSED = "dismissed"
+	SUGGESTION_OUTDATED  = "outdated"
+)
+
+const (
+	// Material Cost Groupings
+	COST_GROUP_ROAD     = "road"
+	COST_GROUP_DISTRICT = "district"
+	COST_GROUP_MONTH    = "month"
+)
+
+const (
+	// Region Levels
+	REGION_LEVEL_PROVINCE = "province"
+	REGION_LEVEL_REGENCY  = "regency"
+	REGION_LEVEL_DISTRICT = "district"
+	REGION_LEVEL_VILLAGE  = "village"
+)
+
+// REGION_LEVELS lists the region levels from the largest to the smallest.
+var REGION_LEVELS = []string{
+	REGION_LEVEL_PROVINCE,
+	REGION_LEVEL_REGENCY,
+	REGION_LEVEL_DISTRICT,
+	REGION_LEVEL_VILLAGE,
+}
+
+// RegionLevelIndex returns the position of the level in REGION_LEVELS, or -1
+// when the level is unknown.
+func RegionLevelIndex(level string) int {
+	for i, l := range REGION_LEVELS {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+const (
+	// Road Authorities
+	ROAD_AUTHORITY_NATIONAL   = "national"
+	ROAD_AUTHORITY_PROVINCIAL = "provincial"
+	ROAD_AUTHORITY_REGENCY    = "regency"
+	ROAD_AUTHORITY_CITY       = "city"
+	ROAD_AUTHORITY_VILLAGE    = "village"
+
+	// ROAD_AUTHORITY_UNKNOWN is the queue of reports not near any imported
+	// road.
+	ROAD_AUTHORITY_UNKNOWN = "unknown"
+)
+
+// ROAD_AUTHORITIES names the agency responsible for each road status.
+var ROAD_AUTHORITIES = map[string]string{
+	ROAD_AUTHORITY_NATIONAL:   "Ministry of Public Works (national road)",
+	ROAD_AUTHORITY_PROVINCIAL: "Provincial public works office",
+	ROAD_AUTHORITY_REGENCY:    "Regency public works office",
+	ROAD_AUTHORITY_CITY:       "City public works office",
+	ROAD_AUTHORITY_VILLAGE:    "Village government",
+}
+
+const (
+	// Worker Leave Types
+	LEAVE_TYPE_LEAVE = "leave"
+	LEAVE_TYPE_SICK  = "sick"
+)
+
+const (
+	// Dataset Versions
+	DATASET_REGIONS       = "regions"
+	DATASET_ROADS         = "roads"
+	DATASET_SERVICE_AREAS = "service_areas"
 )
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// DatasetVersion counts the reloads of a dataset every instance keeps in
+// memory, such as the region boundaries. Instances rebuild their copy when
+// the stored version moves past the one they loaded.
+type DatasetVersion struct {
+	Name      string    `gorm:"type:varchar(30);primary_key" json:"name"`
+	Version   int64     `gorm:"not null;default:0" json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
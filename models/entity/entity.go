@@ -21,21 +21,60 @@ type User struct {
 }
 
 type Report struct {
-	ID             string         `gorm:"type:text;primary_key" json:"id"`
-	UserID         uuid.UUID      `gorm:"type:uuid" json:"user_id"`
-	WorkerID       *uuid.UUID     `gorm:"type:uuid" json:"worker_id"`
-	Longitude      float64        `gorm:"type:numeric" json:"longitude"`
-	Latitude       float64        `gorm:"type:numeric" json:"latitude"`
-	RoadName       string         `gorm:"column:road_name;type:text" json:"road_name"`
-	BeforeImageURL string         `gorm:"column:before_image_url;type:text" json:"before_image_url"`
-	AfterImageURL  string         `gorm:"column:after_image_url;type:text" json:"after_image_url"`
-	Description    string         `gorm:"type:text" json:"description"`
-	DestructClass  string         `gorm:"column:destruct_class;type:text" json:"destruct_class"`
-	LocationScore  float64        `gorm:"column:location_score;type:numeric" json:"location_score"`
-	TotalScore     float64        `gorm:"column:total_score;type:numeric" json:"total_score"`
-	Status         string         `gorm:"type:text" json:"status"`
-	AdminNotes     string         `gorm:"column:admin_notes;type:text" json:"admin_notes"`
-	Deadline       *time.Time     `gorm:"column:deadline;type:timestamp" json:"deadline"`
-	CreatedAt      time.Time      `json:"created_at"`
-	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	ID                 string         `gorm:"type:text;primary_key" json:"id"`
+	UserID             uuid.UUID      `gorm:"type:uuid" json:"user_id"`
+	SourceAPIKeyID     *uuid.UUID     `gorm:"type:uuid;index" json:"source_api_key_id"` // set instead of UserID for reports sent through an API key
+	WorkerID           *uuid.UUID     `gorm:"type:uuid" json:"worker_id"`
+	TeamID             *uuid.UUID     `gorm:"type:uuid;index" json:"team_id"`
+	Longitude          float64        `gorm:"type:numeric" json:"longitude"`
+	Latitude           float64        `gorm:"type:numeric" json:"latitude"`
+	Geohash            string         `gorm:"type:varchar(12);index:idx_reports_geohash_prefix,expression:geohash varchar_pattern_ops;index:idx_reports_geohash_pending,where:geohash IS NULL OR geohash = ''" json:"geohash"`
+	RoadName           string         `gorm:"column:road_name;type:text" json:"road_name"`
+	CanonicalRoadName  string         `gorm:"type:text" json:"canonical_road_name"`
+	District           string         `gorm:"column:district;type:varchar(100);index" json:"district"`
+	ProvinceCode       string         `gorm:"type:varchar(32);index" json:"province_code"`
+	RegencyCode        string         `gorm:"type:varchar(32);index" json:"regency_code"`
+	DistrictCode       string         `gorm:"type:varchar(32);index" json:"district_code"`
+	VillageCode        string         `gorm:"type:varchar(32);index" json:"village_code"`
+	RegionsAssignedAt  *time.Time     `gorm:"index" json:"-"`
+	RegionsVersion     int64          `gorm:"not null;default:0;index" json:"-"` // boundary version the regions were found with
+	RoadSegmentID      string         `gorm:"type:varchar(64);index" json:"road_segment_id"`
+	RoadAuthority      string         `gorm:"type:varchar(20);index" json:"road_authority"`
+	RoadSnappedAt      *time.Time     `gorm:"index" json:"-"`
+	RoadsVersion       int64          `gorm:"not null;default:0;index" json:"-"` // road network version the report was snapped with
+	RoadKey            *string        `gorm:"type:text;index" json:"-"`          // road the condition index counts the report on; nil until derived
+	ForwardedTo        string         `gorm:"type:varchar(20)" json:"forwarded_to"`
+	ForwardNote        string         `gorm:"type:text" json:"forward_note"`
+	ForwardedBy        *uuid.UUID     `gorm:"type:uuid" json:"forwarded_by"`
+	ForwardedAt        *time.Time     `gorm:"index" json:"forwarded_at"`
+	OutsideServiceArea bool           `gorm:"not null;default:false;index" json:"outside_service_area"`
+	RecurrenceOf       *string        `gorm:"type:text;index" json:"recurrence_of"`
+	RecurrenceWorkerID *uuid.UUID     `gorm:"type:uuid;index" json:"recurrence_worker_id"`
+	RequestedClass     string         `gorm:"type:varchar(50)" json:"requested_class"` // destruct class picked by an Open311 client
+	BeforeImageURL     string         `gorm:"column:before_image_url;type:text" json:"before_image_url"`
+	AfterImageURL      string         `gorm:"column:after_image_url;type:text" json:"after_image_url"`
+	Description        string         `gorm:"type:text" json:"description"`
+	DestructClass      string         `gorm:"column:destruct_class;type:text" json:"destruct_class"`
+	LocationScore      float64        `gorm:"column:location_score;type:numeric" json:"location_score"`
+	TotalScore         float64        `gorm:"column:total_score;type:numeric" json:"total_score"`
+	Status             string         `gorm:"type:text" json:"status"`
+	AdminNotes         string         `gorm:"column:admin_notes;type:text" json:"admin_notes"`
+	Deadline           *time.Time     `gorm:"column:deadline;type:timestamp" json:"deadline"`
+	RejectReason       string         `gorm:"column:reject_reason;type:varchar(30)" json:"reject_reason"`
+	RejectNote         string         `gorm:"column:reject_note;type:text" json:"reject_note"`
+	RejectedBy         *uuid.UUID     `gorm:"column:rejected_by;type:uuid" json:"rejected_by"`
+	RejectedAt         *time.Time     `gorm:"column:rejected_at;type:timestamp" json:"rejected_at"`
+	FinishedAt         *time.Time     `gorm:"column:finished_at;type:timestamp" json:"finished_at"`
+	ReworkCount        int            `gorm:"column:rework_count;default:0" json:"rework_count"`
+	AssignmentStatus   string         `gorm:"column:assignment_status;type:varchar(20)" json:"assignment_status"`
+	WorkStage          string         `gorm:"column:work_stage;type:varchar(30)" json:"work_stage"`
+	WorkStageAt        *time.Time     `gorm:"column:work_stage_at;type:timestamp" json:"work_stage_at"`
+	Version            int            `gorm:"not null;default:1" json:"version"`
+	DeadlineWarnedAt   *time.Time     `gorm:"column:deadline_warned_at;type:timestamp" json:"deadline_warned_at"`
+	OverdueAt          *time.Time     `gorm:"column:overdue_at;type:timestamp" json:"overdue_at"`
+	EscalatedAt        *time.Time     `gorm:"column:escalated_at;type:timestamp" json:"escalated_at"`
+	SLAPolicyID        *uuid.UUID     `gorm:"column:sla_policy_id;type:uuid" json:"sla_policy_id"`
+	SLAPolicy          *SLAPolicy     `gorm:"foreignKey:SLAPolicyID;constraint:OnDelete:SET NULL" json:"sla_policy,omitempty"`
+	CreatedAt          time.Time      `json:"created_at"`
+	DeletedAt          gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 }
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Material is a catalogue entry for something consumed by repairs, such as
+// asphalt, cold-mix or cement, priced per unit.
+type Material struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name      string    `gorm:"type:varchar(100);not null;unique" json:"name"`
+	Unit      string    `gorm:"type:varchar(20);not null" json:"unit"`
+	UnitCost  float64   `gorm:"type:numeric;not null" json:"unit_cost"`
+	Active    bool      `gorm:"not null" json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReportMaterial is a quantity of material used on a report. The unit cost is
+// copied from the catalogue so later price changes do not rewrite past costs.
+type ReportMaterial struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ReportID   string    `gorm:"type:text;not null;index" json:"report_id"`
+	MaterialID uuid.UUID `gorm:"type:uuid;not null;index" json:"material_id"`
+	Material   *Material `gorm:"foreignKey:MaterialID" json:"material,omitempty"`
+	Quantity   float64   `gorm:"type:numeric;not null" json:"quantity"`
+	UnitCost   float64   `gorm:"type:numeric;not null" json:"unit_cost"`
+	Cost       float64   `gorm:"type:numeric;not null" json:"cost"`
+	RecordedBy uuid.UUID `gorm:"type:uuid;not null" json:"recorded_by"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Notification struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Type      string     `gorm:"type:varchar(50);not null" json:"type"`
+	Title     string     `gorm:"type:varchar(200)" json:"title"`
+	Message   string     `gorm:"type:text" json:"message"`
+	ReportID  *string    `gorm:"type:text" json:"report_id"`
+	ReadAt    *time.Time `gorm:"type:timestamp" json:"read_at"`
+	CreatedAt time.Time  `gorm:"index" json:"created_at"`
+}
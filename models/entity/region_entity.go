@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Region is an administrative area (province, regency, district or village)
+// imported from the boundary GeoJSON files. The bounding box narrows the
+// point-in-polygon lookups.
+type Region struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Code       string    `gorm:"type:varchar(32);not null;unique" json:"code"`
+	Name       string    `gorm:"type:varchar(150);not null" json:"name"`
+	Level      string    `gorm:"type:varchar(20);not null;index" json:"level"`
+	ParentCode string    `gorm:"type:varchar(32);index" json:"parent_code"`
+	MinLat     float64   `json:"min_lat"`
+	MinLng     float64   `json:"min_lng"`
+	MaxLat     float64   `json:"max_lat"`
+	MaxLng     float64   `json:"max_lng"`
+	Geometry   string    `gorm:"type:jsonb;not null" json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// AdminRegion limits an admin to the reports of a region and the regions
+// inside it. Admins without any are not limited.
+type AdminRegion struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_admin_region" json:"user_id"`
+	RegionCode string    `gorm:"type:varchar(32);not null;uniqueIndex:idx_admin_region" json:"region_code"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReportAssignment keeps every worker assignment of a report, including
+// declined, reassigned and unassigned ones.
+type ReportAssignment struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ReportID    string     `gorm:"type:text;not null;index" json:"report_id"`
+	WorkerID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"worker_id"` // the team leader for crew assignments
+	TeamID      *uuid.UUID `gorm:"type:uuid" json:"team_id"`
+	AssignedBy  *uuid.UUID `gorm:"type:uuid" json:"assigned_by"`
+	Status      string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	AdminNotes  string     `gorm:"type:text" json:"admin_notes"`
+	Deadline    *time.Time `gorm:"type:timestamp" json:"deadline"`
+	SLAPolicyID *uuid.UUID `gorm:"type:uuid" json:"sla_policy_id"` // set when the deadline came from a policy
+	Reason      string     `gorm:"type:text" json:"reason"`
+	Reasoning   string     `gorm:"type:text" json:"reasoning"`
+	RespondedAt *time.Time `gorm:"type:timestamp" json:"responded_at"`
+	EndedAt     *time.Time `gorm:"type:timestamp" json:"ended_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	Crew []ReportCrewMember `gorm:"foreignKey:AssignmentID" json:"crew,omitempty"`
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReportProgress is an update posted by the assigned worker or any member of
+// the assigned crew while a repair is under way. Updates that move the work
+// order to a new stage carry that stage; plain notes leave it empty.
+type ReportProgress struct {
+	ID        uuid.UUID             `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ReportID  string                `gorm:"type:text;not null;index" json:"report_id"`
+	WorkerID  uuid.UUID             `gorm:"type:uuid;not null;index" json:"worker_id"`
+	Stage     string                `gorm:"type:varchar(30)" json:"stage"`
+	Note      string                `gorm:"type:text" json:"note"`
+	Photos    []ReportProgressPhoto `gorm:"foreignKey:ProgressID;constraint:OnDelete:CASCADE" json:"photos"`
+	CreatedAt time.Time             `json:"created_at"`
+}
+
+type ReportProgressPhoto struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ProgressID uuid.UUID `gorm:"type:uuid;not null;index" json:"progress_id"`
+	URL        string    `gorm:"type:text;not null" json:"url"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReportRework archives a completion the admin sent back to the worker, so the
+// after image is kept when the worker uploads a new one.
+type ReportRework struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ReportID         string     `gorm:"type:text;not null;index" json:"report_id"`
+	WorkerID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"worker_id"`
+	AfterImageURL    string     `gorm:"column:after_image_url;type:text" json:"after_image_url"`
+	FinishedAt       *time.Time `gorm:"type:timestamp" json:"finished_at"`
+	Notes            string     `gorm:"type:text" json:"notes"`
+	PreviousDeadline *time.Time `gorm:"type:timestamp" json:"previous_deadline"`
+	NewDeadline      *time.Time `gorm:"type:timestamp" json:"new_deadline"`
+	RequestedBy      *uuid.UUID `gorm:"type:uuid" json:"requested_by"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
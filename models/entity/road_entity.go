@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoadSegment is a piece of the road network imported from the road GeoJSON
+// files, with the agency responsible for it.
+type RoadSegment struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	SegmentID string    `gorm:"type:varchar(64);not null;unique" json:"segment_id"`
+	Name      string    `gorm:"type:varchar(200)" json:"name"`
+	Authority string    `gorm:"type:varchar(20);not null;index" json:"authority"`
+	Geometry  string    `gorm:"type:jsonb;not null" json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceArea is a boundary reports must fall in. Reports outside every
+// active area are rejected or flagged, depending on SERVICE_AREA_MODE.
+type ServiceArea struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name      string    `gorm:"type:varchar(150);not null;unique" json:"name"`
+	MinLat    float64   `json:"min_lat"`
+	MinLng    float64   `json:"min_lng"`
+	MaxLat    float64   `json:"max_lat"`
+	MaxLng    float64   `json:"max_lng"`
+	Geometry  string    `gorm:"type:jsonb;not null" json:"-"`
+	Active    bool      `gorm:"not null" json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
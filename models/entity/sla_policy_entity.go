@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SLAPolicy sets how long a report of a damage class at or above a priority
+// score may take to resolve. An empty DestructClass matches every class.
+type SLAPolicy struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name            string     `gorm:"type:varchar(100);not null" json:"name"`
+	DestructClass   string     `gorm:"type:varchar(50);index" json:"destruct_class"`
+	MinTotalScore   float64    `gorm:"type:numeric;not null" json:"min_total_score"`
+	ResolutionHours int        `gorm:"not null" json:"resolution_hours"`
+	Active          bool       `gorm:"not null" json:"active"`
+	CreatedBy       *uuid.UUID `gorm:"type:uuid" json:"created_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+func (p *SLAPolicy) Matches(report *Report) bool {
+	if !p.Active {
+		return false
+	}
+	if p.DestructClass != "" && p.DestructClass != report.DestructClass {
+		return false
+	}
+	return report.TotalScore >= p.MinTotalScore
+}
+
+// MoreSpecificThan reports whether p should win over other when both match:
+// a class specific policy beats a catch-all one, then the higher score
+// threshold wins, then the shorter resolution time.
+func (p *SLAPolicy) MoreSpecificThan(other *SLAPolicy) bool {
+	if (p.DestructClass != "") != (other.DestructClass != "") {
+		return p.DestructClass != ""
+	}
+	if p.MinTotalScore != other.MinTotalScore {
+		return p.MinTotalScore > other.MinTotalScore
+	}
+	return p.ResolutionHours < other.ResolutionHours
+}
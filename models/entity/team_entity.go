@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Team is a repair crew. The leader is also one of the members and is the one
+// who accepts and finishes the crew's assignments.
+type Team struct {
+	ID        uuid.UUID    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name      string       `gorm:"type:varchar(100);not null;unique" json:"name"`
+	LeaderID  uuid.UUID    `gorm:"type:uuid;not null" json:"leader_id"`
+	Members   []TeamMember `gorm:"foreignKey:TeamID;constraint:OnDelete:CASCADE" json:"members"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// TeamMember links a worker to the single team they belong to.
+type TeamMember struct {
+	TeamID    uuid.UUID `gorm:"type:uuid;primary_key" json:"team_id"`
+	WorkerID  uuid.UUID `gorm:"type:uuid;primary_key;uniqueIndex" json:"worker_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ReportCrewMember records who was on the team when it was given an
+// assignment, so history and performance stay with the people who did the job
+// after the team changes.
+type ReportCrewMember struct {
+	AssignmentID uuid.UUID `gorm:"type:uuid;primary_key" json:"assignment_id"`
+	WorkerID     uuid.UUID `gorm:"type:uuid;primary_key;index" json:"worker_id"`
+	ReportID     string    `gorm:"type:text;not null;index" json:"report_id"`
+	TeamID       uuid.UUID `gorm:"type:uuid;not null" json:"team_id"`
+	IsLeader     bool      `gorm:"not null" json:"is_leader"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkerShiftSession is a shift a worker actually clocked in for. Location
+// pings are only stored while a session is open and the worker opted in to
+// tracking when starting it.
+type WorkerShiftSession struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	WorkerID        uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_worker_open_session,where:ended_at IS NULL" json:"worker_id"`
+	TrackingConsent bool       `gorm:"not null" json:"tracking_consent"`
+	StartedAt       time.Time  `gorm:"type:timestamp;not null" json:"started_at"`
+	EndedAt         *time.Time `gorm:"type:timestamp;index" json:"ended_at"`
+}
+
+type WorkerLocationPing struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	WorkerID   uuid.UUID `gorm:"type:uuid;not null;index:idx_location_ping_worker_time" json:"worker_id"`
+	SessionID  uuid.UUID `gorm:"type:uuid;not null;index" json:"session_id"`
+	Latitude   float64   `gorm:"type:numeric;not null" json:"latitude"`
+	Longitude  float64   `gorm:"type:numeric;not null" json:"longitude"`
+	AccuracyM  float64   `gorm:"type:numeric" json:"accuracy_m"`
+	RecordedAt time.Time `gorm:"type:timestamp;not null;index:idx_location_ping_worker_time" json:"recorded_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkerProfile holds what the auto-assignment engine needs to know about a
+// worker: where they are based, what they can repair and how many jobs they
+// can hold at once. Working hours live in WorkerShift.
+type WorkerProfile struct {
+	WorkerID          uuid.UUID `gorm:"type:uuid;primary_key" json:"worker_id"`
+	BaseLatitude      float64   `gorm:"type:numeric" json:"base_latitude"`
+	BaseLongitude     float64   `gorm:"type:numeric" json:"base_longitude"`
+	BaseRadiusKm      float64   `gorm:"type:numeric;default:0" json:"base_radius_km"`
+	Skills            string    `gorm:"type:text" json:"skills"`                       // comma separated destruct classes, empty means any
+	MaxConcurrentJobs int       `gorm:"not null;default:0" json:"max_concurrent_jobs"` // 0 means no limit
+	AutoAssign        bool      `gorm:"not null;default:true" json:"auto_assign"`
+	Contractor        string    `gorm:"type:varchar(100);index" json:"contractor"` // empty means in-house crew
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+func (p *WorkerProfile) SkillList() []string {
+	if p.Skills == "" {
+		return []string{}
+	}
+	return strings.Split(p.Skills, ",")
+}
+
+func (p *WorkerProfile) HasSkill(destructClass string) bool {
+	if p.Skills == "" || destructClass == "" {
+		return true
+	}
+	for _, skill := range p.SkillList() {
+		if strings.EqualFold(skill, destructClass) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkerShift is a weekly recurring shift. Weekday follows time.Weekday
+// (0 is Sunday); a shift ending before it starts runs past midnight.
+type WorkerShift struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	WorkerID  uuid.UUID `gorm:"type:uuid;not null;index" json:"worker_id"`
+	Weekday   int       `gorm:"not null" json:"weekday"`
+	StartTime string    `gorm:"type:varchar(5);not null" json:"start_time"`
+	EndTime   string    `gorm:"type:varchar(5);not null" json:"end_time"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Covers reports whether t falls inside this shift.
+func (s *WorkerShift) Covers(t time.Time) bool {
+	clock := t.Format("15:04")
+	weekday := int(t.Weekday())
+
+	if s.StartTime < s.EndTime {
+		return weekday == s.Weekday && clock >= s.StartTime && clock < s.EndTime
+	}
+	if weekday == s.Weekday && clock >= s.StartTime {
+		return true
+	}
+	return weekday == (s.Weekday+1)%7 && clock < s.EndTime
+}
+
+// WorkerLeave marks whole days a worker is unavailable. EndDate is inclusive.
+type WorkerLeave struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	WorkerID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"worker_id"`
+	Type      string     `gorm:"type:varchar(20);not null" json:"type"`
+	StartDate time.Time  `gorm:"type:date;not null;index" json:"start_date"`
+	EndDate   time.Time  `gorm:"type:date;not null;index" json:"end_date"`
+	Reason    string     `gorm:"type:text" json:"reason"`
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Covers reports whether the calendar day of t is inside the leave.
+func (l *WorkerLeave) Covers(t time.Time) bool {
+	day := t.Format("2006-01-02")
+	return day >= l.StartDate.Format("2006-01-02") && day <= l.EndDate.Format("2006-01-02")
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkerShiftCovers(t *testing.T) {
+	// 3 March 2025 is a Monday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, time.March, day, hour, minute, 0, 0, time.UTC)
+	}
+	day := WorkerShift{Weekday: int(time.Monday), StartTime: "08:00", EndTime: "16:00"}
+	night := WorkerShift{Weekday: int(time.Monday), StartTime: "22:00", EndTime: "06:00"}
+	saturdayNight := WorkerShift{Weekday: int(time.Saturday), StartTime: "20:00", EndTime: "04:00"}
+
+	tests := []struct {
+		name  string
+		shift WorkerShift
+		time  time.Time
+		want  bool
+	}{
+		{"day shift start", day, at(3, 8, 0), true},
+		{"day shift middle", day, at(3, 12, 30), true},
+		{"day shift end is exclusive", day, at(3, 16, 0), false},
+		{"before the day shift", day, at(3, 7, 59), false},
+		{"day shift on another weekday", day, at(4, 12, 0), false},
+		{"overnight shift evening", night, at(3, 23, 0), true},
+		{"overnight shift start", night, at(3, 22, 0), true},
+		{"overnight shift after midnight", night, at(4, 2, 0), true},
+		{"overnight shift end is exclusive", night, at(4, 6, 0), false},
+		{"overnight shift before it starts", night, at(3, 21, 59), false},
+		{"early morning of the shift's own day", night, at(3, 2, 0), false},
+		{"overnight shift a week later", night, at(4, 22, 30), false},
+		{"saturday night into sunday", saturdayNight, at(9, 3, 0), true},
+		{"sunday night is not saturday's shift", saturdayNight, at(9, 21, 0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.shift.Covers(tt.time); got != tt.want {
+				t.Errorf("Covers(%s) = %v, want %v", tt.time.Format("Mon 15:04"), got, tt.want)
+			}
+		})
+	}
+}
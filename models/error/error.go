@@ -30,9 +30,92 @@ var (
 	INVALID_COORDINATES          = errors.New("Invalid coordinates provided")
 	REPORT_CREATION_FAILED       = errors.New("Failed to create report")
 	REPORT_NOT_FOUND             = errors.New("report not found")
-	REPORT_ALREADY_ASSIGNED      = errors.New("report already assigned to a worker")
+	REPORT_ALREADY_ASSIGNED      = errors.New("report already assigned to a worker, use reassign instead")
 	WORKER_NOT_FOUND             = errors.New("worker not found")
 	ONLY_WORKER_CAN_ASSIGN       = errors.New("only workers can be assigned")
 	NOT_ASSIGNED_TO_REPORT       = errors.New("you are not assigned to this report")
 	ONLY_FINISH_BY_WORKER_VERIFY = errors.New("only reports with status 'Finish by Worker' can be verified")
+	REPORT_NOT_PENDING           = errors.New("only pending reports can be classified")
+	REPORT_REJECTED              = errors.New("report has been rejected")
+	INVALID_REJECT_REASON        = errors.New("invalid reject reason code")
+	ONLY_UNASSIGNED_REJECT       = errors.New("only pending or classified reports that are not assigned can be rejected")
+	NOTIFICATION_NOT_FOUND       = errors.New("notification not found")
+	ONLY_FINISH_BY_WORKER_REWORK = errors.New("only reports with status 'Finish by Worker' can be sent back for rework")
+	INVALID_DEADLINE             = errors.New("deadline must be in the future")
+	ASSIGNMENT_NOT_PENDING       = errors.New("assignment is not waiting for acceptance")
+	ASSIGNMENT_NOT_OPEN          = errors.New("report is not currently assigned")
+	SAME_WORKER_REASSIGN         = errors.New("report is already assigned to this worker")
+	REPORT_VERSION_CONFLICT      = errors.New("report was modified by another request, reload it and try again")
+	INVALID_IF_MATCH             = errors.New("invalid If-Match header, expected the report version as returned in the ETag")
+	WORKER_PROFILE_NOT_FOUND     = errors.New("worker profile not found")
+	INVALID_SHIFT                = errors.New("shifts need a weekday from 0 (Sunday) to 6 and different HH:MM start and end times")
+	INVALID_LEAVE                = errors.New("leave needs a type of leave or sick and a YYYY-MM-DD start date not after the end date")
+	LEAVE_NOT_FOUND              = errors.New("leave not found")
+	INVALID_DATE_RANGE           = errors.New("invalid date range, use YYYY-MM-DD with at most 31 days")
+	WORKER_UNAVAILABLE           = errors.New("worker is unavailable, send force to assign anyway")
+	SUGGESTION_NOT_FOUND         = errors.New("assignment suggestion not found")
+	SUGGESTION_NOT_PENDING       = errors.New("assignment suggestion was already applied or dismissed")
+	SLA_POLICY_NOT_FOUND         = errors.New("sla policy not found")
+	ROUTE_START_REQUIRED         = errors.New("current position is required when the worker has no base location")
+	SHIFT_ALREADY_STARTED        = errors.New("shift already started")
+	SHIFT_NOT_STARTED            = errors.New("no shift in progress")
+	TRACKING_NOT_ENABLED         = errors.New("location tracking was not enabled for this shift")
+	TEAM_NOT_FOUND               = errors.New("team not found")
+	TEAM_NAME_TAKEN              = errors.New("team name already in use")
+	WORKER_IN_OTHER_TEAM         = errors.New("worker already belongs to another team")
+	TEAM_HAS_OPEN_REPORTS        = errors.New("team still has open assignments")
+	ONLY_TEAM_LEADER_FINISH      = errors.New("only the team leader can finish the report")
+	INVALID_WORK_STAGE           = errors.New("invalid work stage")
+	WORK_STAGE_NOT_FORWARD       = errors.New("work order can only move to a later stage")
+	WORK_STAGE_PHOTO_REQUIRED    = errors.New("at least one photo is required to finish the work order")
+	ONLY_ASSIGNEE_CHANGE_STAGE   = errors.New("only the assigned worker or team leader can change the stage")
+	TOO_MANY_PHOTOS              = errors.New("too many photos in one update")
+	MATERIAL_NOT_FOUND           = errors.New("material not found")
+	MATERIAL_NAME_TAKEN          = errors.New("material name already in use")
+	MATERIAL_IN_USE              = errors.New("material has been used on reports, deactivate it instead")
+	MATERIAL_INACTIVE            = errors.New("material is no longer in use")
+	INVALID_MATERIAL_QUANTITY    = errors.New("material quantity must be greater than zero")
+	MATERIALS_ONLY_ON_FINISH     = errors.New("materials are recorded when finishing the work order")
+	INVALID_BBOX                 = errors.New("bbox must be min_lng,min_lat,max_lng,max_lat within valid ranges")
+	INVALID_RADIUS               = errors.New("radius needs latitude and longitude and must be between 0 and 100 km")
+	INVALID_POLYGON              = errors.New("polygon must be a GeoJSON Polygon with closed rings of at least four positions")
+	INVALID_ZOOM                 = errors.New("zoom must be between 0 and 22")
+	AGGREGATE_AREA_TOO_LARGE     = errors.New("bbox covers too many tiles at this zoom, zoom in or narrow the bbox")
+	INVALID_TILE                 = errors.New("tile coordinates must be z/x/y within the zoom level")
+	REGION_NOT_FOUND             = errors.New("region not found")
+	REGION_OUT_OF_SCOPE          = errors.New("region is outside your jurisdiction")
+	REPORT_OUT_OF_SCOPE          = errors.New("report is outside your jurisdiction")
+	REGION_BOUNDARIES_NOT_SET    = errors.New("no region boundaries path is configured")
+	INVALID_REGION_LEVEL         = errors.New("region level must be province, regency, district or village")
+	INVALID_REGION_BOUNDARY      = errors.New("region features need a code, name, level and a Polygon or MultiPolygon geometry")
+	ONLY_UNSCOPED_ADMIN          = errors.New("only admins not limited to regions can do this")
+	REGIONS_ONLY_FOR_ADMINS      = errors.New("regions can only be assigned to admins")
+	INVALID_ROAD_AUTHORITY       = errors.New("road authority must be national, provincial, regency, city or village")
+	INVALID_ROAD_SEGMENT         = errors.New("road features need a segment_id, an authority and a LineString or MultiLineString geometry")
+	ROAD_NETWORK_NOT_SET         = errors.New("no road network path is configured")
+	REPORT_WITHIN_AUTHORITY      = errors.New("report is on a road within our authority")
+	ONLY_UNASSIGNED_FORWARD      = errors.New("only pending or classified reports that are not assigned can be forwarded")
+	REPORT_FORWARDED             = errors.New("report was forwarded to another agency")
+	ROAD_NOT_FOUND               = errors.New("road not found")
+	REPORT_ALREADY_FORWARDED     = errors.New("report has already been forwarded")
+	GEOCODER_NOT_LOADED          = errors.New("no road name extract is loaded")
+	SERVICE_AREA_NOT_FOUND       = errors.New("service area not found")
+	SERVICE_AREA_NAME_TAKEN      = errors.New("service area name already in use")
+	INVALID_SERVICE_AREA         = errors.New("service areas need a Polygon or MultiPolygon geometry")
+	OUTSIDE_SERVICE_AREA         = errors.New("location is outside the service area")
+	COORDINATES_SWAPPED          = errors.New("latitude and longitude appear to be swapped")
+	OPEN311_SERVICE_NOT_FOUND    = errors.New("service_code is not one of the listed services")
+	OPEN311_LOCATION_REQUIRED    = errors.New("lat and long are required")
+	INVALID_MEDIA_URL            = errors.New("media_url must be an http or https URL")
+	INVALID_OPEN311_STATUS       = errors.New("status must be open or closed")
+	INVALID_OPEN311_TOKEN        = errors.New("token not found")
+	API_KEY_REQUIRED             = errors.New("api_key is required")
+	API_KEY_PERMISSION_DENIED    = errors.New("api key lacks the permission for this request")
+	INVALID_COST_GROUP           = errors.New("group_by must be road, district or month")
+	INVALID_ROLE                 = errors.New("invalid role")
+	CANNOT_CHANGE_OWN_ROLE       = errors.New("you can not change your own role")
+	API_KEY_NOT_FOUND            = errors.New("api key not found")
+	INVALID_API_KEY              = errors.New("invalid, expired or revoked api key")
+	INVALID_API_KEY_PERMISSION   = errors.New("unknown api key permission")
+	INVALID_API_KEY_EXPIRY       = errors.New("api key expiry must be in the future")
 )
@@ -5,19 +5,87 @@ import "dinacom-11.0-backend/controllers"
 type ControllerProvider interface {
 	ProvideAuthController() controllers.AuthController
 	ProvideReportController() controllers.ReportController
+	ProvideAPIKeyController() controllers.APIKeyController
+	ProvideAuditController() controllers.AuditController
+	ProvideNotificationController() controllers.NotificationController
+	ProvideWorkerController() controllers.WorkerController
+	ProvideAutoAssignController() controllers.AutoAssignController
+	ProvideSLAController() controllers.SLAController
+	ProvideRouteController() controllers.RouteController
+	ProvideLocationController() controllers.LocationController
+	ProvideTeamController() controllers.TeamController
+	ProvideMaterialController() controllers.MaterialController
+	ProvideMapController() controllers.MapController
+	ProvideRegionController() controllers.RegionController
+	ProvideRoadController() controllers.RoadController
+	ProvideGeocodeController() controllers.GeocodeController
+	ProvideServiceAreaController() controllers.ServiceAreaController
+	ProvideRecurrenceController() controllers.RecurrenceController
+	ProvideOpen311Controller() controllers.Open311Controller
 }
 
 type controllerProvider struct {
-	authController   controllers.AuthController
-	reportController controllers.ReportController
+	authController         controllers.AuthController
+	reportController       controllers.ReportController
+	apiKeyController       controllers.APIKeyController
+	auditController        controllers.AuditController
+	notificationController controllers.NotificationController
+	workerController       controllers.WorkerController
+	autoAssignController   controllers.AutoAssignController
+	slaController          controllers.SLAController
+	routeController        controllers.RouteController
+	locationController     controllers.LocationController
+	teamController         controllers.TeamController
+	materialController     controllers.MaterialController
+	mapController          controllers.MapController
+	regionController       controllers.RegionController
+	roadController         controllers.RoadController
+	geocodeController      controllers.GeocodeController
+	serviceAreaController  controllers.ServiceAreaController
+	recurrenceController   controllers.RecurrenceController
+	open311Controller      controllers.Open311Controller
 }
 
 func NewControllerProvider(servicesProvider ServicesProvider) ControllerProvider {
-	authController := controllers.NewAuthController(servicesProvider.ProvideAuthService())
-	reportController := controllers.NewReportController(servicesProvider.ProvideReportService())
+	authController := controllers.NewAuthController(servicesProvider.ProvideAuthService(), servicesProvider.ProvideRegionService())
+	reportController := controllers.NewReportController(servicesProvider.ProvideReportService(), servicesProvider.ProvideRegionService())
+	apiKeyController := controllers.NewAPIKeyController(servicesProvider.ProvideAPIKeyService())
+	auditController := controllers.NewAuditController(servicesProvider.ProvideAuditService(), servicesProvider.ProvideRegionService())
+	notificationController := controllers.NewNotificationController(servicesProvider.ProvideNotificationService())
+	workerController := controllers.NewWorkerController(servicesProvider.ProvideWorkerService())
+	autoAssignController := controllers.NewAutoAssignController(servicesProvider.ProvideAutoAssignService(), servicesProvider.ProvideRegionService())
+	slaController := controllers.NewSLAController(servicesProvider.ProvideSLAService(), servicesProvider.ProvideRegionService())
+	routeController := controllers.NewRouteController(servicesProvider.ProvideRouteService())
+	locationController := controllers.NewLocationController(servicesProvider.ProvideLocationService())
+	teamController := controllers.NewTeamController(servicesProvider.ProvideTeamService())
+	materialController := controllers.NewMaterialController(servicesProvider.ProvideMaterialService(), servicesProvider.ProvideRegionService())
+	mapController := controllers.NewMapController(servicesProvider.ProvideMapService())
+	regionController := controllers.NewRegionController(servicesProvider.ProvideRegionService())
+	roadController := controllers.NewRoadController(servicesProvider.ProvideRoadService(), servicesProvider.ProvideReportService(), servicesProvider.ProvideRegionService())
+	geocodeController := controllers.NewGeocodeController(servicesProvider.ProvideGeocodeService())
+	serviceAreaController := controllers.NewServiceAreaController(servicesProvider.ProvideServiceAreaService())
+	recurrenceController := controllers.NewRecurrenceController(servicesProvider.ProvideRecurrenceService(), servicesProvider.ProvideRegionService())
+	open311Controller := controllers.NewOpen311Controller(servicesProvider.ProvideOpen311Service(), servicesProvider.ProvideAPIKeyService())
 	return &controllerProvider{
-		authController:   authController,
-		reportController: reportController,
+		authController:         authController,
+		reportController:       reportController,
+		apiKeyController:       apiKeyController,
+		auditController:        auditController,
+		notificationController: notificationController,
+		workerController:       workerController,
+		autoAssignController:   autoAssignController,
+		slaController:          slaController,
+		routeController:        routeController,
+		locationController:     locationController,
+		teamController:         teamController,
+		materialController:     materialController,
+		mapController:          mapController,
+		regionController:       regionController,
+		roadController:         roadController,
+		geocodeController:      geocodeController,
+		serviceAreaController:  serviceAreaController,
+		recurrenceController:   recurrenceController,
+		open311Controller:      open311Controller,
 	}
 }
 
@@ -28,3 +96,71 @@ func (c *controllerProvider) ProvideAuthController() controllers.AuthController
 func (c *controllerProvider) ProvideReportController() controllers.ReportController {
 	return c.reportController
 }
+
+func (c *controllerProvider) ProvideAPIKeyController() controllers.APIKeyController {
+	return c.apiKeyController
+}
+
+func (c *controllerProvider) ProvideAuditController() controllers.AuditController {
+	return c.auditController
+}
+
+func (c *controllerProvider) ProvideNotificationController() controllers.NotificationController {
+	return c.notificationController
+}
+
+func (c *controllerProvider) ProvideWorkerController() controllers.WorkerController {
+	return c.workerController
+}
+
+func (c *controllerProvider) ProvideAutoAssignController() controllers.AutoAssignController {
+	return c.autoAssignController
+}
+
+func (c *controllerProvider) ProvideSLAController() controllers.SLAController {
+	return c.slaController
+}
+
+func (c *controllerProvider) ProvideRouteController() controllers.RouteController {
+	return c.routeController
+}
+
+func (c *controllerProvider) ProvideLocationController() controllers.LocationController {
+	return c.locationController
+}
+
+func (c *controllerProvider) ProvideTeamController() controllers.TeamController {
+	return c.teamController
+}
+
+func (c *controllerProvider) ProvideMaterialController() controllers.MaterialController {
+	return c.materialController
+}
+
+func (c *controllerProvider) ProvideMapController() controllers.MapController {
+	return c.mapController
+}
+
+func (c *controllerProvider) ProvideRegionController() controllers.RegionController {
+	return c.regionController
+}
+
+func (c *controllerProvider) ProvideRoadController() controllers.RoadController {
+	return c.roadController
+}
+
+func (c *controllerProvider) ProvideGeocodeController() controllers.GeocodeController {
+	return c.geocodeController
+}
+
+func (c *controllerProvider) ProvideServiceAreaController() controllers.ServiceAreaController {
+	return c.serviceAreaController
+}
+
+func (c *controllerProvider) ProvideRecurrenceController() controllers.RecurrenceController {
+	return c.recurrenceController
+}
+
+func (c *controllerProvider) ProvideOpen311Controller() controllers.Open311Controller {
+	return c.open311Controller
+}
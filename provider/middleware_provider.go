@@ -16,7 +16,7 @@ type middlewareProvider struct {
 
 func NewMiddlewareProvider(servicesProvider ServicesProvider) MiddlewareProvider {
 	return &middlewareProvider{
-		authMiddleware: middleware.AuthMiddleware(),
+		authMiddleware: middleware.AuthMiddleware(servicesProvider.ProvideAPIKeyService(), servicesProvider.ProvideRegionService()),
 	}
 }
 
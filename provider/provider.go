@@ -1,7 +1,11 @@
 package provider
 
 import (
+	"time"
+
 	"dinacom-11.0-backend/models/entity"
+	"dinacom-11.0-backend/scheduler"
+	"dinacom-11.0-backend/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -12,6 +16,7 @@ type AppProvider interface {
 	ProvideServices() ServicesProvider
 	ProvideControllers() ControllerProvider
 	ProvideMiddlewares() MiddlewareProvider
+	ProvideScheduler() scheduler.Scheduler
 }
 type appProvider struct {
 	ginRouter            *gin.Engine
@@ -20,6 +25,7 @@ type appProvider struct {
 	servicesProvider     ServicesProvider
 	controllerProvider   ControllerProvider
 	middlewareProvider   MiddlewareProvider
+	jobScheduler         scheduler.Scheduler
 }
 
 func NewAppProvider() AppProvider {
@@ -29,7 +35,59 @@ func NewAppProvider() AppProvider {
 	servicesProvider := NewServicesProvider(repositoriesProvider, configProvider)
 	controllerProvider := NewControllerProvider(servicesProvider)
 	middlewareProvider := NewMiddlewareProvider(servicesProvider)
-	configProvider.ProvideDatabaseConfig().AutoMigrateAll(&entity.User{}, &entity.Report{})
+	configProvider.ProvideDatabaseConfig().AutoMigrateAll(
+		&entity.User{},
+		&entity.Report{},
+		&entity.APIKey{},
+		&entity.APIKeyUsage{},
+		&entity.AuditLog{},
+		&entity.Notification{},
+		&entity.ReportRework{},
+		&entity.ReportAssignment{},
+		&entity.WorkerProfile{},
+		&entity.AssignmentSuggestion{},
+		&entity.WorkerShift{},
+		&entity.WorkerLeave{},
+		&entity.SLAPolicy{},
+		&entity.WorkerShiftSession{},
+		&entity.WorkerLocationPing{},
+		&entity.Team{},
+		&entity.TeamMember{},
+		&entity.ReportCrewMember{},
+		&entity.ReportProgress{},
+		&entity.ReportProgressPhoto{},
+		&entity.Material{},
+		&entity.ReportMaterial{},
+		&entity.Region{},
+		&entity.AdminRegion{},
+		&entity.RoadSegment{},
+		&entity.ServiceArea{},
+		&entity.DatasetVersion{},
+	)
+
+	if err := servicesProvider.ProvideRegionService().LoadBoundaries(); err != nil {
+		utils.InternalErrorLog(err, "step", "region_boundaries")
+	}
+	if err := servicesProvider.ProvideRoadService().LoadNetwork(); err != nil {
+		utils.InternalErrorLog(err, "step", "road_network")
+	}
+	if err := servicesProvider.ProvideGeocodeService().LoadExtract(); err != nil {
+		utils.InternalErrorLog(err, "step", "road_name_extract")
+	}
+	if err := servicesProvider.ProvideServiceAreaService().LoadAreas(); err != nil {
+		utils.InternalErrorLog(err, "step", "service_areas")
+	}
+
+	jobScheduler := scheduler.NewScheduler()
+	jobScheduler.Register("sla_check", configProvider.ProvideEnvConfig().GetSLACheckInterval(), servicesProvider.ProvideSLAService().CheckDeadlines)
+	jobScheduler.Register("location_retention", time.Hour, servicesProvider.ProvideLocationService().PurgeExpired)
+	jobScheduler.Register("geohash_backfill", 10*time.Minute, servicesProvider.ProvideReportService().BackfillGeohashes)
+	jobScheduler.Register("region_sync", time.Minute, servicesProvider.ProvideRegionService().SyncBoundaries)
+	jobScheduler.Register("region_backfill", 10*time.Minute, servicesProvider.ProvideRegionService().BackfillReportRegions)
+	jobScheduler.Register("road_sync", time.Minute, servicesProvider.ProvideRoadService().SyncNetwork)
+	jobScheduler.Register("road_backfill", 10*time.Minute, servicesProvider.ProvideRoadService().BackfillReportRoads)
+	jobScheduler.Register("service_area_sync", time.Minute, servicesProvider.ProvideServiceAreaService().SyncAreas)
+	jobScheduler.Register("api_key_usage", time.Minute, servicesProvider.ProvideAPIKeyService().FlushUsage)
 
 	return &appProvider{
 		ginRouter:            ginRouter,
@@ -38,6 +96,7 @@ func NewAppProvider() AppProvider {
 		servicesProvider:     servicesProvider,
 		controllerProvider:   controllerProvider,
 		middlewareProvider:   middlewareProvider,
+		jobScheduler:         jobScheduler,
 	}
 }
 func (a *appProvider) ProvideRouter() *gin.Engine {
@@ -62,3 +121,7 @@ func (a *appProvider) ProvideControllers() ControllerProvider {
 func (a *appProvider) ProvideMiddlewares() MiddlewareProvider {
 	return a.middlewareProvider
 }
+
+func (a *appProvider) ProvideScheduler() scheduler.Scheduler {
+	return a.jobScheduler
+}
@@ -5,19 +5,71 @@ import "dinacom-11.0-backend/repositories"
 type RepositoriesProvider interface {
 	ProvideUserRepository() repositories.UserRepository
 	ProvideReportRepository() repositories.ReportRepository
+	ProvideAPIKeyRepository() repositories.APIKeyRepository
+	ProvideAuditRepository() repositories.AuditRepository
+	ProvideNotificationRepository() repositories.NotificationRepository
+	ProvideWorkerRepository() repositories.WorkerRepository
+	ProvideAssignmentSuggestionRepository() repositories.AssignmentSuggestionRepository
+	ProvideSLAPolicyRepository() repositories.SLAPolicyRepository
+	ProvideLocationRepository() repositories.LocationRepository
+	ProvideTeamRepository() repositories.TeamRepository
+	ProvideMaterialRepository() repositories.MaterialRepository
+	ProvideRegionRepository() repositories.RegionRepository
+	ProvideRoadRepository() repositories.RoadRepository
+	ProvideServiceAreaRepository() repositories.ServiceAreaRepository
+	ProvideDatasetVersionRepository() repositories.DatasetVersionRepository
 }
 
 type repositoriesProvider struct {
-	userRepository   repositories.UserRepository
-	reportRepository repositories.ReportRepository
+	userRepository                 repositories.UserRepository
+	reportRepository               repositories.ReportRepository
+	apiKeyRepository               repositories.APIKeyRepository
+	auditRepository                repositories.AuditRepository
+	notificationRepository         repositories.NotificationRepository
+	workerRepository               repositories.WorkerRepository
+	assignmentSuggestionRepository repositories.AssignmentSuggestionRepository
+	slaPolicyRepository            repositories.SLAPolicyRepository
+	locationRepository             repositories.LocationRepository
+	teamRepository                 repositories.TeamRepository
+	materialRepository             repositories.MaterialRepository
+	regionRepository               repositories.RegionRepository
+	roadRepository                 repositories.RoadRepository
+	serviceAreaRepository          repositories.ServiceAreaRepository
+	datasetVersionRepository       repositories.DatasetVersionRepository
 }
 
 func NewRepositoriesProvider(cfg ConfigProvider) RepositoriesProvider {
 	userRepository := repositories.NewUserRepository(cfg.ProvideDatabaseConfig().GetInstance())
 	reportRepository := repositories.NewReportRepository(cfg.ProvideDatabaseConfig().GetInstance())
+	apiKeyRepository := repositories.NewAPIKeyRepository(cfg.ProvideDatabaseConfig().GetInstance())
+	auditRepository := repositories.NewAuditRepository(cfg.ProvideDatabaseConfig().GetInstance())
+	notificationRepository := repositories.NewNotificationRepository(cfg.ProvideDatabaseConfig().GetInstance())
+	workerRepository := repositories.NewWorkerRepository(cfg.ProvideDatabaseConfig().GetInstance())
+	assignmentSuggestionRepository := repositories.NewAssignmentSuggestionRepository(cfg.ProvideDatabaseConfig().GetInstance())
+	slaPolicyRepository := repositories.NewSLAPolicyRepository(cfg.ProvideDatabaseConfig().GetInstance())
+	locationRepository := repositories.NewLocationRepository(cfg.ProvideDatabaseConfig().GetInstance())
+	teamRepository := repositories.NewTeamRepository(cfg.ProvideDatabaseConfig().GetInstance())
+	materialRepository := repositories.NewMaterialRepository(cfg.ProvideDatabaseConfig().GetInstance())
+	regionRepository := repositories.NewRegionRepository(cfg.ProvideDatabaseConfig().GetInstance())
+	roadRepository := repositories.NewRoadRepository(cfg.ProvideDatabaseConfig().GetInstance())
+	serviceAreaRepository := repositories.NewServiceAreaRepository(cfg.ProvideDatabaseConfig().GetInstance())
+	datasetVersionRepository := repositories.NewDatasetVersionRepository(cfg.ProvideDatabaseConfig().GetInstance())
 	return &repositoriesProvider{
-		userRepository:   userRepository,
-		reportRepository: reportRepository,
+		userRepository:                 userRepository,
+		reportRepository:               reportRepository,
+		apiKeyRepository:               apiKeyRepository,
+		auditRepository:                auditRepository,
+		notificationRepository:         notificationRepository,
+		workerRepository:               workerRepository,
+		assignmentSuggestionRepository: assignmentSuggestionRepository,
+		slaPolicyRepository:            slaPolicyRepository,
+		locationRepository:             locationRepository,
+		teamRepository:                 teamRepository,
+		materialRepository:             materialRepository,
+		regionRepository:               regionRepository,
+		roadRepository:                 roadRepository,
+		serviceAreaRepository:          serviceAreaRepository,
+		datasetVersionRepository:       datasetVersionRepository,
 	}
 }
 
@@ -28,3 +80,55 @@ func (rp *repositoriesProvider) ProvideUserRepository() repositories.UserReposit
 func (rp *repositoriesProvider) ProvideReportRepository() repositories.ReportRepository {
 	return rp.reportRepository
 }
+
+func (rp *repositoriesProvider) ProvideAPIKeyRepository() repositories.APIKeyRepository {
+	return rp.apiKeyRepository
+}
+
+func (rp *repositoriesProvider) ProvideAuditRepository() repositories.AuditRepository {
+	return rp.auditRepository
+}
+
+func (rp *repositoriesProvider) ProvideNotificationRepository() repositories.NotificationRepository {
+	return rp.notificationRepository
+}
+
+func (rp *repositoriesProvider) ProvideWorkerRepository() repositories.WorkerRepository {
+	return rp.workerRepository
+}
+
+func (rp *repositoriesProvider) ProvideAssignmentSuggestionRepository() repositories.AssignmentSuggestionRepository {
+	return rp.assignmentSuggestionRepository
+}
+
+func (rp *repositoriesProvider) ProvideSLAPolicyRepository() repositories.SLAPolicyRepository {
+	return rp.slaPolicyRepository
+}
+
+func (rp *repositoriesProvider) ProvideLocationRepository() repositories.LocationRepository {
+	return rp.locationRepository
+}
+
+func (rp *repositoriesProvider) ProvideTeamRepository() repositories.TeamRepository {
+	return rp.teamRepository
+}
+
+func (rp *repositoriesProvider) ProvideMaterialRepository() repositories.MaterialRepository {
+	return rp.materialRepository
+}
+
+func (rp *repositoriesProvider) ProvideRegionRepository() repositories.RegionRepository {
+	return rp.regionRepository
+}
+
+func (rp *repositoriesProvider) ProvideRoadRepository() repositories.RoadRepository {
+	return rp.roadRepository
+}
+
+func (rp *repositoriesProvider) ProvideServiceAreaRepository() repositories.ServiceAreaRepository {
+	return rp.serviceAreaRepository
+}
+
+func (rp *repositoriesProvider) ProvideDatasetVersionRepository() repositories.DatasetVersionRepository {
+	return rp.datasetVersionRepository
+}
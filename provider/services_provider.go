@@ -5,19 +5,87 @@ import "dinacom-11.0-backend/services"
 type ServicesProvider interface {
 	ProvideAuthService() services.AuthService
 	ProvideReportService() services.ReportService
+	ProvideAPIKeyService() services.APIKeyService
+	ProvideAuditService() services.AuditService
+	ProvideNotificationService() services.NotificationService
+	ProvideWorkerService() services.WorkerService
+	ProvideAutoAssignService() services.AutoAssignService
+	ProvideSLAService() services.SLAService
+	ProvideRouteService() services.RouteService
+	ProvideLocationService() services.LocationService
+	ProvideTeamService() services.TeamService
+	ProvideMaterialService() services.MaterialService
+	ProvideMapService() services.MapService
+	ProvideRegionService() services.RegionService
+	ProvideRoadService() services.RoadService
+	ProvideGeocodeService() services.GeocodeService
+	ProvideServiceAreaService() services.ServiceAreaService
+	ProvideRecurrenceService() services.RecurrenceService
+	ProvideOpen311Service() services.Open311Service
 }
 
 type servicesProvider struct {
-	authService   services.AuthService
-	reportService services.ReportService
+	authService         services.AuthService
+	reportService       services.ReportService
+	apiKeyService       services.APIKeyService
+	auditService        services.AuditService
+	notificationService services.NotificationService
+	workerService       services.WorkerService
+	autoAssignService   services.AutoAssignService
+	slaService          services.SLAService
+	routeService        services.RouteService
+	locationService     services.LocationService
+	teamService         services.TeamService
+	materialService     services.MaterialService
+	mapService          services.MapService
+	regionService       services.RegionService
+	roadService         services.RoadService
+	geocodeService      services.GeocodeService
+	serviceAreaService  services.ServiceAreaService
+	recurrenceService   services.RecurrenceService
+	open311Service      services.Open311Service
 }
 
 func NewServicesProvider(repoProvider RepositoriesProvider, configProvider ConfigProvider) ServicesProvider {
-	authService := services.NewAuthService(repoProvider.ProvideUserRepository())
-	reportService := services.NewReportService(repoProvider.ProvideReportRepository(), repoProvider.ProvideUserRepository())
+	auditService := services.NewAuditService(repoProvider.ProvideAuditRepository())
+	notificationService := services.NewNotificationService(repoProvider.ProvideNotificationRepository(), repoProvider.ProvideUserRepository())
+	slaService := services.NewSLAService(repoProvider.ProvideReportRepository(), repoProvider.ProvideUserRepository(), repoProvider.ProvideSLAPolicyRepository(), auditService, notificationService, configProvider.ProvideEnvConfig().GetSLADueSoonWindow(), configProvider.ProvideEnvConfig().GetSLAEscalationGrace())
+	authService := services.NewAuthService(repoProvider.ProvideUserRepository(), auditService)
+	workerService := services.NewWorkerService(repoProvider.ProvideWorkerRepository(), repoProvider.ProvideUserRepository(), repoProvider.ProvideReportRepository(), auditService, notificationService)
+	autoAssignService := services.NewAutoAssignService(repoProvider.ProvideReportRepository(), repoProvider.ProvideUserRepository(), repoProvider.ProvideWorkerRepository(), repoProvider.ProvideAssignmentSuggestionRepository(), repoProvider.ProvideLocationRepository(), workerService, slaService, auditService, notificationService, configProvider.ProvideEnvConfig().GetAutoAssignMode())
+	materialService := services.NewMaterialService(repoProvider.ProvideMaterialRepository(), repoProvider.ProvideReportRepository(), auditService)
+	regionService := services.NewRegionService(repoProvider.ProvideRegionRepository(), repoProvider.ProvideReportRepository(), repoProvider.ProvideUserRepository(), repoProvider.ProvideDatasetVersionRepository(), auditService, configProvider.ProvideEnvConfig().GetRegionBoundariesPath())
+	roadService := services.NewRoadService(repoProvider.ProvideRoadRepository(), repoProvider.ProvideReportRepository(), repoProvider.ProvideDatasetVersionRepository(), auditService, configProvider.ProvideEnvConfig().GetRoadNetworkPath(), configProvider.ProvideEnvConfig().GetOwnRoadAuthorities())
+	geocodeService := services.NewGeocodeService(configProvider.ProvideEnvConfig().GetRoadNameExtractPath())
+	serviceAreaService := services.NewServiceAreaService(repoProvider.ProvideServiceAreaRepository(), repoProvider.ProvideDatasetVersionRepository(), auditService, configProvider.ProvideEnvConfig().GetServiceAreaMode())
+	recurrenceService := services.NewRecurrenceService(repoProvider.ProvideReportRepository(), repoProvider.ProvideUserRepository(), repoProvider.ProvideWorkerRepository(), notificationService, configProvider.ProvideEnvConfig().GetRecurrenceWindow(), configProvider.ProvideEnvConfig().GetRecurrenceRadiusMeters())
+	reportService := services.NewReportService(repoProvider.ProvideReportRepository(), repoProvider.ProvideUserRepository(), repoProvider.ProvideTeamRepository(), auditService, notificationService, autoAssignService, workerService, slaService, materialService, regionService, roadService, geocodeService, serviceAreaService, recurrenceService)
+	apiKeyService := services.NewAPIKeyService(repoProvider.ProvideAPIKeyRepository(), auditService)
+	routeService := services.NewRouteService(repoProvider.ProvideReportRepository(), repoProvider.ProvideWorkerRepository(), repoProvider.ProvideLocationRepository())
+	locationService := services.NewLocationService(repoProvider.ProvideLocationRepository(), repoProvider.ProvideUserRepository(), auditService, configProvider.ProvideEnvConfig().GetLocationRetention(), configProvider.ProvideEnvConfig().GetLocationMaxPingsPerWorker())
+	teamService := services.NewTeamService(repoProvider.ProvideTeamRepository(), repoProvider.ProvideUserRepository(), repoProvider.ProvideReportRepository(), auditService)
+	mapService := services.NewMapService(repoProvider.ProvideReportRepository(), reportService)
+	open311Service := services.NewOpen311Service(repoProvider.ProvideReportRepository(), reportService)
 	return &servicesProvider{
-		authService:   authService,
-		reportService: reportService,
+		authService:         authService,
+		reportService:       reportService,
+		apiKeyService:       apiKeyService,
+		auditService:        auditService,
+		notificationService: notificationService,
+		workerService:       workerService,
+		autoAssignService:   autoAssignService,
+		slaService:          slaService,
+		routeService:        routeService,
+		locationService:     locationService,
+		teamService:         teamService,
+		materialService:     materialService,
+		mapService:          mapService,
+		regionService:       regionService,
+		roadService:         roadService,
+		geocodeService:      geocodeService,
+		serviceAreaService:  serviceAreaService,
+		recurrenceService:   recurrenceService,
+		open311Service:      open311Service,
 	}
 }
 
@@ -28,3 +96,71 @@ func (s *servicesProvider) ProvideAuthService() services.AuthService {
 func (s *servicesProvider) ProvideReportService() services.ReportService {
 	return s.reportService
 }
+
+func (s *servicesProvider) ProvideAPIKeyService() services.APIKeyService {
+	return s.apiKeyService
+}
+
+func (s *servicesProvider) ProvideAuditService() services.AuditService {
+	return s.auditService
+}
+
+func (s *servicesProvider) ProvideNotificationService() services.NotificationService {
+	return s.notificationService
+}
+
+func (s *servicesProvider) ProvideWorkerService() services.WorkerService {
+	return s.workerService
+}
+
+func (s *servicesProvider) ProvideAutoAssignService() services.AutoAssignService {
+	return s.autoAssignService
+}
+
+func (s *servicesProvider) ProvideSLAService() services.SLAService {
+	return s.slaService
+}
+
+func (s *servicesProvider) ProvideRouteService() services.RouteService {
+	return s.routeService
+}
+
+func (s *servicesProvider) ProvideLocationService() services.LocationService {
+	return s.locationService
+}
+
+func (s *servicesProvider) ProvideTeamService() services.TeamService {
+	return s.teamService
+}
+
+func (s *servicesProvider) ProvideMaterialService() services.MaterialService {
+	return s.materialService
+}
+
+func (s *servicesProvider) ProvideMapService() services.MapService {
+	return s.mapService
+}
+
+func (s *servicesProvider) ProvideRegionService() services.RegionService {
+	return s.regionService
+}
+
+func (s *servicesProvider) ProvideRoadService() services.RoadService {
+	return s.roadService
+}
+
+func (s *servicesProvider) ProvideGeocodeService() services.GeocodeService {
+	return s.geocodeService
+}
+
+func (s *servicesProvider) ProvideServiceAreaService() services.ServiceAreaService {
+	return s.serviceAreaService
+}
+
+func (s *servicesProvider) ProvideRecurrenceService() services.RecurrenceService {
+	return s.recurrenceService
+}
+
+func (s *servicesProvider) ProvideOpen311Service() services.Open311Service {
+	return s.open311Service
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	entity "dinacom-11.0-backend/models/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type APIKeyRepository interface {
+	CreateAPIKey(key *entity.APIKey) error
+	GetAPIKeys() ([]entity.APIKey, error)
+	GetAPIKeyByID(id uuid.UUID) (*entity.APIKey, error)
+	GetAPIKeyByHash(hash string) (*entity.APIKey, error)
+	RevokeAPIKey(id uuid.UUID, revokedAt time.Time) error
+	RecordUsage(id uuid.UUID, ip string, usedAt time.Time, counts map[time.Time]int64) error
+	GetUsage(id uuid.UUID, since time.Time) ([]entity.APIKeyUsage, error)
+}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) CreateAPIKey(key *entity.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+func (r *apiKeyRepository) GetAPIKeys() ([]entity.APIKey, error) {
+	var keys []entity.APIKey
+	err := r.db.Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *apiKeyRepository) GetAPIKeyByID(id uuid.UUID) (*entity.APIKey, error) {
+	var key entity.APIKey
+	err := r.db.Where("id = ?", id).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) GetAPIKeyByHash(hash string) (*entity.APIKey, error) {
+	var key entity.APIKey
+	err := r.db.Where("key_hash = ?", hash).First(&key).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) RevokeAPIKey(id uuid.UUID, revokedAt time.Time) error {
+	return r.db.Model(&entity.APIKey{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", revokedAt).Error
+}
+
+// RecordUsage adds the requests counted per day, keyed by the start of the
+// day, the last made at usedAt from ip.
+func (r *apiKeyRepository) RecordUsage(id uuid.UUID, ip string, usedAt time.Time, counts map[time.Time]int64) error {
+	var total int64
+	for _, count := range counts {
+		total += count
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.APIKey{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"last_used_at": usedAt,
+			"last_used_ip": ip,
+			"usage_count":  gorm.Expr("usage_count + ?", total),
+		}).Error; err != nil {
+			return err
+		}
+
+		for day, count := range counts {
+			usage := entity.APIKeyUsage{APIKeyID: id, Day: day, Count: count}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "api_key_id"}, {Name: "day"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("api_key_usages.count + ?", count)}),
+			}).Create(&usage).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *apiKeyRepository) GetUsage(id uuid.UUID, since time.Time) ([]entity.APIKeyUsage, error) {
+	var usage []entity.APIKeyUsage
+	err := r.db.Where("api_key_id = ? AND day >= ?", id, since).Order("day ASC").Find(&usage).Error
+	return usage, err
+}
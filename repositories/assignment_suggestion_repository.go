@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"time"
+
+	entity "dinacom-11.0-backend/models/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AssignmentSuggestionRepository interface {
+	CreateSuggestion(suggestion *entity.AssignmentSuggestion) error
+	GetSuggestionByID(id uuid.UUID) (*entity.AssignmentSuggestion, error)
+	GetSuggestions(status string, regionCodes []string, limit, offset int) ([]entity.AssignmentSuggestion, int64, error)
+	UpdateSuggestionStatus(id uuid.UUID, status string, reviewedBy *uuid.UUID, reviewedAt time.Time) (int64, error)
+	OutdatePendingSuggestions(reportID string) error
+}
+
+type assignmentSuggestionRepository struct {
+	db *gorm.DB
+}
+
+func NewAssignmentSuggestionRepository(db *gorm.DB) AssignmentSuggestionRepository {
+	return &assignmentSuggestionRepository{db: db}
+}
+
+func (r *assignmentSuggestionRepository) CreateSuggestion(suggestion *entity.AssignmentSuggestion) error {
+	return r.db.Create(suggestion).Error
+}
+
+func (r *assignmentSuggestionRepository) GetSuggestionByID(id uuid.UUID) (*entity.AssignmentSuggestion, error) {
+	var suggestion entity.AssignmentSuggestion
+	err := r.db.Where("id = ?", id).First(&suggestion).Error
+	if err != nil {
+		return nil, err
+	}
+	return &suggestion, nil
+}
+
+func (r *assignmentSuggestionRepository) GetSuggestions(status string, regionCodes []string, limit, offset int) ([]entity.AssignmentSuggestion, int64, error) {
+	var suggestions []entity.AssignmentSuggestion
+	var total int64
+
+	query := r.db.Model(&entity.AssignmentSuggestion{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if len(regionCodes) > 0 {
+		query = query.Where("report_id IN (?)", inRegions(r.db.Model(&entity.Report{}).Select("id"), regionCodes))
+	}
+
+	query.Count(&total)
+	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&suggestions).Error
+	return suggestions, total, err
+}
+
+// UpdateSuggestionStatus only moves pending suggestions, so a suggestion can be
+// applied or dismissed once. It returns the number of rows changed.
+func (r *assignmentSuggestionRepository) UpdateSuggestionStatus(id uuid.UUID, status string, reviewedBy *uuid.UUID, reviewedAt time.Time) (int64, error) {
+	result := r.db.Model(&entity.AssignmentSuggestion{}).
+		Where("id = ? AND status = ?", id, entity.SUGGESTION_PENDING).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"reviewed_by": reviewedBy,
+			"reviewed_at": reviewedAt,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *assignmentSuggestionRepository) OutdatePendingSuggestions(reportID string) error {
+	return r.db.Model(&entity.AssignmentSuggestion{}).
+		Where("report_id = ? AND status = ?", reportID, entity.SUGGESTION_PENDING).
+		Update("status", entity.SUGGESTION_OUTDATED).Error
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+
+	"gorm.io/gorm"
+)
+
+type AuditRepository interface {
+	CreateAuditLog(log *entity.AuditLog) error
+	GetAuditLogs(filter dto.AuditLogFilter, limit, offset int) ([]entity.AuditLog, int64, error)
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) CreateAuditLog(log *entity.AuditLog) error {
+	return r.db.Create(log).Error
+}
+
+func (r *auditRepository) GetAuditLogs(filter dto.AuditLogFilter, limit, offset int) ([]entity.AuditLog, int64, error) {
+	var logs []entity.AuditLog
+	var total int64
+	query := r.applyFilter(r.db.Model(&entity.AuditLog{}), filter)
+	query.Count(&total)
+	err := r.applyFilter(r.db, filter).Order("created_at DESC").Limit(limit).Offset(offset).Find(&logs).Error
+	return logs, total, err
+}
+
+func (r *auditRepository) applyFilter(query *gorm.DB, filter dto.AuditLogFilter) *gorm.DB {
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.ActorType != "" {
+		query = query.Where("actor_type = ?", filter.ActorType)
+	}
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.IP != "" {
+		query = query.Where("ip = ?", filter.IP)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+	if len(filter.RegionCodes) > 0 {
+		query = query.Where("target_type = ? AND target_id IN (?)", entity.AUDIT_TARGET_REPORT,
+			inRegions(r.db.Model(&entity.Report{}).Select("id"), filter.RegionCodes))
+	}
+	return query
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	entity "dinacom-11.0-backend/models/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DatasetVersionRepository interface {
+	GetVersion(name string) (int64, error)
+	BumpVersion(name string) (int64, error)
+}
+
+type datasetVersionRepository struct {
+	db *gorm.DB
+}
+
+func NewDatasetVersionRepository(db *gorm.DB) DatasetVersionRepository {
+	return &datasetVersionRepository{db: db}
+}
+
+// GetVersion returns the stored version of the dataset, 0 before its first
+// reload.
+func (r *datasetVersionRepository) GetVersion(name string) (int64, error) {
+	var versions []entity.DatasetVersion
+	if err := r.db.Where("name = ?", name).Limit(1).Find(&versions).Error; err != nil {
+		return 0, err
+	}
+	if len(versions) == 0 {
+		return 0, nil
+	}
+	return versions[0].Version, nil
+}
+
+// BumpVersion raises the version of the dataset by one and returns it.
+func (r *datasetVersionRepository) BumpVersion(name string) (int64, error) {
+	version := entity.DatasetVersion{Name: name, Version: 1}
+	err := r.db.Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"version": gorm.Expr("dataset_versions.version + 1"), "updated_at": gorm.Expr("NOW()")}),
+		},
+		clause.Returning{Columns: []clause.Column{{Name: "version"}}},
+	).Create(&version).Error
+	return version.Version, err
+}
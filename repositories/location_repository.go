@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"time"
+
+	entity "dinacom-11.0-backend/models/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LocationRepository interface {
+	CreateSession(session *entity.WorkerShiftSession) error
+	GetOpenSession(workerID uuid.UUID) (*entity.WorkerShiftSession, error)
+	GetOpenSessions() ([]entity.WorkerShiftSession, error)
+	EndSession(id uuid.UUID, endedAt time.Time) error
+	CreatePings(pings []entity.WorkerLocationPing) error
+	GetLatestPing(workerID uuid.UUID, since time.Time) (*entity.WorkerLocationPing, error)
+	GetLatestPings(since time.Time) ([]entity.WorkerLocationPing, error)
+	GetLatestPingsOfOpenSessions() ([]entity.WorkerLocationPing, error)
+	GetPings(workerID uuid.UUID, from, to time.Time, limit int) ([]entity.WorkerLocationPing, error)
+	DeletePingsBefore(t time.Time) (int64, error)
+	TrimPings(maxPerWorker int) (int64, error)
+}
+
+type locationRepository struct {
+	db *gorm.DB
+}
+
+func NewLocationRepository(db *gorm.DB) LocationRepository {
+	return &locationRepository{db: db}
+}
+
+func (r *locationRepository) CreateSession(session *entity.WorkerShiftSession) error {
+	return r.db.Create(session).Error
+}
+
+func (r *locationRepository) GetOpenSession(workerID uuid.UUID) (*entity.WorkerShiftSession, error) {
+	var session entity.WorkerShiftSession
+	if err := r.db.Where("worker_id = ? AND ended_at IS NULL", workerID).Order("started_at DESC").First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *locationRepository) GetOpenSessions() ([]entity.WorkerShiftSession, error) {
+	var sessions []entity.WorkerShiftSession
+	err := r.db.Where("ended_at IS NULL").Order("started_at ASC").Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *locationRepository) EndSession(id uuid.UUID, endedAt time.Time) error {
+	return r.db.Model(&entity.WorkerShiftSession{}).Where("id = ? AND ended_at IS NULL", id).Update("ended_at", endedAt).Error
+}
+
+func (r *locationRepository) CreatePings(pings []entity.WorkerLocationPing) error {
+	return r.db.Create(&pings).Error
+}
+
+// GetLatestPing returns the newest ping of the worker's open, tracked session
+// recorded after since.
+func (r *locationRepository) GetLatestPing(workerID uuid.UUID, since time.Time) (*entity.WorkerLocationPing, error) {
+	var ping entity.WorkerLocationPing
+	err := r.db.Joins("JOIN worker_shift_sessions ON worker_shift_sessions.id = worker_location_pings.session_id").
+		Where("worker_location_pings.worker_id = ? AND worker_location_pings.recorded_at >= ?", workerID, since).
+		Where("worker_shift_sessions.ended_at IS NULL AND worker_shift_sessions.tracking_consent = ?", true).
+		Order("worker_location_pings.recorded_at DESC").
+		First(&ping).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ping, nil
+}
+
+// GetLatestPings returns the newest ping of every worker's open, tracked
+// session recorded after since.
+func (r *locationRepository) GetLatestPings(since time.Time) ([]entity.WorkerLocationPing, error) {
+	var pings []entity.WorkerLocationPing
+	err := r.db.Raw(`SELECT DISTINCT ON (p.worker_id) p.*
+		FROM worker_location_pings p
+		JOIN worker_shift_sessions s ON s.id = p.session_id
+		WHERE p.recorded_at >= ? AND s.ended_at IS NULL AND s.tracking_consent
+		ORDER BY p.worker_id, p.recorded_at DESC`, since).Scan(&pings).Error
+	return pings, err
+}
+
+func (r *locationRepository) GetLatestPingsOfOpenSessions() ([]entity.WorkerLocationPing, error) {
+	var pings []entity.WorkerLocationPing
+	err := r.db.Raw(`SELECT DISTINCT ON (p.session_id) p.*
+		FROM worker_location_pings p
+		JOIN worker_shift_sessions s ON s.id = p.session_id
+		WHERE s.ended_at IS NULL AND s.tracking_consent
+		ORDER BY p.session_id, p.recorded_at DESC`).Scan(&pings).Error
+	return pings, err
+}
+
+func (r *locationRepository) GetPings(workerID uuid.UUID, from, to time.Time, limit int) ([]entity.WorkerLocationPing, error) {
+	var pings []entity.WorkerLocationPing
+	err := r.db.Where("worker_id = ? AND recorded_at BETWEEN ? AND ?", workerID, from, to).
+		Order("recorded_at ASC").
+		Limit(limit).
+		Find(&pings).Error
+	return pings, err
+}
+
+func (r *locationRepository) DeletePingsBefore(t time.Time) (int64, error) {
+	result := r.db.Where("recorded_at < ?", t).Delete(&entity.WorkerLocationPing{})
+	return result.RowsAffected, result.Error
+}
+
+// TrimPings keeps only the newest maxPerWorker pings of every worker.
+func (r *locationRepository) TrimPings(maxPerWorker int) (int64, error) {
+	result := r.db.Exec(`DELETE FROM worker_location_pings WHERE id IN (
+		SELECT id FROM (
+			SELECT id, ROW_NUMBER() OVER (PARTITION BY worker_id ORDER BY recorded_at DESC) AS position
+			FROM worker_location_pings
+		) ranked WHERE position > ?)`, maxPerWorker)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"time"
+
+	entity "dinacom-11.0-backend/models/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MaterialRepository interface {
+	CreateMaterial(material *entity.Material) error
+	GetMaterialByID(id uuid.UUID) (*entity.Material, error)
+	GetMaterialByName(name string) (*entity.Material, error)
+	GetMaterials(activeOnly bool) ([]entity.Material, error)
+	GetMaterialsByIDs(ids []uuid.UUID) ([]entity.Material, error)
+	UpdateMaterial(material *entity.Material) error
+	DeleteMaterial(id uuid.UUID) (int64, error)
+	CountUsage(materialID uuid.UUID) (int64, error)
+	GetReportMaterials(reportID string) ([]entity.ReportMaterial, error)
+	GetCostStats(groupBy string, from, to *time.Time, regionCodes []string) ([]MaterialCostStat, error)
+	CountCostReports(groupBy string, from, to *time.Time, regionCodes []string) (map[string]int64, error)
+}
+
+// MaterialCostStat is the quantity and cost of one material used on the
+// reports sharing a group key.
+type MaterialCostStat struct {
+	GroupKey     string
+	MaterialID   uuid.UUID
+	MaterialName string
+	Unit         string
+	Quantity     float64
+	Cost         float64
+}
+
+// materialGroups maps the cost grouping names to their SQL expressions. Roads
+// go by their canonical name where one was found, as typed names vary.
+var materialGroups = map[string]string{
+	entity.COST_GROUP_ROAD:     "COALESCE(NULLIF(reports.canonical_road_name, ''), reports.road_name)",
+	entity.COST_GROUP_DISTRICT: "reports.district",
+	entity.COST_GROUP_MONTH:    "to_char(report_materials.created_at, 'YYYY-MM')",
+}
+
+type materialRepository struct {
+	db *gorm.DB
+}
+
+func NewMaterialRepository(db *gorm.DB) MaterialRepository {
+	return &materialRepository{db: db}
+}
+
+func (r *materialRepository) CreateMaterial(material *entity.Material) error {
+	return r.db.Create(material).Error
+}
+
+func (r *materialRepository) GetMaterialByID(id uuid.UUID) (*entity.Material, error) {
+	var material entity.Material
+	if err := r.db.Where("id = ?", id).First(&material).Error; err != nil {
+		return nil, err
+	}
+	return &material, nil
+}
+
+func (r *materialRepository) GetMaterialByName(name string) (*entity.Material, error) {
+	var material entity.Material
+	if err := r.db.Where("LOWER(name) = LOWER(?)", name).First(&material).Error; err != nil {
+		return nil, err
+	}
+	return &material, nil
+}
+
+func (r *materialRepository) GetMaterials(activeOnly bool) ([]entity.Material, error) {
+	var materials []entity.Material
+	query := r.db.Order("name ASC")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Find(&materials).Error
+	return materials, err
+}
+
+func (r *materialRepository) GetMaterialsByIDs(ids []uuid.UUID) ([]entity.Material, error) {
+	var materials []entity.Material
+	err := r.db.Where("id IN ?", ids).Find(&materials).Error
+	return materials, err
+}
+
+func (r *materialRepository) UpdateMaterial(material *entity.Material) error {
+	return r.db.Model(material).Select("name", "unit", "unit_cost", "active").Updates(material).Error
+}
+
+func (r *materialRepository) DeleteMaterial(id uuid.UUID) (int64, error) {
+	result := r.db.Where("id = ?", id).Delete(&entity.Material{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *materialRepository) CountUsage(materialID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.ReportMaterial{}).Where("material_id = ?", materialID).Count(&count).Error
+	return count, err
+}
+
+func (r *materialRepository) GetReportMaterials(reportID string) ([]entity.ReportMaterial, error) {
+	var usage []entity.ReportMaterial
+	err := r.db.Preload("Material").Where("report_id = ?", reportID).Order("created_at ASC").Find(&usage).Error
+	return usage, err
+}
+
+// costQuery joins material usage to the reports it was used on, skipping
+// deleted reports, within the optional usage period and regions.
+func (r *materialRepository) costQuery(from, to *time.Time, regionCodes []string) *gorm.DB {
+	query := r.db.Table("report_materials").
+		Joins("JOIN reports ON reports.id = report_materials.report_id AND reports.deleted_at IS NULL")
+	if from != nil {
+		query = query.Where("report_materials.created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("report_materials.created_at <= ?", *to)
+	}
+	return inRegions(query, regionCodes)
+}
+
+func (r *materialRepository) GetCostStats(groupBy string, from, to *time.Time, regionCodes []string) ([]MaterialCostStat, error) {
+	var stats []MaterialCostStat
+	err := r.costQuery(from, to, regionCodes).
+		Joins("JOIN materials ON materials.id = report_materials.material_id").
+		Select("COALESCE(" + materialGroups[groupBy] + ", '') AS group_key, materials.id AS material_id, materials.name AS material_name, materials.unit AS unit, " +
+			"SUM(report_materials.quantity) AS quantity, SUM(report_materials.cost) AS cost").
+		Group("group_key, materials.id, materials.name, materials.unit").
+		Order("group_key ASC, materials.name ASC").
+		Scan(&stats).Error
+	return stats, err
+}
+
+func (r *materialRepository) CountCostReports(groupBy string, from, to *time.Time, regionCodes []string) (map[string]int64, error) {
+	var rows []struct {
+		GroupKey string
+		Reports  int64
+	}
+	err := r.costQuery(from, to, regionCodes).
+		Select("COALESCE(" + materialGroups[groupBy] + ", '') AS group_key, COUNT(DISTINCT report_materials.report_id) AS reports").
+		Group("group_key").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.GroupKey] = row.Reports
+	}
+	return counts, nil
+}
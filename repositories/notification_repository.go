@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"time"
+
+	entity "dinacom-11.0-backend/models/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	CreateNotification(notification *entity.Notification) error
+	GetNotificationsByUserID(userID uuid.UUID, limit, offset int) ([]entity.Notification, int64, error)
+	CountUnread(userID uuid.UUID) (int64, error)
+	MarkAsRead(userID uuid.UUID, id uuid.UUID, readAt time.Time) (int64, error)
+	MarkAllAsRead(userID uuid.UUID, readAt time.Time) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) CreateNotification(notification *entity.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+func (r *notificationRepository) GetNotificationsByUserID(userID uuid.UUID, limit, offset int) ([]entity.Notification, int64, error) {
+	var notifications []entity.Notification
+	var total int64
+	r.db.Model(&entity.Notification{}).Where("user_id = ?", userID).Count(&total)
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Limit(limit).Offset(offset).Find(&notifications).Error
+	return notifications, total, err
+}
+
+func (r *notificationRepository) CountUnread(userID uuid.UUID) (int64, error) {
+	var total int64
+	err := r.db.Model(&entity.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&total).Error
+	return total, err
+}
+
+func (r *notificationRepository) MarkAsRead(userID uuid.UUID, id uuid.UUID, readAt time.Time) (int64, error) {
+	result := r.db.Model(&entity.Notification{}).Where("id = ? AND user_id = ?", id, userID).Update("read_at", readAt)
+	return result.RowsAffected, result.Error
+}
+
+func (r *notificationRepository) MarkAllAsRead(userID uuid.UUID, readAt time.Time) error {
+	return r.db.Model(&entity.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Update("read_at", readAt).Error
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	entity "dinacom-11.0-backend/models/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RegionRepository interface {
+	UpsertRegions(regions []entity.Region) error
+	GetRegions(level, parentCode string) ([]entity.Region, error)
+	GetRegionsWithGeometry() ([]entity.Region, error)
+	GetRegionByCode(code string) (*entity.Region, error)
+	GetRegionsByCodes(codes []string) ([]entity.Region, error)
+	GetAdminRegionCodes(userID uuid.UUID) ([]string, error)
+	SetAdminRegions(userID uuid.UUID, codes []string) error
+}
+
+type regionRepository struct {
+	db *gorm.DB
+}
+
+func NewRegionRepository(db *gorm.DB) RegionRepository {
+	return &regionRepository{db: db}
+}
+
+// UpsertRegions inserts the regions or, for codes already known, replaces
+// their name, level, parent and boundary.
+func (r *regionRepository) UpsertRegions(regions []entity.Region) error {
+	if len(regions) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "level", "parent_code", "min_lat", "min_lng", "max_lat", "max_lng", "geometry", "updated_at"}),
+	}).CreateInBatches(regions, 100).Error
+}
+
+func (r *regionRepository) GetRegions(level, parentCode string) ([]entity.Region, error) {
+	var regions []entity.Region
+	query := r.db.Omit("geometry").Order("code ASC")
+	if level != "" {
+		query = query.Where("level = ?", level)
+	}
+	if parentCode != "" {
+		query = query.Where("parent_code = ?", parentCode)
+	}
+	err := query.Find(&regions).Error
+	return regions, err
+}
+
+func (r *regionRepository) GetRegionsWithGeometry() ([]entity.Region, error) {
+	var regions []entity.Region
+	err := r.db.Order("code ASC").Find(&regions).Error
+	return regions, err
+}
+
+func (r *regionRepository) GetRegionByCode(code string) (*entity.Region, error) {
+	var region entity.Region
+	if err := r.db.Omit("geometry").Where("code = ?", code).First(&region).Error; err != nil {
+		return nil, err
+	}
+	return &region, nil
+}
+
+func (r *regionRepository) GetRegionsByCodes(codes []string) ([]entity.Region, error) {
+	var regions []entity.Region
+	if len(codes) == 0 {
+		return regions, nil
+	}
+	err := r.db.Omit("geometry").Where("code IN ?", codes).Order("code ASC").Find(&regions).Error
+	return regions, err
+}
+
+func (r *regionRepository) GetAdminRegionCodes(userID uuid.UUID) ([]string, error) {
+	var codes []string
+	err := r.db.Model(&entity.AdminRegion{}).Where("user_id = ?", userID).Order("region_code ASC").Pluck("region_code", &codes).Error
+	return codes, err
+}
+
+// SetAdminRegions replaces the regions an admin is limited to.
+func (r *regionRepository) SetAdminRegions(userID uuid.UUID, codes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.AdminRegion{}).Error; err != nil {
+			return err
+		}
+		for _, code := range codes {
+			if err := tx.Create(&entity.AdminRegion{UserID: userID, RegionCode: code}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -1,9 +1,11 @@
 package repositories
 
 import (
+	"strings"
 	"time"
 
 	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -12,14 +14,61 @@ import (
 type ReportRepository interface {
 	CreateReport(report *entity.Report) error
 	GetCompletedNonGoodReports() ([]entity.Report, error)
+	SearchReports(filter ReportSpatialFilter, limit, offset int) ([]entity.Report, error)
+	AggregateReports(filter ReportSpatialFilter, cellsAcross float64) ([]ReportGridCell, error)
+	GetReportsWithoutGeohash(limit int) ([]entity.Report, error)
+	SetGeohash(reportID string, geohash string) error
 	GetReportByID(id string) (*entity.Report, error)
-	AssignWorker(reportID string, workerID uuid.UUID, adminNotes string, deadline *time.Time) error
-	GetAssignedReports() ([]entity.Report, error)
-	UpdateAfterImage(reportID string, afterImageURL string, status string) error
+	AssignWorker(assignment *entity.ReportAssignment, version int) error
+	RespondToAssignment(reportID string, version int, workerID uuid.UUID, status string, reason string, unassignedStatus string) error
+	ReassignWorker(assignment *entity.ReportAssignment, version int, reason string) error
+	UnassignWorker(reportID string, version int, reason string, unassignedStatus string) error
+	GetAssignmentsByReportID(reportID string) ([]entity.ReportAssignment, error)
+	CompleteReport(reportID string, version int) error
+	GetAssignedReports(regionCodes []string) ([]entity.Report, error)
+	AdvanceWorkStage(progress *entity.ReportProgress, version int) error
+	FinishWorkOrder(progress *entity.ReportProgress, version int, afterImageURL string, materials []entity.ReportMaterial) error
 	GetReportsByUserID(userID uuid.UUID, limit, offset int) ([]entity.Report, int64, error)
 	GetAssignedReportsByWorkerID(workerID uuid.UUID, limit, offset int) ([]entity.Report, int64, error)
+	CountOpenJobsByWorker(workerIDs []uuid.UUID) (map[uuid.UUID]int64, error)
 	GetWorkerHistory(workerID uuid.UUID, status string, limit, offset int) ([]entity.Report, int64, error)
-	UpdateStatus(reportID string, status string) error
+	GetReportsByStatus(status string, regionCodes []string, limit, offset int) ([]entity.Report, int64, error)
+	UpdateClassification(reportID string, version int, destructClass string, locationScore, totalScore float64, district string) error
+	DeleteReport(reportID string, version int) error
+	RejectReport(reportID string, version int, reasonCode, note string, rejectedBy *uuid.UUID, rejectedAt time.Time) error
+	ReworkReport(rework *entity.ReportRework, version int) error
+	GetReworksByReportID(reportID string) ([]entity.ReportRework, error)
+	IsCrewMember(reportID string, workerID uuid.UUID) (bool, error)
+	CountOpenReportsByTeam(teamID uuid.UUID) (int64, error)
+	CreateProgress(progress *entity.ReportProgress) error
+	GetProgressByReportID(reportID string) ([]entity.ReportProgress, error)
+	GetOpenReportsWithDeadlineBefore(t time.Time) ([]entity.Report, error)
+	GetOverdueReports(now time.Time, regionCodes []string) ([]entity.Report, error)
+	MarkDeadlineWarned(reportID string, at time.Time) (bool, error)
+	MarkOverdue(reportID string, at time.Time) (bool, error)
+	MarkEscalated(reportID string, at time.Time) (bool, error)
+	GetSLAStatsByWorker(from, to *time.Time, regionCodes []string) ([]SLAStat, error)
+	GetSLAStatsByRoad(from, to *time.Time, regionCodes []string) ([]SLAStat, error)
+	CountOverdueByWorker(now time.Time, regionCodes []string) (map[string]int64, error)
+	CountOverdueByRoad(now time.Time, regionCodes []string) (map[string]int64, error)
+	GetReportsWithoutRegions(version int64, limit int) ([]entity.Report, error)
+	SetRegions(report *entity.Report) error
+	GetReportsWithoutRoad(version int64, limit int) ([]entity.Report, error)
+	SetRoad(report *entity.Report) error
+	GetReportsWithoutRoadKey(limit int) ([]entity.Report, error)
+	SetRoadKey(reportID string, roadKey string) error
+	GetOpenReportsByAuthority(authority string, regionCodes []string, limit, offset int) ([]entity.Report, int64, error)
+	CountOpenReportsByAuthority(regionCodes []string) (map[string]int64, error)
+	ForwardReport(reportID string, version int, authority, note string, forwardedBy *uuid.UUID, forwardedAt time.Time) error
+	GetRoadConditionStats(query RoadConditionQuery) ([]RoadConditionStat, int64, error)
+	GetRoadClassCounts(roadKeys []string, regionCodes []string) ([]RoadClassCount, error)
+	GetRoadConditionHistory(query RoadConditionHistoryQuery) ([]RoadConditionPoint, error)
+	GetRepairsInBox(minLat, minLng, maxLat, maxLng float64, since time.Time) ([]entity.Report, error)
+	GetRecurrenceStatsByWorker(from, to *time.Time, regionCodes []string) ([]RecurrenceStat, error)
+	GetRecurrenceStatsByContractor(from, to *time.Time, regionCodes []string) ([]RecurrenceStat, error)
+	GetDamageClasses() ([]string, error)
+	GetServiceRequests(filter ServiceRequestFilter, limit int) ([]entity.Report, error)
+	GetReportByToken(token string) (*entity.Report, error)
 }
 
 type reportRepository struct {
@@ -40,61 +89,973 @@ func (r *reportRepository) GetCompletedNonGoodReports() ([]entity.Report, error)
 	return reports, err
 }
 
+// ReportSpatialFilter narrows the public map reports to a bounding box. Cells
+// are the geohash prefixes covering the box; without cells only the
+// coordinates are compared.
+type ReportSpatialFilter struct {
+	MinLat, MinLng float64
+	MaxLat, MaxLng float64
+	HasBBox        bool
+	Cells          []string
+}
+
+// SearchReports returns completed non-good reports in the filter's box, most
+// severe first.
+func (r *reportRepository) SearchReports(filter ReportSpatialFilter, limit, offset int) ([]entity.Report, error) {
+	var reports []entity.Report
+	err := r.spatialQuery(filter).Order("total_score DESC, created_at DESC, id ASC").Limit(limit).Offset(offset).Find(&reports).Error
+	return reports, err
+}
+
+// ReportGridCell aggregates the public map reports falling in one cell of a
+// web mercator grid. ReportID and MaxClass belong to the most severe report.
+type ReportGridCell struct {
+	CellX      int64
+	CellY      int64
+	Count      int64
+	MaxScore   float64
+	TotalScore float64
+	CenterLat  float64
+	CenterLng  float64
+	MaxClass   string
+	ReportID   string
+}
+
+// AggregateReports groups the public map reports in the filter's box into a
+// web mercator grid that is cellsAcross cells wide around the whole world.
+func (r *reportRepository) AggregateReports(filter ReportSpatialFilter, cellsAcross float64) ([]ReportGridCell, error) {
+	var cells []ReportGridCell
+	err := r.spatialQuery(filter).Model(&entity.Report{}).
+		Select("CAST(FLOOR((longitude + 180) / 360 * ?) AS bigint) AS cell_x, "+
+			"CAST(FLOOR((1 - LN(TAN(RADIANS(latitude)) + 1 / COS(RADIANS(latitude))) / PI()) / 2 * ?) AS bigint) AS cell_y, "+
+			"COUNT(*) AS count, MAX(total_score) AS max_score, SUM(total_score) AS total_score, "+
+			"AVG(latitude) AS center_lat, AVG(longitude) AS center_lng, "+
+			"(ARRAY_AGG(destruct_class ORDER BY total_score DESC))[1] AS max_class, "+
+			"(ARRAY_AGG(CAST(id AS text) ORDER BY total_score DESC))[1] AS report_id", cellsAcross, cellsAcross).
+		Group("cell_x, cell_y").
+		Scan(&cells).Error
+	return cells, err
+}
+
+// spatialQuery selects completed non-good reports in the filter's box. The
+// geohash prefixes are matched through the pattern index; reports not yet
+// given a geohash are looked up on their own through the pending index and
+// matched on coordinates.
+func (r *reportRepository) spatialQuery(filter ReportSpatialFilter) *gorm.DB {
+	query := r.db.Where("status = ? AND destruct_class != ?", entity.STATUS_COMPLETED, entity.DESTRUCT_CLASS_GOOD)
+
+	if len(filter.Cells) > 0 {
+		conditions := make([]string, 0, len(filter.Cells))
+		args := make([]interface{}, 0, len(filter.Cells))
+		for _, cell := range filter.Cells {
+			conditions = append(conditions, "geohash LIKE ?")
+			args = append(args, cell+"%")
+		}
+		cells := r.db.Model(&entity.Report{}).Select("id").Where(strings.Join(conditions, " OR "), args...)
+		pending := r.db.Model(&entity.Report{}).Select("id").Where("geohash IS NULL OR geohash = ''")
+		query = query.Where("id IN (?)", gorm.Expr("? UNION ALL ?", cells, pending))
+	}
+	if filter.HasBBox {
+		query = query.Where("latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?", filter.MinLat, filter.MaxLat, filter.MinLng, filter.MaxLng)
+	}
+	return query
+}
+
+func (r *reportRepository) GetReportsWithoutGeohash(limit int) ([]entity.Report, error) {
+	var reports []entity.Report
+	err := r.db.Where("geohash IS NULL OR geohash = ''").Limit(limit).Find(&reports).Error
+	return reports, err
+}
+
+// SetGeohash fills in the derived geohash without bumping the version, as the
+// report itself does not change.
+func (r *reportRepository) SetGeohash(reportID string, geohash string) error {
+	return r.db.Model(&entity.Report{}).Where("id = ?", reportID).UpdateColumn("geohash", geohash).Error
+}
+
 func (r *reportRepository) GetReportByID(id string) (*entity.Report, error) {
 	var report entity.Report
-	err := r.db.Where("id = ?", id).First(&report).Error
+	err := r.db.Preload("SLAPolicy").Where("id = ?", id).First(&report).Error
 	if err != nil {
 		return nil, err
 	}
 	return &report, nil
 }
 
-func (r *reportRepository) AssignWorker(reportID string, workerID uuid.UUID, adminNotes string, deadline *time.Time) error {
-	return r.db.Model(&entity.Report{}).Where("id = ?", reportID).Updates(map[string]interface{}{
-		"worker_id":   workerID,
-		"status":      entity.STATUS_ASSIGNED,
-		"admin_notes": adminNotes,
-		"deadline":    deadline,
+// updateVersioned applies updates only while the report is still at the
+// version the caller read and bumps it. Report writes go first in every
+// transaction so a concurrent writer blocks on the row lock and then fails here
+// instead of overwriting the first change.
+func (r *reportRepository) updateVersioned(tx *gorm.DB, reportID string, version int, updates map[string]interface{}) error {
+	updates["version"] = gorm.Expr("version + 1")
+	result := tx.Model(&entity.Report{}).Where("id = ? AND version = ?", reportID, version).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return http_error.REPORT_VERSION_CONFLICT
+	}
+	return nil
+}
+
+func (r *reportRepository) AssignWorker(assignment *entity.ReportAssignment, version int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return r.startAssignment(tx, assignment, version)
+	})
+}
+
+// RespondToAssignment records the worker's answer to a pending assignment. A
+// declined report is released back to the unassigned queue.
+func (r *reportRepository) RespondToAssignment(reportID string, version int, workerID uuid.UUID, status string, reason string, unassignedStatus string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if status == entity.ASSIGNMENT_DECLINED {
+			if err := r.releaseReport(tx, reportID, version, unassignedStatus); err != nil {
+				return err
+			}
+		} else if err := r.updateVersioned(tx, reportID, version, map[string]interface{}{"assignment_status": status}); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		updates := map[string]interface{}{
+			"status":       status,
+			"reason":       reason,
+			"responded_at": now,
+		}
+		if status == entity.ASSIGNMENT_DECLINED {
+			updates["ended_at"] = now
+		}
+		return tx.Model(&entity.ReportAssignment{}).
+			Where("report_id = ? AND worker_id = ? AND ended_at IS NULL", reportID, workerID).
+			Updates(updates).Error
+	})
+}
+
+func (r *reportRepository) ReassignWorker(assignment *entity.ReportAssignment, version int, reason string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.updateVersioned(tx, assignment.ReportID, version, r.assignmentUpdates(assignment)); err != nil {
+			return err
+		}
+		if err := r.endActiveAssignment(tx, assignment.ReportID, entity.ASSIGNMENT_REASSIGNED, reason); err != nil {
+			return err
+		}
+		return tx.Create(assignment).Error
+	})
+}
+
+func (r *reportRepository) UnassignWorker(reportID string, version int, reason string, unassignedStatus string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.releaseReport(tx, reportID, version, unassignedStatus); err != nil {
+			return err
+		}
+		return r.endActiveAssignment(tx, reportID, entity.ASSIGNMENT_UNASSIGNED, reason)
+	})
+}
+
+func (r *reportRepository) GetAssignmentsByReportID(reportID string) ([]entity.ReportAssignment, error) {
+	var assignments []entity.ReportAssignment
+	err := r.db.Preload("Crew").Where("report_id = ?", reportID).Order("created_at DESC").Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *reportRepository) startAssignment(tx *gorm.DB, assignment *entity.ReportAssignment, version int) error {
+	if err := r.updateVersioned(tx, assignment.ReportID, version, r.assignmentUpdates(assignment)); err != nil {
+		return err
+	}
+	return tx.Create(assignment).Error
+}
+
+func (r *reportRepository) assignmentUpdates(assignment *entity.ReportAssignment) map[string]interface{} {
+	return map[string]interface{}{
+		"worker_id":          assignment.WorkerID,
+		"status":             entity.STATUS_ASSIGNED,
+		"assignment_status":  assignment.Status,
+		"admin_notes":        assignment.AdminNotes,
+		"deadline":           assignment.Deadline,
+		"deadline_warned_at": nil,
+		"overdue_at":         nil,
+		"escalated_at":       nil,
+		"sla_policy_id":      assignment.SLAPolicyID,
+		"team_id":            assignment.TeamID,
+		"work_stage":         "",
+		"work_stage_at":      nil,
+	}
+}
+
+func (r *reportRepository) endActiveAssignment(tx *gorm.DB, reportID string, status string, reason string) error {
+	return tx.Model(&entity.ReportAssignment{}).Where("report_id = ? AND ended_at IS NULL", reportID).Updates(map[string]interface{}{
+		"status":   status,
+		"reason":   reason,
+		"ended_at": time.Now(),
 	}).Error
 }
 
-func (r *reportRepository) GetAssignedReports() ([]entity.Report, error) {
+func (r *reportRepository) releaseReport(tx *gorm.DB, reportID string, version int, unassignedStatus string) error {
+	return r.updateVersioned(tx, reportID, version, map[string]interface{}{
+		"worker_id":          nil,
+		"status":             unassignedStatus,
+		"assignment_status":  "",
+		"deadline":           nil,
+		"deadline_warned_at": nil,
+		"overdue_at":         nil,
+		"escalated_at":       nil,
+		"sla_policy_id":      nil,
+		"team_id":            nil,
+		"work_stage":         "",
+		"work_stage_at":      nil,
+	})
+}
+
+func (r *reportRepository) GetAssignedReports(regionCodes []string) ([]entity.Report, error) {
 	var reports []entity.Report
-	err := r.db.Where("worker_id IS NOT NULL").Find(&reports).Error
+	err := inRegions(r.db.Where("worker_id IS NOT NULL"), regionCodes).Find(&reports).Error
 	return reports, err
 }
 
-func (r *reportRepository) UpdateAfterImage(reportID string, afterImageURL string, status string) error {
-	return r.db.Model(&entity.Report{}).Where("id = ?", reportID).Updates(map[string]interface{}{
-		"after_image_url": afterImageURL,
-		"status":          status,
-	}).Error
+// AdvanceWorkStage moves the work order to the stage of the progress update
+// and stores the update. Working on the report implies the worker accepted it,
+// so a still pending assignment is accepted as well.
+func (r *reportRepository) AdvanceWorkStage(progress *entity.ReportProgress, version int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := r.updateVersioned(tx, progress.ReportID, version, map[string]interface{}{
+			"work_stage":        progress.Stage,
+			"work_stage_at":     now,
+			"assignment_status": entity.ASSIGNMENT_ACCEPTED,
+		}); err != nil {
+			return err
+		}
+		if err := tx.Create(progress).Error; err != nil {
+			return err
+		}
+		return r.acceptPendingAssignment(tx, progress.ReportID, progress.WorkerID, now)
+	})
+}
+
+// FinishWorkOrder stores the done stage update with the materials used and
+// hands the report to the admin for verification with the given after image.
+func (r *reportRepository) FinishWorkOrder(progress *entity.ReportProgress, version int, afterImageURL string, materials []entity.ReportMaterial) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := r.updateVersioned(tx, progress.ReportID, version, map[string]interface{}{
+			"after_image_url":   afterImageURL,
+			"status":            entity.STATUS_FINISH_BY_WORKER,
+			"assignment_status": entity.ASSIGNMENT_ACCEPTED,
+			"work_stage":        entity.WORK_STAGE_DONE,
+			"work_stage_at":     now,
+			"finished_at":       now,
+		}); err != nil {
+			return err
+		}
+		if err := tx.Create(progress).Error; err != nil {
+			return err
+		}
+		if len(materials) > 0 {
+			if err := tx.Create(&materials).Error; err != nil {
+				return err
+			}
+		}
+		return r.acceptPendingAssignment(tx, progress.ReportID, progress.WorkerID, now)
+	})
+}
+
+func (r *reportRepository) acceptPendingAssignment(tx *gorm.DB, reportID string, workerID uuid.UUID, now time.Time) error {
+	return tx.Model(&entity.ReportAssignment{}).
+		Where("report_id = ? AND worker_id = ? AND ended_at IS NULL AND status = ?", reportID, workerID, entity.ASSIGNMENT_PENDING).
+		Updates(map[string]interface{}{
+			"status":       entity.ASSIGNMENT_ACCEPTED,
+			"responded_at": now,
+		}).Error
 }
 
 func (r *reportRepository) GetReportsByUserID(userID uuid.UUID, limit, offset int) ([]entity.Report, int64, error) {
 	var reports []entity.Report
 	var total int64
 	r.db.Model(&entity.Report{}).Where("user_id = ?", userID).Count(&total)
-	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Limit(limit).Offset(offset).Find(&reports).Error
+	err := r.db.Preload("SLAPolicy").Where("user_id = ?", userID).Order("created_at DESC").Limit(limit).Offset(offset).Find(&reports).Error
 	return reports, total, err
 }
 
 func (r *reportRepository) GetAssignedReportsByWorkerID(workerID uuid.UUID, limit, offset int) ([]entity.Report, int64, error) {
 	var reports []entity.Report
 	var total int64
-	r.db.Model(&entity.Report{}).Where("worker_id = ? AND status = ?", workerID, entity.STATUS_ASSIGNED).Count(&total)
-	err := r.db.Where("worker_id = ? AND status = ?", workerID, entity.STATUS_ASSIGNED).Order("created_at DESC").Limit(limit).Offset(offset).Find(&reports).Error
+	r.db.Model(&entity.Report{}).Where("(worker_id = ? OR id IN (?)) AND status = ?", workerID, r.crewReportIDs(workerID), entity.STATUS_ASSIGNED).Count(&total)
+	err := r.db.Preload("SLAPolicy").Where("(worker_id = ? OR id IN (?)) AND status = ?", workerID, r.crewReportIDs(workerID), entity.STATUS_ASSIGNED).Order("created_at DESC").Limit(limit).Offset(offset).Find(&reports).Error
 	return reports, total, err
 }
 
+// CountOpenJobsByWorker counts the assigned reports each of the workers is
+// the worker or on the crew of, as GetAssignedReportsByWorkerID does.
+func (r *reportRepository) CountOpenJobsByWorker(workerIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64, len(workerIDs))
+	if len(workerIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		WorkerID uuid.UUID
+		Count    int64
+	}
+	err := r.byWorker().
+		Select("report_workers.worker_id, COUNT(*) AS count").
+		Where("reports.status = ? AND report_workers.worker_id IN ?", entity.STATUS_ASSIGNED, workerIDs).
+		Group("report_workers.worker_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.WorkerID] = row.Count
+	}
+	return counts, nil
+}
+
 func (r *reportRepository) GetWorkerHistory(workerID uuid.UUID, status string, limit, offset int) ([]entity.Report, int64, error) {
 	var reports []entity.Report
 	var total int64
-	r.db.Model(&entity.Report{}).Where("worker_id = ? AND status = ?", workerID, status).Count(&total)
-	err := r.db.Where("worker_id = ? AND status = ?", workerID, status).Order("created_at DESC").Limit(limit).Offset(offset).Find(&reports).Error
+	r.db.Model(&entity.Report{}).Where("(worker_id = ? OR id IN (?)) AND status = ?", workerID, r.crewReportIDs(workerID), status).Count(&total)
+	err := r.db.Preload("SLAPolicy").Where("(worker_id = ? OR id IN (?)) AND status = ?", workerID, r.crewReportIDs(workerID), status).Order("created_at DESC").Limit(limit).Offset(offset).Find(&reports).Error
+	return reports, total, err
+}
+
+func (r *reportRepository) GetReportsByStatus(status string, regionCodes []string, limit, offset int) ([]entity.Report, int64, error) {
+	var reports []entity.Report
+	var total int64
+	inRegions(r.db.Model(&entity.Report{}).Where("status = ?", status), regionCodes).Count(&total)
+	err := inRegions(r.db.Preload("SLAPolicy").Where("status = ?", status), regionCodes).Order("created_at ASC").Limit(limit).Offset(offset).Find(&reports).Error
+	return reports, total, err
+}
+
+// UpdateClassification stores the classifier's result. An empty district keeps
+// the one given by the reporter.
+func (r *reportRepository) UpdateClassification(reportID string, version int, destructClass string, locationScore, totalScore float64, district string) error {
+	updates := map[string]interface{}{
+		"destruct_class": destructClass,
+		"location_score": locationScore,
+		"total_score":    totalScore,
+		"status":         entity.STATUS_COMPLETED,
+	}
+	if district != "" {
+		updates["district"] = district
+	}
+	return r.updateVersioned(r.db, reportID, version, updates)
+}
+
+func (r *reportRepository) DeleteReport(reportID string, version int) error {
+	result := r.db.Where("id = ? AND version = ?", reportID, version).Delete(&entity.Report{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return http_error.REPORT_VERSION_CONFLICT
+	}
+	return nil
+}
+
+// RejectReport also drops the recurrence link, as a rejected report does not
+// count against the earlier repair.
+func (r *reportRepository) RejectReport(reportID string, version int, reasonCode, note string, rejectedBy *uuid.UUID, rejectedAt time.Time) error {
+	return r.updateVersioned(r.db, reportID, version, map[string]interface{}{
+		"status":               entity.STATUS_REJECTED,
+		"reject_reason":        reasonCode,
+		"reject_note":          note,
+		"rejected_by":          rejectedBy,
+		"rejected_at":          rejectedAt,
+		"recurrence_of":        nil,
+		"recurrence_worker_id": nil,
+	})
+}
+
+// ReworkReport archives the current completion and hands the report back to
+// the worker in a single transaction.
+func (r *reportRepository) ReworkReport(rework *entity.ReportRework, version int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{
+			"status":          entity.STATUS_ASSIGNED,
+			"after_image_url": "",
+			"finished_at":     nil,
+			"rework_count":    gorm.Expr("rework_count + 1"),
+			"work_stage":      entity.WORK_STAGE_IN_PROGRESS,
+			"work_stage_at":   time.Now(),
+		}
+		if rework.NewDeadline != nil {
+			updates["deadline"] = rework.NewDeadline
+			updates["deadline_warned_at"] = nil
+			updates["overdue_at"] = nil
+			updates["escalated_at"] = nil
+			updates["sla_policy_id"] = nil
+		}
+
+		if err := r.updateVersioned(tx, rework.ReportID, version, updates); err != nil {
+			return err
+		}
+		return tx.Create(rework).Error
+	})
+}
+
+func (r *reportRepository) GetReworksByReportID(reportID string) ([]entity.ReportRework, error) {
+	var reworks []entity.ReportRework
+	err := r.db.Where("report_id = ?", reportID).Order("created_at DESC").Find(&reworks).Error
+	return reworks, err
+}
+
+// CompleteReport marks a verified report finished and closes its assignment.
+func (r *reportRepository) CompleteReport(reportID string, version int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.updateVersioned(tx, reportID, version, map[string]interface{}{
+			"status":            entity.STATUS_FINISHED,
+			"assignment_status": entity.ASSIGNMENT_COMPLETED,
+		}); err != nil {
+			return err
+		}
+		return r.endActiveAssignment(tx, reportID, entity.ASSIGNMENT_COMPLETED, "")
+	})
+}
+
+// IsCrewMember reports whether the worker is on the crew of the report's open
+// assignment.
+func (r *reportRepository) IsCrewMember(reportID string, workerID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&entity.ReportCrewMember{}).
+		Joins("JOIN report_assignments ON report_assignments.id = report_crew_members.assignment_id").
+		Where("report_crew_members.report_id = ? AND report_crew_members.worker_id = ? AND report_assignments.ended_at IS NULL", reportID, workerID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *reportRepository) CountOpenReportsByTeam(teamID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Report{}).
+		Where("team_id = ? AND status IN ?", teamID, []string{entity.STATUS_ASSIGNED, entity.STATUS_FINISH_BY_WORKER}).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *reportRepository) CreateProgress(progress *entity.ReportProgress) error {
+	return r.db.Create(progress).Error
+}
+
+func (r *reportRepository) GetProgressByReportID(reportID string) ([]entity.ReportProgress, error) {
+	var progress []entity.ReportProgress
+	err := r.db.Preload("Photos").Where("report_id = ?", reportID).Order("created_at ASC").Find(&progress).Error
+	return progress, err
+}
+
+// SLAStat is the deadline compliance of finished reports sharing a group key.
+type SLAStat struct {
+	GroupKey      string
+	Completed     int64
+	OnTime        int64
+	AvgDelayHours float64
+}
+
+// slaGroupRoad groups reports under their canonical road name, falling back
+// to the name the reporter typed, as the material costs do.
+const (
+	slaGroupWorker = "CAST(report_workers.worker_id AS text)"
+	slaGroupRoad   = "COALESCE(NULLIF(reports.canonical_road_name, ''), reports.road_name)"
+)
+
+// GetOpenReportsWithDeadlineBefore returns assigned reports whose deadline falls
+// before t, oldest deadline first.
+func (r *reportRepository) GetOpenReportsWithDeadlineBefore(t time.Time) ([]entity.Report, error) {
+	var reports []entity.Report
+	err := r.db.Where("status = ? AND deadline IS NOT NULL AND deadline < ?", entity.STATUS_ASSIGNED, t).
+		Order("deadline ASC").
+		Find(&reports).Error
+	return reports, err
+}
+
+func (r *reportRepository) GetOverdueReports(now time.Time, regionCodes []string) ([]entity.Report, error) {
+	var reports []entity.Report
+	err := inRegions(r.db.Where("status = ? AND deadline IS NOT NULL AND deadline < ?", entity.STATUS_ASSIGNED, now), regionCodes).
+		Order("deadline ASC").
+		Find(&reports).Error
+	return reports, err
+}
+
+// The mark methods only set a timestamp that is still empty and report whether
+// they did, so a check running on several instances notifies once.
+func (r *reportRepository) MarkDeadlineWarned(reportID string, at time.Time) (bool, error) {
+	return r.markOnce(reportID, "deadline_warned_at", at)
+}
+
+func (r *reportRepository) MarkOverdue(reportID string, at time.Time) (bool, error) {
+	return r.markOnce(reportID, "overdue_at", at)
+}
+
+func (r *reportRepository) MarkEscalated(reportID string, at time.Time) (bool, error) {
+	return r.markOnce(reportID, "escalated_at", at)
+}
+
+func (r *reportRepository) markOnce(reportID string, column string, at time.Time) (bool, error) {
+	result := r.db.Model(&entity.Report{}).
+		Where("id = ? AND status = ? AND "+column+" IS NULL", reportID, entity.STATUS_ASSIGNED).
+		Update(column, at)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *reportRepository) GetSLAStatsByWorker(from, to *time.Time, regionCodes []string) ([]SLAStat, error) {
+	return r.slaStats(inRegions(r.byWorker(), regionCodes), slaGroupWorker, from, to)
+}
+
+func (r *reportRepository) GetSLAStatsByRoad(from, to *time.Time, regionCodes []string) ([]SLAStat, error) {
+	return r.slaStats(inRegions(r.db.Model(&entity.Report{}), regionCodes), slaGroupRoad, from, to)
+}
+
+func (r *reportRepository) slaStats(query *gorm.DB, groupBy string, from, to *time.Time) ([]SLAStat, error) {
+	var stats []SLAStat
+	query = query.
+		Select(groupBy + " AS group_key, COUNT(*) AS completed, " +
+			"SUM(CASE WHEN reports.finished_at <= reports.deadline THEN 1 ELSE 0 END) AS on_time, " +
+			"COALESCE(AVG(CASE WHEN reports.finished_at > reports.deadline THEN EXTRACT(EPOCH FROM reports.finished_at - reports.deadline) / 3600 END), 0) AS avg_delay_hours").
+		Where("reports.worker_id IS NOT NULL AND reports.deadline IS NOT NULL AND reports.finished_at IS NOT NULL")
+	if from != nil {
+		query = query.Where("reports.finished_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("reports.finished_at <= ?", *to)
+	}
+	err := query.Group(groupBy).Order("group_key").Scan(&stats).Error
+	return stats, err
+}
+
+func (r *reportRepository) CountOverdueByWorker(now time.Time, regionCodes []string) (map[string]int64, error) {
+	return r.countOverdue(inRegions(r.byWorker(), regionCodes), slaGroupWorker, now)
+}
+
+func (r *reportRepository) CountOverdueByRoad(now time.Time, regionCodes []string) (map[string]int64, error) {
+	return r.countOverdue(inRegions(r.db.Model(&entity.Report{}), regionCodes), slaGroupRoad, now)
+}
+
+func (r *reportRepository) countOverdue(query *gorm.DB, groupBy string, now time.Time) (map[string]int64, error) {
+	var rows []struct {
+		GroupKey string
+		Count    int64
+	}
+	err := query.
+		Select(groupBy+" AS group_key, COUNT(*) AS count").
+		Where("reports.status = ? AND reports.deadline IS NOT NULL AND reports.deadline < ?", entity.STATUS_ASSIGNED, now).
+		Group(groupBy).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.GroupKey] = row.Count
+	}
+	return counts, nil
+}
+
+// byWorker counts a report once for its assigned worker and once for every
+// other member of the crew that worked it.
+func (r *reportRepository) byWorker() *gorm.DB {
+	reportWorkers := r.db.Raw(`SELECT id AS report_id, worker_id FROM reports WHERE worker_id IS NOT NULL
+		UNION
+		SELECT c.report_id, c.worker_id FROM report_crew_members c
+		JOIN report_assignments a ON a.id = c.assignment_id
+		WHERE a.ended_at IS NULL OR a.status = ?`, entity.ASSIGNMENT_COMPLETED)
+	return r.db.Model(&entity.Report{}).Joins("JOIN (?) AS report_workers ON report_workers.report_id = reports.id", reportWorkers)
+}
+
+// crewReportIDs selects the reports the worker is on the crew of, for
+// assignments that are still open or were completed.
+func (r *reportRepository) crewReportIDs(workerID uuid.UUID) *gorm.DB {
+	return r.db.Model(&entity.ReportCrewMember{}).
+		Select("report_crew_members.report_id").
+		Joins("JOIN report_assignments ON report_assignments.id = report_crew_members.assignment_id").
+		Where("report_crew_members.worker_id = ? AND (report_assignments.ended_at IS NULL OR report_assignments.status = ?)", workerID, entity.ASSIGNMENT_COMPLETED)
+}
+
+// GetReportsWithoutRegions returns reports not yet placed in their regions or
+// placed with boundaries older than the version.
+func (r *reportRepository) GetReportsWithoutRegions(version int64, limit int) ([]entity.Report, error) {
+	var reports []entity.Report
+	err := r.db.Where("regions_assigned_at IS NULL OR regions_version < ?", version).Limit(limit).Find(&reports).Error
+	return reports, err
+}
+
+// SetRegions stores the regions found for the report without bumping the
+// version, as they are derived from its coordinates.
+func (r *reportRepository) SetRegions(report *entity.Report) error {
+	return r.db.Model(&entity.Report{}).Where("id = ?", report.ID).UpdateColumns(map[string]interface{}{
+		"province_code":       report.ProvinceCode,
+		"regency_code":        report.RegencyCode,
+		"district_code":       report.DistrictCode,
+		"village_code":        report.VillageCode,
+		"district":            report.District,
+		"regions_assigned_at": report.RegionsAssignedAt,
+		"regions_version":     report.RegionsVersion,
+	}).Error
+}
+
+// GetReportsWithoutRoad returns reports not yet snapped or snapped to a road
+// network older than the version.
+func (r *reportRepository) GetReportsWithoutRoad(version int64, limit int) ([]entity.Report, error) {
+	var reports []entity.Report
+	err := r.db.Where("road_snapped_at IS NULL OR roads_version < ?", version).Limit(limit).Find(&reports).Error
+	return reports, err
+}
+
+// SetRoad stores the road segment found for the report without bumping the
+// version, as it is derived from its coordinates.
+func (r *reportRepository) SetRoad(report *entity.Report) error {
+	return r.db.Model(&entity.Report{}).Where("id = ?", report.ID).UpdateColumns(map[string]interface{}{
+		"road_segment_id": report.RoadSegmentID,
+		"road_authority":  report.RoadAuthority,
+		"road_name":       report.RoadName,
+		"road_snapped_at": report.RoadSnappedAt,
+		"roads_version":   report.RoadsVersion,
+		"road_key":        report.RoadKey,
+	}).Error
+}
+
+// GetReportsWithoutRoadKey returns reports filed before road keys were
+// stored.
+func (r *reportRepository) GetReportsWithoutRoadKey(limit int) ([]entity.Report, error) {
+	var reports []entity.Report
+	err := r.db.Where("road_key IS NULL").Limit(limit).Find(&reports).Error
+	return reports, err
+}
+
+func (r *reportRepository) SetRoadKey(reportID string, roadKey string) error {
+	return r.db.Model(&entity.Report{}).Where("id = ?", reportID).UpdateColumn("road_key", roadKey).Error
+}
+
+// openAuthorityReports selects reports still waiting for action by an
+// agency: not finished, rejected or forwarded.
+func (r *reportRepository) openAuthorityReports(regionCodes []string) *gorm.DB {
+	return inRegions(r.db.Model(&entity.Report{}).
+		Where("reports.status NOT IN ? AND reports.forwarded_at IS NULL", []string{entity.STATUS_FINISHED, entity.STATUS_REJECTED}), regionCodes)
+}
+
+// GetOpenReportsByAuthority lists the open reports on roads of the authority,
+// most severe first. The unknown authority lists reports not on any road.
+func (r *reportRepository) GetOpenReportsByAuthority(authority string, regionCodes []string, limit, offset int) ([]entity.Report, int64, error) {
+	condition, args := "reports.road_authority = ?", []interface{}{authority}
+	if authority == entity.ROAD_AUTHORITY_UNKNOWN {
+		condition, args = "reports.road_authority IS NULL OR reports.road_authority = ''", nil
+	}
+
+	var reports []entity.Report
+	var total int64
+	r.openAuthorityReports(regionCodes).Where(condition, args...).Count(&total)
+	err := r.openAuthorityReports(regionCodes).Preload("SLAPolicy").Where(condition, args...).
+		Order("total_score DESC, created_at ASC").Limit(limit).Offset(offset).Find(&reports).Error
 	return reports, total, err
 }
 
-func (r *reportRepository) UpdateStatus(reportID string, status string) error {
-	return r.db.Model(&entity.Report{}).Where("id = ?", reportID).Update("status", status).Error
+func (r *reportRepository) CountOpenReportsByAuthority(regionCodes []string) (map[string]int64, error) {
+	var rows []struct {
+		Authority string
+		Count     int64
+	}
+	err := r.openAuthorityReports(regionCodes).
+		Select("COALESCE(reports.road_authority, '') AS authority, COUNT(*) AS count").
+		Group("authority").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		if row.Authority == "" {
+			row.Authority = entity.ROAD_AUTHORITY_UNKNOWN
+		}
+		counts[row.Authority] += row.Count
+	}
+	return counts, nil
+}
+
+func (r *reportRepository) ForwardReport(reportID string, version int, authority, note string, forwardedBy *uuid.UUID, forwardedAt time.Time) error {
+	return r.updateVersioned(r.db, reportID, version, map[string]interface{}{
+		"forwarded_to": authority,
+		"forward_note": note,
+		"forwarded_by": forwardedBy,
+		"forwarded_at": forwardedAt,
+	})
+}
+
+// RoadConditionQuery pages through the roads of the condition index, or
+// picks out the road with RoadKey. Each open damage report multiplies the
+// index by OpenFactor and each recurrence filed since RecurrenceSince by
+// RecurrenceFactor.
+type RoadConditionQuery struct {
+	RoadKey          string
+	RegionCodes      []string
+	RecurrenceSince  time.Time
+	OpenFactor       float64
+	RecurrenceFactor float64
+	Limit, Offset    int
+}
+
+// RoadConditionStat sums up the reports on one road. Damage reports are
+// closed once verified as finished.
+type RoadConditionStat struct {
+	RoadKey           string
+	SegmentID         string
+	Authority         string
+	Name              string
+	Reports           int
+	OpenReports       int
+	ClosedReports     int
+	RepairHours       float64
+	Recurrences       int
+	RecentRecurrences int
+	LastReportAt      time.Time
+	ConditionIndex    float64
+}
+
+// RoadConditionHistoryQuery measures one road as it stood at each of the
+// points, counting the recurrences filed in the RecurrenceMemory before each.
+type RoadConditionHistoryQuery struct {
+	RoadKey          string
+	RegionCodes      []string
+	Points           []time.Time
+	RecurrenceMemory time.Duration
+	OpenFactor       float64
+	RecurrenceFactor float64
+}
+
+type RoadConditionPoint struct {
+	At                time.Time
+	OpenReports       int
+	RecentRecurrences int
+	ConditionIndex    float64
+}
+
+type RoadClassCount struct {
+	RoadKey string
+	Class   string
+	Total   int
+}
+
+const (
+	roadDamageCondition = "COALESCE(reports.destruct_class, '') <> '" + entity.DESTRUCT_CLASS_GOOD + "'"
+	roadClosedCondition = roadDamageCondition + " AND reports.status = '" + entity.STATUS_FINISHED + "' AND reports.finished_at IS NOT NULL"
+
+	// roadConditionIndex computes the index from the open_reports and
+	// recent_recurrences columns, binding the open and recurrence factors.
+	roadConditionIndex = "ROUND(CAST(100 * POWER(?, open_reports) * POWER(?, recent_recurrences) AS numeric), 1)"
+)
+
+// roadConditionReports selects the reports the condition index counts: those
+// on a known road that were not rejected.
+func (r *reportRepository) roadConditionReports(regionCodes []string) *gorm.DB {
+	return inRegions(r.db.Model(&entity.Report{}), regionCodes).
+		Where("reports.status <> ? AND reports.road_key IS NOT NULL AND reports.road_key <> ''", entity.STATUS_REJECTED)
+}
+
+// GetRoadConditionStats aggregates the reports per road, worst condition
+// first, along with the number of roads.
+func (r *reportRepository) GetRoadConditionStats(query RoadConditionQuery) ([]RoadConditionStat, int64, error) {
+	roads := r.roadConditionReports(query.RegionCodes).
+		Select("reports.road_key, "+
+			"MAX(reports.road_segment_id) AS segment_id, "+
+			"MAX(NULLIF(reports.road_authority, '')) AS authority, "+
+			"MODE() WITHIN GROUP (ORDER BY NULLIF(COALESCE(NULLIF(reports.canonical_road_name, ''), TRIM(reports.road_name)), '')) AS name, "+
+			"COUNT(*) AS reports, "+
+			"COUNT(CASE WHEN "+roadDamageCondition+" AND NOT ("+roadClosedCondition+") THEN 1 END) AS open_reports, "+
+			"COUNT(CASE WHEN "+roadClosedCondition+" THEN 1 END) AS closed_reports, "+
+			"COALESCE(SUM(CASE WHEN "+roadClosedCondition+" THEN EXTRACT(EPOCH FROM reports.finished_at - reports.created_at) / 3600 END), 0) AS repair_hours, "+
+			"COUNT(CASE WHEN "+roadDamageCondition+" AND reports.recurrence_of IS NOT NULL THEN 1 END) AS recurrences, "+
+			"COUNT(CASE WHEN "+roadDamageCondition+" AND reports.recurrence_of IS NOT NULL AND reports.created_at >= ? THEN 1 END) AS recent_recurrences, "+
+			"MAX(reports.created_at) AS last_report_at", query.RecurrenceSince).
+		Group("reports.road_key")
+	if query.RoadKey != "" {
+		roads = roads.Where("reports.road_key = ?", query.RoadKey)
+	}
+
+	var total int64
+	if err := r.db.Table("(?) AS roads", roads).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var stats []RoadConditionStat
+	err := r.db.Table("(?) AS roads", roads).
+		Select("roads.*, "+roadConditionIndex+" AS condition_index", query.OpenFactor, query.RecurrenceFactor).
+		Order("condition_index ASC, roads.open_reports DESC, roads.road_key ASC").
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Scan(&stats).Error
+	return stats, total, err
+}
+
+// GetRoadClassCounts counts the reports per destruct class on each road,
+// unclassified ones under an empty class.
+func (r *reportRepository) GetRoadClassCounts(roadKeys []string, regionCodes []string) ([]RoadClassCount, error) {
+	var counts []RoadClassCount
+	if len(roadKeys) == 0 {
+		return counts, nil
+	}
+	err := r.roadConditionReports(regionCodes).
+		Select("reports.road_key, COALESCE(reports.destruct_class, '') AS class, COUNT(*) AS total").
+		Where("reports.road_key IN ?", roadKeys).
+		Group("reports.road_key, COALESCE(reports.destruct_class, '')").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// GetRoadConditionHistory counts the open damage reports and recent
+// recurrences on the road at each point, oldest first, and computes the index
+// the same way GetRoadConditionStats does.
+func (r *reportRepository) GetRoadConditionHistory(query RoadConditionHistoryQuery) ([]RoadConditionPoint, error) {
+	var points []RoadConditionPoint
+	if len(query.Points) == 0 {
+		return points, nil
+	}
+
+	values := make([]string, len(query.Points))
+	args := make([]interface{}, len(query.Points))
+	for i, point := range query.Points {
+		values[i] = "(CAST(? AS timestamptz))"
+		args[i] = point
+	}
+
+	history := r.roadConditionReports(query.RegionCodes).
+		Joins("CROSS JOIN (VALUES "+strings.Join(values, ", ")+") AS points(at)", args...).
+		Select("points.at, "+
+			"COUNT(CASE WHEN "+roadDamageCondition+" AND reports.created_at <= points.at AND NOT ("+roadClosedCondition+" AND reports.finished_at <= points.at) THEN 1 END) AS open_reports, "+
+			"COUNT(CASE WHEN "+roadDamageCondition+" AND reports.recurrence_of IS NOT NULL AND reports.created_at <= points.at AND reports.created_at >= points.at - ? * INTERVAL '1 second' THEN 1 END) AS recent_recurrences",
+			query.RecurrenceMemory.Seconds()).
+		Where("reports.road_key = ?", query.RoadKey).
+		Group("points.at")
+
+	err := r.db.Table("(?) AS history", history).
+		Select("history.*, "+roadConditionIndex+" AS condition_index", query.OpenFactor, query.RecurrenceFactor).
+		Order("history.at ASC").
+		Scan(&points).Error
+	return points, err
+}
+
+// GetRepairsInBox returns reports with a worker that were finished since the
+// given time inside the bounding box, most recent repair first.
+func (r *reportRepository) GetRepairsInBox(minLat, minLng, maxLat, maxLng float64, since time.Time) ([]entity.Report, error) {
+	var reports []entity.Report
+	err := r.db.Where("worker_id IS NOT NULL AND finished_at IS NOT NULL AND finished_at >= ?", since).
+		Where("latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?", minLat, maxLat, minLng, maxLng).
+		Order("finished_at DESC").
+		Find(&reports).Error
+	return reports, err
+}
+
+// RecurrenceStat counts the repairs finished by a group and how many of them
+// had the damage come back.
+type RecurrenceStat struct {
+	GroupKey string
+	Repairs  int64
+	Recurred int64
+}
+
+const recurrenceGroupContractor = "COALESCE(worker_profiles.contractor, '')"
+
+func (r *reportRepository) GetRecurrenceStatsByWorker(from, to *time.Time, regionCodes []string) ([]RecurrenceStat, error) {
+	return r.recurrenceStats(inRegions(r.byWorker(), regionCodes), slaGroupWorker, from, to)
+}
+
+// GetRecurrenceStatsByContractor groups repairs by the contractor of everyone
+// who worked them, counting a repair once per contractor. Repairs by workers
+// without a contractor share the empty key.
+func (r *reportRepository) GetRecurrenceStatsByContractor(from, to *time.Time, regionCodes []string) ([]RecurrenceStat, error) {
+	query := r.byWorker().Joins("LEFT JOIN worker_profiles ON worker_profiles.worker_id = report_workers.worker_id")
+	return r.recurrenceStats(inRegions(query, regionCodes), recurrenceGroupContractor, from, to)
+}
+
+func (r *reportRepository) recurrenceStats(query *gorm.DB, groupBy string, from, to *time.Time) ([]RecurrenceStat, error) {
+	var stats []RecurrenceStat
+	query = query.
+		Select(groupBy+" AS group_key, COUNT(DISTINCT reports.id) AS repairs, "+
+			"COUNT(DISTINCT CASE WHEN EXISTS (SELECT 1 FROM reports recurrences WHERE recurrences.recurrence_of = reports.id AND recurrences.status <> ? AND recurrences.deleted_at IS NULL) THEN reports.id END) AS recurred", entity.STATUS_REJECTED).
+		Where("reports.worker_id IS NOT NULL AND reports.finished_at IS NOT NULL")
+	if from != nil {
+		query = query.Where("reports.finished_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("reports.finished_at <= ?", *to)
+	}
+	err := query.Group(groupBy).Order("group_key").Scan(&stats).Error
+	return stats, err
+}
+
+// GetDamageClasses lists the destruct classes reports were given, other than
+// good.
+func (r *reportRepository) GetDamageClasses() ([]string, error) {
+	var classes []string
+	err := r.db.Model(&entity.Report{}).
+		Distinct("destruct_class").
+		Where("destruct_class != '' AND destruct_class != ?", entity.DESTRUCT_CLASS_GOOD).
+		Order("destruct_class").
+		Pluck("destruct_class", &classes).Error
+	return classes, err
+}
+
+// ServiceRequestFilter narrows the reports listed as Open311 service
+// requests. With IDs the other fields are ignored.
+type ServiceRequestFilter struct {
+	IDs         []string
+	ServiceCode string
+	Open        *bool
+	From, To    *time.Time
+}
+
+// openRequestCondition matches reports still being handled by this agency.
+const openRequestCondition = "reports.status IN (?) AND reports.forwarded_at IS NULL"
+
+// GetServiceRequests returns the reports matching the filter, newest first.
+// The service code is the destruct class once a report was classified as
+// damage, and until then the class the client asked for.
+func (r *reportRepository) GetServiceRequests(filter ServiceRequestFilter, limit int) ([]entity.Report, error) {
+	var reports []entity.Report
+	query := r.db.Model(&entity.Report{})
+	if len(filter.IDs) > 0 {
+		query = query.Where("reports.id IN ?", filter.IDs)
+	} else {
+		unclassified := "reports.destruct_class IN ('', '" + entity.DESTRUCT_CLASS_GOOD + "')"
+		switch filter.ServiceCode {
+		case "":
+		case entity.OPEN311_SERVICE_ROAD_DAMAGE:
+			query = query.Where(unclassified + " AND reports.requested_class = ''")
+		default:
+			query = query.Where("reports.destruct_class = ? OR ("+unclassified+" AND reports.requested_class = ?)", filter.ServiceCode, filter.ServiceCode)
+		}
+
+		openStatuses := []string{entity.STATUS_PENDING, entity.STATUS_COMPLETED, entity.STATUS_ASSIGNED, entity.STATUS_FINISH_BY_WORKER}
+		if filter.Open != nil && *filter.Open {
+			query = query.Where(openRequestCondition, openStatuses)
+		} else if filter.Open != nil {
+			query = query.Where("NOT ("+openRequestCondition+")", openStatuses)
+		}
+		if filter.From != nil {
+			query = query.Where("reports.created_at >= ?", *filter.From)
+		}
+		if filter.To != nil {
+			query = query.Where("reports.created_at <= ?", *filter.To)
+		}
+	}
+	err := query.Order("reports.created_at DESC").Limit(limit).Find(&reports).Error
+	return reports, err
+}
+
+// GetReportByToken finds the report whose ID starts with the token.
+func (r *reportRepository) GetReportByToken(token string) (*entity.Report, error) {
+	var report entity.Report
+	err := r.db.Where("id LIKE ?", token+"\\_%").First(&report).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// inRegions limits a query on reports to those inside any of the regions, at
+// whatever level. Without regions the query is left as is.
+func inRegions(query *gorm.DB, regionCodes []string) *gorm.DB {
+	if len(regionCodes) == 0 {
+		return query
+	}
+	return query.Where("reports.province_code IN ? OR reports.regency_code IN ? OR reports.district_code IN ? OR reports.village_code IN ?",
+		regionCodes, regionCodes, regionCodes, regionCodes)
 }
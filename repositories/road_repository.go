@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	entity "dinacom-11.0-backend/models/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RoadRepository interface {
+	UpsertSegments(segments []entity.RoadSegment) error
+	GetSegmentsWithGeometry() ([]entity.RoadSegment, error)
+}
+
+type roadRepository struct {
+	db *gorm.DB
+}
+
+func NewRoadRepository(db *gorm.DB) RoadRepository {
+	return &roadRepository{db: db}
+}
+
+// UpsertSegments inserts the segments or, for segment IDs already known,
+// replaces their name, authority and geometry.
+func (r *roadRepository) UpsertSegments(segments []entity.RoadSegment) error {
+	if len(segments) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "segment_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "authority", "geometry", "updated_at"}),
+	}).CreateInBatches(segments, 100).Error
+}
+
+func (r *roadRepository) GetSegmentsWithGeometry() ([]entity.RoadSegment, error) {
+	var segments []entity.RoadSegment
+	err := r.db.Order("segment_id ASC").Find(&segments).Error
+	return segments, err
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	entity "dinacom-11.0-backend/models/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ServiceAreaRepository interface {
+	CreateServiceArea(area *entity.ServiceArea) error
+	GetServiceAreaByID(id uuid.UUID) (*entity.ServiceArea, error)
+	GetServiceAreaByName(name string) (*entity.ServiceArea, error)
+	GetServiceAreas(activeOnly bool) ([]entity.ServiceArea, error)
+	UpdateServiceArea(area *entity.ServiceArea) error
+	DeleteServiceArea(id uuid.UUID) (int64, error)
+}
+
+type serviceAreaRepository struct {
+	db *gorm.DB
+}
+
+func NewServiceAreaRepository(db *gorm.DB) ServiceAreaRepository {
+	return &serviceAreaRepository{db: db}
+}
+
+func (r *serviceAreaRepository) CreateServiceArea(area *entity.ServiceArea) error {
+	return r.db.Create(area).Error
+}
+
+func (r *serviceAreaRepository) GetServiceAreaByID(id uuid.UUID) (*entity.ServiceArea, error) {
+	var area entity.ServiceArea
+	if err := r.db.Where("id = ?", id).First(&area).Error; err != nil {
+		return nil, err
+	}
+	return &area, nil
+}
+
+func (r *serviceAreaRepository) GetServiceAreaByName(name string) (*entity.ServiceArea, error) {
+	var area entity.ServiceArea
+	if err := r.db.Where("LOWER(name) = LOWER(?)", name).First(&area).Error; err != nil {
+		return nil, err
+	}
+	return &area, nil
+}
+
+func (r *serviceAreaRepository) GetServiceAreas(activeOnly bool) ([]entity.ServiceArea, error) {
+	var areas []entity.ServiceArea
+	query := r.db.Order("name ASC")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Find(&areas).Error
+	return areas, err
+}
+
+func (r *serviceAreaRepository) UpdateServiceArea(area *entity.ServiceArea) error {
+	return r.db.Model(area).Select("name", "min_lat", "min_lng", "max_lat", "max_lng", "geometry", "active").Updates(area).Error
+}
+
+func (r *serviceAreaRepository) DeleteServiceArea(id uuid.UUID) (int64, error) {
+	result := r.db.Where("id = ?", id).Delete(&entity.ServiceArea{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	entity "dinacom-11.0-backend/models/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SLAPolicyRepository interface {
+	CreatePolicy(policy *entity.SLAPolicy) error
+	GetPolicyByID(id uuid.UUID) (*entity.SLAPolicy, error)
+	GetPolicies(activeOnly bool) ([]entity.SLAPolicy, error)
+	UpdatePolicy(policy *entity.SLAPolicy) error
+	DeletePolicy(id uuid.UUID) (int64, error)
+}
+
+type slaPolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewSLAPolicyRepository(db *gorm.DB) SLAPolicyRepository {
+	return &slaPolicyRepository{db: db}
+}
+
+func (r *slaPolicyRepository) CreatePolicy(policy *entity.SLAPolicy) error {
+	return r.db.Create(policy).Error
+}
+
+func (r *slaPolicyRepository) GetPolicyByID(id uuid.UUID) (*entity.SLAPolicy, error) {
+	var policy entity.SLAPolicy
+	if err := r.db.Where("id = ?", id).First(&policy).Error; err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *slaPolicyRepository) GetPolicies(activeOnly bool) ([]entity.SLAPolicy, error) {
+	var policies []entity.SLAPolicy
+	query := r.db.Order("destruct_class ASC, min_total_score DESC")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Find(&policies).Error
+	return policies, err
+}
+
+func (r *slaPolicyRepository) UpdatePolicy(policy *entity.SLAPolicy) error {
+	return r.db.Model(policy).Select("name", "destruct_class", "min_total_score", "resolution_hours", "active").Updates(policy).Error
+}
+
+func (r *slaPolicyRepository) DeletePolicy(id uuid.UUID) (int64, error) {
+	result := r.db.Where("id = ?", id).Delete(&entity.SLAPolicy{})
+	return result.RowsAffected, result.Error
+}
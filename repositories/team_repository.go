@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	entity "dinacom-11.0-backend/models/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TeamRepository interface {
+	CreateTeam(team *entity.Team) error
+	GetTeamByID(id uuid.UUID) (*entity.Team, error)
+	GetTeamByName(name string) (*entity.Team, error)
+	GetTeamByWorkerID(workerID uuid.UUID) (*entity.Team, error)
+	GetTeams() ([]entity.Team, error)
+	UpdateTeam(team *entity.Team) error
+	DeleteTeam(id uuid.UUID) error
+}
+
+type teamRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamRepository(db *gorm.DB) TeamRepository {
+	return &teamRepository{db: db}
+}
+
+func (r *teamRepository) CreateTeam(team *entity.Team) error {
+	return r.db.Create(team).Error
+}
+
+func (r *teamRepository) GetTeamByID(id uuid.UUID) (*entity.Team, error) {
+	var team entity.Team
+	if err := r.db.Preload("Members").Where("id = ?", id).First(&team).Error; err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (r *teamRepository) GetTeamByName(name string) (*entity.Team, error) {
+	var team entity.Team
+	if err := r.db.Where("name = ?", name).First(&team).Error; err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (r *teamRepository) GetTeamByWorkerID(workerID uuid.UUID) (*entity.Team, error) {
+	var team entity.Team
+	err := r.db.Preload("Members").
+		Where("id = (?)", r.db.Model(&entity.TeamMember{}).Select("team_id").Where("worker_id = ?", workerID)).
+		First(&team).Error
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (r *teamRepository) GetTeams() ([]entity.Team, error) {
+	var teams []entity.Team
+	err := r.db.Preload("Members").Order("name ASC").Find(&teams).Error
+	return teams, err
+}
+
+// UpdateTeam saves the name and leader and replaces the member list.
+func (r *teamRepository) UpdateTeam(team *entity.Team) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(team).Select("name", "leader_id").Updates(team).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("team_id = ?", team.ID).Delete(&entity.TeamMember{}).Error; err != nil {
+			return err
+		}
+		for i := range team.Members {
+			team.Members[i].TeamID = team.ID
+		}
+		return tx.Create(&team.Members).Error
+	})
+}
+
+func (r *teamRepository) DeleteTeam(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("team_id = ?", id).Delete(&entity.TeamMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&entity.Team{}).Error
+	})
+}
@@ -13,9 +13,14 @@ type UserRepository interface {
 	CreateUser(user *entity.User) error
 	FindUserByEmail(email string) (*entity.User, error)
 	FindUserByID(id uuid.UUID) (*entity.User, error)
+	FindUsersByIDs(ids []uuid.UUID) ([]entity.User, error)
 	UpdateUserVerified(email string, verified bool) error
 	GetAllUsers() ([]entity.User, error)
 	GetUsersByRole(role string) ([]entity.User, error)
+	GetReportersInRegions(regionCodes []string) ([]entity.User, error)
+	UpdateUserRole(id uuid.UUID, role string) error
+	CountReportsByStatusPerUser(status string, regionCodes []string) (map[uuid.UUID]int64, error)
+	CountReworksPerWorker() (map[uuid.UUID]int64, error)
 }
 
 type userRepository struct {
@@ -54,6 +59,15 @@ func (r *userRepository) FindUserByID(id uuid.UUID) (*entity.User, error) {
 	return &user, nil
 }
 
+func (r *userRepository) FindUsersByIDs(ids []uuid.UUID) ([]entity.User, error) {
+	var users []entity.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
 func (r *userRepository) UpdateUserVerified(email string, verified bool) error {
 	return r.db.Model(&entity.User{}).Where("email = ?", email).Update("verified", verified).Error
 }
@@ -69,3 +83,63 @@ func (r *userRepository) GetUsersByRole(role string) ([]entity.User, error) {
 	err := r.db.Where("role = ?", role).Find(&users).Error
 	return users, err
 }
+
+// GetReportersInRegions returns the citizens who filed a report inside the
+// regions, or all of them when no region is given.
+func (r *userRepository) GetReportersInRegions(regionCodes []string) ([]entity.User, error) {
+	var users []entity.User
+	query := r.db.Where("role = ?", entity.ROLE_USER)
+	if len(regionCodes) > 0 {
+		query = query.Where("id IN (?)", inRegions(r.db.Model(&entity.Report{}).Select("user_id"), regionCodes))
+	}
+	err := query.Find(&users).Error
+	return users, err
+}
+
+func (r *userRepository) UpdateUserRole(id uuid.UUID, role string) error {
+	return r.db.Model(&entity.User{}).Where("id = ?", id).Update("role", role).Error
+}
+
+func (r *userRepository) CountReportsByStatusPerUser(status string, regionCodes []string) (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		UserID uuid.UUID
+		Total  int64
+	}
+	query := inRegions(r.db.Model(&entity.Report{}).Where("status = ?", status), regionCodes)
+	err := query.Select("user_id, COUNT(*) AS total").Group("user_id").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		counts[row.UserID] = row.Total
+	}
+	return counts, nil
+}
+
+// CountReworksPerWorker counts reworks against the worker who finished the
+// report and every member of the crew assigned at the time.
+func (r *userRepository) CountReworksPerWorker() (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		WorkerID uuid.UUID
+		Total    int64
+	}
+	err := r.db.Raw(`SELECT worker_id, COUNT(*) AS total FROM (
+			SELECT id, worker_id FROM report_reworks
+			UNION
+			SELECT rw.id, c.worker_id FROM report_reworks rw
+			JOIN report_crew_members c ON c.report_id = rw.report_id
+			JOIN report_assignments a ON a.id = c.assignment_id
+			WHERE a.created_at <= rw.created_at AND (a.ended_at IS NULL OR a.ended_at >= rw.created_at)
+		) rework_workers GROUP BY worker_id`).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		counts[row.WorkerID] = row.Total
+	}
+	return counts, nil
+}
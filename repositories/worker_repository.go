@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"time"
+
+	entity "dinacom-11.0-backend/models/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WorkerRepository interface {
+	GetWorkerProfile(workerID uuid.UUID) (*entity.WorkerProfile, error)
+	GetWorkerProfiles() ([]entity.WorkerProfile, error)
+	SaveWorkerProfile(profile *entity.WorkerProfile) error
+	GetShiftsByWorkerID(workerID uuid.UUID) ([]entity.WorkerShift, error)
+	GetAllShifts() ([]entity.WorkerShift, error)
+	ReplaceShifts(workerID uuid.UUID, shifts []entity.WorkerShift) error
+	CreateLeave(leave *entity.WorkerLeave) error
+	GetLeaveByID(id uuid.UUID) (*entity.WorkerLeave, error)
+	GetLeaves(workerID *uuid.UUID, from, to time.Time) ([]entity.WorkerLeave, error)
+	DeleteLeave(id uuid.UUID) error
+}
+
+type workerRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkerRepository(db *gorm.DB) WorkerRepository {
+	return &workerRepository{db: db}
+}
+
+func (r *workerRepository) GetWorkerProfile(workerID uuid.UUID) (*entity.WorkerProfile, error) {
+	var profile entity.WorkerProfile
+	err := r.db.Where("worker_id = ?", workerID).First(&profile).Error
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *workerRepository) GetWorkerProfiles() ([]entity.WorkerProfile, error) {
+	var profiles []entity.WorkerProfile
+	err := r.db.Find(&profiles).Error
+	return profiles, err
+}
+
+func (r *workerRepository) SaveWorkerProfile(profile *entity.WorkerProfile) error {
+	return r.db.Save(profile).Error
+}
+
+func (r *workerRepository) GetShiftsByWorkerID(workerID uuid.UUID) ([]entity.WorkerShift, error) {
+	var shifts []entity.WorkerShift
+	err := r.db.Where("worker_id = ?", workerID).Order("weekday ASC, start_time ASC").Find(&shifts).Error
+	return shifts, err
+}
+
+func (r *workerRepository) GetAllShifts() ([]entity.WorkerShift, error) {
+	var shifts []entity.WorkerShift
+	err := r.db.Order("weekday ASC, start_time ASC").Find(&shifts).Error
+	return shifts, err
+}
+
+func (r *workerRepository) ReplaceShifts(workerID uuid.UUID, shifts []entity.WorkerShift) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("worker_id = ?", workerID).Delete(&entity.WorkerShift{}).Error; err != nil {
+			return err
+		}
+		if len(shifts) == 0 {
+			return nil
+		}
+		return tx.Create(&shifts).Error
+	})
+}
+
+func (r *workerRepository) CreateLeave(leave *entity.WorkerLeave) error {
+	return r.db.Create(leave).Error
+}
+
+func (r *workerRepository) GetLeaveByID(id uuid.UUID) (*entity.WorkerLeave, error) {
+	var leave entity.WorkerLeave
+	err := r.db.Where("id = ?", id).First(&leave).Error
+	if err != nil {
+		return nil, err
+	}
+	return &leave, nil
+}
+
+// GetLeaves returns leaves overlapping the days from..to, optionally for one worker.
+func (r *workerRepository) GetLeaves(workerID *uuid.UUID, from, to time.Time) ([]entity.WorkerLeave, error) {
+	var leaves []entity.WorkerLeave
+	query := r.db.Where("start_date <= ? AND end_date >= ?", to, from)
+	if workerID != nil {
+		query = query.Where("worker_id = ?", *workerID)
+	}
+	err := query.Order("start_date ASC").Find(&leaves).Error
+	return leaves, err
+}
+
+func (r *workerRepository) DeleteLeave(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&entity.WorkerLeave{}).Error
+}
@@ -0,0 +1,32 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyRouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type apiKeyRouter struct {
+	apiKeyController controllers.APIKeyController
+	authMiddleware   gin.HandlerFunc
+}
+
+func NewAPIKeyRouter(apiKeyController controllers.APIKeyController, authMiddleware gin.HandlerFunc) APIKeyRouter {
+	return &apiKeyRouter{apiKeyController: apiKeyController, authMiddleware: authMiddleware}
+}
+
+func (r *apiKeyRouter) Setup(router *gin.RouterGroup) {
+	adminGroup := router.Group("/admin/api-keys")
+	adminGroup.Use(r.authMiddleware)
+	adminGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN))
+	adminGroup.POST("", r.apiKeyController.CreateAPIKey)
+	adminGroup.GET("", r.apiKeyController.GetAPIKeys)
+	adminGroup.DELETE("/:id", r.apiKeyController.RevokeAPIKey)
+	adminGroup.GET("/:id/usage", r.apiKeyController.GetAPIKeyUsage)
+}
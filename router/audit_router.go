@@ -0,0 +1,29 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditRouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type auditRouter struct {
+	auditController controllers.AuditController
+	authMiddleware  gin.HandlerFunc
+}
+
+func NewAuditRouter(auditController controllers.AuditController, authMiddleware gin.HandlerFunc) AuditRouter {
+	return &auditRouter{auditController: auditController, authMiddleware: authMiddleware}
+}
+
+func (r *auditRouter) Setup(router *gin.RouterGroup) {
+	adminGroup := router.Group("/admin/audit")
+	adminGroup.Use(r.authMiddleware)
+	adminGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN))
+	adminGroup.GET("", r.auditController.GetAuditLogs)
+}
@@ -3,6 +3,7 @@ package router
 import (
 	"dinacom-11.0-backend/controllers"
 	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,10 +14,11 @@ type AuthRouter interface {
 
 type authRouter struct {
 	authController controllers.AuthController
+	authMiddleware gin.HandlerFunc
 }
 
-func NewAuthRouter(authController controllers.AuthController) AuthRouter {
-	return &authRouter{authController: authController}
+func NewAuthRouter(authController controllers.AuthController, authMiddleware gin.HandlerFunc) AuthRouter {
+	return &authRouter{authController: authController, authMiddleware: authMiddleware}
 }
 
 func (r *authRouter) Setup(router *gin.RouterGroup) {
@@ -36,22 +38,28 @@ func (r *authRouter) Setup(router *gin.RouterGroup) {
 	workerGroup.POST("/login", r.authController.LoginWorker)
 
 	protectedGroup := authGroup.Group("")
-	protectedGroup.Use(middleware.AuthMiddleware())
+	protectedGroup.Use(r.authMiddleware)
+	protectedGroup.Use(middleware.RoleMiddleware("user", "worker", "admin", entity.ROLE_SUPERVISOR))
 	protectedGroup.GET("/me", r.authController.GetProfile)
 
 	adminProtected := authGroup.Group("/admin")
-	adminProtected.Use(middleware.AuthMiddleware())
+	adminProtected.Use(r.authMiddleware)
 	adminProtected.Use(middleware.RoleMiddleware("admin"))
 	adminProtected.GET("/users", r.authController.GetAllUsers)
-	adminProtected.GET("/workers", r.authController.GetAllWorkers)
+	adminProtected.PATCH("/users/:id/role", r.authController.ChangeUserRole)
+
+	adminReadProtected := authGroup.Group("/admin")
+	adminReadProtected.Use(r.authMiddleware)
+	adminReadProtected.Use(middleware.RoleMiddleware("admin", entity.ROLE_SERVICE))
+	adminReadProtected.GET("/workers", middleware.PermissionMiddleware(entity.PERMISSION_WORKERS_READ), r.authController.GetAllWorkers)
 
 	workerProtected := authGroup.Group("/worker")
-	workerProtected.Use(middleware.AuthMiddleware())
+	workerProtected.Use(r.authMiddleware)
 	workerProtected.Use(middleware.RoleMiddleware("worker", "admin"))
 	workerProtected.GET("/me", r.authController.GetProfile)
 
 	userProtected := authGroup.Group("/user")
-	userProtected.Use(middleware.AuthMiddleware())
+	userProtected.Use(r.authMiddleware)
 	userProtected.Use(middleware.RoleMiddleware("user", "admin"))
 	userProtected.GET("/me", r.authController.GetProfile)
 }
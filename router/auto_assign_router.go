@@ -0,0 +1,36 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AutoAssignRouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type autoAssignRouter struct {
+	autoAssignController controllers.AutoAssignController
+	authMiddleware       gin.HandlerFunc
+}
+
+func NewAutoAssignRouter(autoAssignController controllers.AutoAssignController, authMiddleware gin.HandlerFunc) AutoAssignRouter {
+	return &autoAssignRouter{autoAssignController: autoAssignController, authMiddleware: authMiddleware}
+}
+
+func (r *autoAssignRouter) Setup(router *gin.RouterGroup) {
+	reportGroup := router.Group("/admin/report")
+	reportGroup.Use(r.authMiddleware)
+	reportGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN))
+	reportGroup.GET("/:id/candidates", r.autoAssignController.GetCandidates)
+
+	suggestionGroup := router.Group("/admin/assignment-suggestions")
+	suggestionGroup.Use(r.authMiddleware)
+	suggestionGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN))
+	suggestionGroup.GET("", r.autoAssignController.GetSuggestions)
+	suggestionGroup.PATCH("/:id/apply", r.autoAssignController.ApplySuggestion)
+	suggestionGroup.PATCH("/:id/dismiss", r.autoAssignController.DismissSuggestion)
+}
@@ -0,0 +1,29 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GeocodeRouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type geocodeRouter struct {
+	geocodeController controllers.GeocodeController
+	authMiddleware    gin.HandlerFunc
+}
+
+func NewGeocodeRouter(geocodeController controllers.GeocodeController, authMiddleware gin.HandlerFunc) GeocodeRouter {
+	return &geocodeRouter{geocodeController: geocodeController, authMiddleware: authMiddleware}
+}
+
+func (r *geocodeRouter) Setup(router *gin.RouterGroup) {
+	geocodeGroup := router.Group("/geocode")
+	geocodeGroup.Use(r.authMiddleware)
+	geocodeGroup.Use(middleware.RoleMiddleware(entity.ROLE_USER, entity.ROLE_WORKER, entity.ROLE_ADMIN, entity.ROLE_SUPERVISOR, entity.ROLE_SERVICE))
+	geocodeGroup.GET("/reverse", middleware.PermissionMiddleware(entity.PERMISSION_REPORTS_CREATE), r.geocodeController.ReverseGeocode)
+}
@@ -0,0 +1,38 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LocationRouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type locationRouter struct {
+	locationController controllers.LocationController
+	authMiddleware     gin.HandlerFunc
+}
+
+func NewLocationRouter(locationController controllers.LocationController, authMiddleware gin.HandlerFunc) LocationRouter {
+	return &locationRouter{locationController: locationController, authMiddleware: authMiddleware}
+}
+
+func (r *locationRouter) Setup(router *gin.RouterGroup) {
+	adminGroup := router.Group("/admin/workers")
+	adminGroup.Use(r.authMiddleware)
+	adminGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN))
+	adminGroup.GET("/locations", r.locationController.GetLatestPositions)
+	adminGroup.GET("/:id/track", r.locationController.GetWorkerTrack)
+
+	workerGroup := router.Group("/worker")
+	workerGroup.Use(r.authMiddleware)
+	workerGroup.Use(middleware.RoleMiddleware(entity.ROLE_WORKER))
+	workerGroup.GET("/shift", r.locationController.GetCurrentShift)
+	workerGroup.POST("/shift/start", r.locationController.StartShift)
+	workerGroup.POST("/shift/end", r.locationController.EndShift)
+	workerGroup.POST("/location", r.locationController.RecordPings)
+}
@@ -0,0 +1,26 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MapRouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type mapRouter struct {
+	mapController controllers.MapController
+}
+
+func NewMapRouter(mapController controllers.MapController) MapRouter {
+	return &mapRouter{mapController: mapController}
+}
+
+func (r *mapRouter) Setup(router *gin.RouterGroup) {
+	router.GET("/reports.geojson", r.mapController.GetReportsGeoJSON)
+	router.GET("/reports/clusters", r.mapController.GetClusters)
+	router.GET("/reports/heatmap", r.mapController.GetHeatmap)
+	router.GET("/tiles/:z/:x/:y", r.mapController.GetTile)
+}
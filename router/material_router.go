@@ -0,0 +1,39 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MaterialRouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type materialRouter struct {
+	materialController controllers.MaterialController
+	authMiddleware     gin.HandlerFunc
+}
+
+func NewMaterialRouter(materialController controllers.MaterialController, authMiddleware gin.HandlerFunc) MaterialRouter {
+	return &materialRouter{materialController: materialController, authMiddleware: authMiddleware}
+}
+
+func (r *materialRouter) Setup(router *gin.RouterGroup) {
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(r.authMiddleware)
+	adminGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN))
+	adminGroup.GET("/materials", r.materialController.GetMaterials)
+	adminGroup.POST("/materials", r.materialController.CreateMaterial)
+	adminGroup.GET("/materials/costs", r.materialController.GetCostReport)
+	adminGroup.PUT("/materials/:id", r.materialController.UpdateMaterial)
+	adminGroup.DELETE("/materials/:id", r.materialController.DeleteMaterial)
+	adminGroup.GET("/report/:id/materials", r.materialController.GetReportCost)
+
+	workerGroup := router.Group("/worker")
+	workerGroup.Use(r.authMiddleware)
+	workerGroup.Use(middleware.RoleMiddleware(entity.ROLE_WORKER))
+	workerGroup.GET("/materials", r.materialController.GetActiveMaterials)
+}
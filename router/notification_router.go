@@ -0,0 +1,31 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationRouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type notificationRouter struct {
+	notificationController controllers.NotificationController
+	authMiddleware         gin.HandlerFunc
+}
+
+func NewNotificationRouter(notificationController controllers.NotificationController, authMiddleware gin.HandlerFunc) NotificationRouter {
+	return &notificationRouter{notificationController: notificationController, authMiddleware: authMiddleware}
+}
+
+func (r *notificationRouter) Setup(router *gin.RouterGroup) {
+	notificationGroup := router.Group("/notifications")
+	notificationGroup.Use(r.authMiddleware)
+	notificationGroup.Use(middleware.RoleMiddleware(entity.ROLE_USER, entity.ROLE_WORKER, entity.ROLE_ADMIN, entity.ROLE_SUPERVISOR))
+	notificationGroup.GET("/me", r.notificationController.GetMyNotifications)
+	notificationGroup.PATCH("/read-all", r.notificationController.MarkAllAsRead)
+	notificationGroup.PATCH("/:id/read", r.notificationController.MarkAsRead)
+}
@@ -0,0 +1,30 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Open311Router interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type open311Router struct {
+	open311Controller controllers.Open311Controller
+}
+
+// NewOpen311Router takes no auth middleware: Open311 clients send their API
+// key as the api_key parameter, which the controller checks.
+func NewOpen311Router(open311Controller controllers.Open311Controller) Open311Router {
+	return &open311Router{open311Controller: open311Controller}
+}
+
+func (r *open311Router) Setup(router *gin.RouterGroup) {
+	group := router.Group("/open311/v2")
+	group.GET("/services.:format", r.open311Controller.GetServices)
+	group.GET("/requests.:format", r.open311Controller.GetRequests)
+	group.POST("/requests.:format", r.open311Controller.CreateRequest)
+	group.GET("/requests/:id", r.open311Controller.GetRequest)
+	group.GET("/tokens/:token", r.open311Controller.GetToken)
+}
@@ -0,0 +1,30 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecurrenceRouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type recurrenceRouter struct {
+	recurrenceController controllers.RecurrenceController
+	authMiddleware       gin.HandlerFunc
+}
+
+func NewRecurrenceRouter(recurrenceController controllers.RecurrenceController, authMiddleware gin.HandlerFunc) RecurrenceRouter {
+	return &recurrenceRouter{recurrenceController: recurrenceController, authMiddleware: authMiddleware}
+}
+
+func (r *recurrenceRouter) Setup(router *gin.RouterGroup) {
+	adminGroup := router.Group("/admin/recurrences")
+	adminGroup.Use(r.authMiddleware)
+	adminGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN, entity.ROLE_SUPERVISOR))
+	adminGroup.GET("/workers", r.recurrenceController.GetWorkerRecurrences)
+	adminGroup.GET("/contractors", r.recurrenceController.GetContractorRecurrences)
+}
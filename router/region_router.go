@@ -0,0 +1,32 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RegionRouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type regionRouter struct {
+	regionController controllers.RegionController
+	authMiddleware   gin.HandlerFunc
+}
+
+func NewRegionRouter(regionController controllers.RegionController, authMiddleware gin.HandlerFunc) RegionRouter {
+	return &regionRouter{regionController: regionController, authMiddleware: authMiddleware}
+}
+
+func (r *regionRouter) Setup(router *gin.RouterGroup) {
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(r.authMiddleware)
+	adminGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN))
+	adminGroup.GET("/regions", r.regionController.GetRegions)
+	adminGroup.POST("/regions/reload", r.regionController.ReloadBoundaries)
+	adminGroup.GET("/users/:id/regions", r.regionController.GetAdminRegions)
+	adminGroup.PUT("/users/:id/regions", r.regionController.SetAdminRegions)
+}
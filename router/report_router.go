@@ -14,31 +14,60 @@ type ReportRouter interface {
 
 type reportRouter struct {
 	reportController controllers.ReportController
+	authMiddleware   gin.HandlerFunc
 }
 
-func NewReportRouter(reportController controllers.ReportController) ReportRouter {
-	return &reportRouter{reportController: reportController}
+func NewReportRouter(reportController controllers.ReportController, authMiddleware gin.HandlerFunc) ReportRouter {
+	return &reportRouter{reportController: reportController, authMiddleware: authMiddleware}
 }
 
 func (r *reportRouter) Setup(router *gin.RouterGroup) {
 	router.GET("/get_report", r.reportController.GetReports)
+	router.POST("/get_report/search", r.reportController.SearchReports)
 
 	userReportGroup := router.Group("/user/report")
-	userReportGroup.Use(middleware.AuthMiddleware())
+	userReportGroup.Use(r.authMiddleware)
+	userReportGroup.Use(middleware.RoleMiddleware(entity.ROLE_USER, entity.ROLE_WORKER, entity.ROLE_ADMIN))
 	userReportGroup.POST("", r.reportController.CreateReport)
 	userReportGroup.GET("/me", r.reportController.GetUserReports)
+	userReportGroup.GET("/:id/timeline", r.reportController.GetReportTimeline)
 
 	adminGroup := router.Group("/admin/report")
-	adminGroup.Use(middleware.AuthMiddleware())
+	adminGroup.Use(r.authMiddleware)
 	adminGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN))
 	adminGroup.PATCH("/assign", r.reportController.AssignWorker)
-	adminGroup.GET("/assign", r.reportController.GetAssignedReports)
+	adminGroup.PATCH("/assign-team", r.reportController.AssignTeam)
 	adminGroup.PATCH("/verify", r.reportController.VerifyReport)
+	adminGroup.PATCH("/reject", r.reportController.RejectReport)
+	adminGroup.PATCH("/forward", r.reportController.ForwardReport)
+	adminGroup.PATCH("/rework", r.reportController.ReworkReport)
+	adminGroup.GET("/:id/reworks", r.reportController.GetReportReworks)
+	adminGroup.PATCH("/reassign", r.reportController.ReassignWorker)
+	adminGroup.PATCH("/unassign", r.reportController.UnassignWorker)
+	adminGroup.GET("/:id/assignments", r.reportController.GetReportAssignments)
+	adminGroup.GET("/:id/progress", r.reportController.GetReportProgress)
+	adminGroup.GET("/:id", r.reportController.GetReport)
+	adminGroup.DELETE("/:id", r.reportController.DeleteReport)
+
+	adminReadGroup := router.Group("/admin/report")
+	adminReadGroup.Use(r.authMiddleware)
+	adminReadGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN, entity.ROLE_SERVICE))
+	adminReadGroup.GET("/assign", middleware.PermissionMiddleware(entity.PERMISSION_ASSIGNMENTS_READ), r.reportController.GetAssignedReports)
 
 	workerGroup := router.Group("/worker")
-	workerGroup.Use(middleware.AuthMiddleware())
+	workerGroup.Use(r.authMiddleware)
 	workerGroup.Use(middleware.RoleMiddleware(entity.ROLE_WORKER, entity.ROLE_ADMIN))
 	workerGroup.PATCH("/report", r.reportController.FinishReport)
+	workerGroup.PATCH("/report/accept", r.reportController.AcceptAssignment)
+	workerGroup.PATCH("/report/decline", r.reportController.DeclineAssignment)
 	workerGroup.GET("/report/assign/me", r.reportController.GetWorkerAssignedReports)
 	workerGroup.GET("/report/history/me", r.reportController.GetWorkerHistory)
+	workerGroup.POST("/report/progress", r.reportController.SubmitProgress)
+	workerGroup.GET("/report/:id/progress", r.reportController.GetMyReportProgress)
+
+	serviceGroup := router.Group("/service/report")
+	serviceGroup.Use(r.authMiddleware)
+	serviceGroup.Use(middleware.RoleMiddleware(entity.ROLE_SERVICE, entity.ROLE_ADMIN))
+	serviceGroup.GET("/pending", middleware.PermissionMiddleware(entity.PERMISSION_REPORTS_READ), r.reportController.GetPendingReports)
+	serviceGroup.PATCH("/classify", middleware.PermissionMiddleware(entity.PERMISSION_REPORTS_CLASSIFY), r.reportController.ClassifyReport)
 }
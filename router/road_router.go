@@ -0,0 +1,34 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoadRouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type roadRouter struct {
+	roadController controllers.RoadController
+	authMiddleware gin.HandlerFunc
+}
+
+func NewRoadRouter(roadController controllers.RoadController, authMiddleware gin.HandlerFunc) RoadRouter {
+	return &roadRouter{roadController: roadController, authMiddleware: authMiddleware}
+}
+
+func (r *roadRouter) Setup(router *gin.RouterGroup) {
+	router.GET("/roads", r.roadController.GetRoads)
+	router.GET("/roads/:id", r.roadController.GetRoad)
+
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(r.authMiddleware)
+	adminGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN))
+	adminGroup.GET("/roads/queues", r.roadController.GetQueues)
+	adminGroup.GET("/roads/queues/:authority", r.roadController.GetQueue)
+	adminGroup.POST("/roads/reload", r.roadController.ReloadNetwork)
+}
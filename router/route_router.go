@@ -0,0 +1,34 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RouteRouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type routeRouter struct {
+	routeController controllers.RouteController
+	authMiddleware  gin.HandlerFunc
+}
+
+func NewRouteRouter(routeController controllers.RouteController, authMiddleware gin.HandlerFunc) RouteRouter {
+	return &routeRouter{routeController: routeController, authMiddleware: authMiddleware}
+}
+
+func (r *routeRouter) Setup(router *gin.RouterGroup) {
+	adminGroup := router.Group("/admin/workers")
+	adminGroup.Use(r.authMiddleware)
+	adminGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN))
+	adminGroup.GET("/:id/route", r.routeController.GetWorkerRoute)
+
+	workerGroup := router.Group("/worker")
+	workerGroup.Use(r.authMiddleware)
+	workerGroup.Use(middleware.RoleMiddleware(entity.ROLE_WORKER))
+	workerGroup.GET("/route", r.routeController.GetMyRoute)
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	_ "dinacom-11.0-backend/docs"
+	"dinacom-11.0-backend/middleware"
 	"dinacom-11.0-backend/provider"
 
 	"github.com/gin-contrib/gzip"
@@ -11,14 +12,67 @@ import (
 
 func RunRouter(appProvider provider.AppProvider) {
 	router, controller, config := appProvider.ProvideRouter(), appProvider.ProvideControllers(), appProvider.ProvideConfig()
+	authMiddleware := appProvider.ProvideMiddlewares().ProvideAuthMiddleware()
 	router.Use(gzip.Gzip(gzip.DefaultCompression))
+	router.Use(middleware.RequestIDMiddleware())
 
-	authRouter := NewAuthRouter(controller.ProvideAuthController())
+	authRouter := NewAuthRouter(controller.ProvideAuthController(), authMiddleware)
 	authRouter.Setup(router.Group("/api"))
 
-	reportRouter := NewReportRouter(controller.ProvideReportController())
+	reportRouter := NewReportRouter(controller.ProvideReportController(), authMiddleware)
 	reportRouter.Setup(router.Group("/api"))
 
+	apiKeyRouter := NewAPIKeyRouter(controller.ProvideAPIKeyController(), authMiddleware)
+	apiKeyRouter.Setup(router.Group("/api"))
+
+	auditRouter := NewAuditRouter(controller.ProvideAuditController(), authMiddleware)
+	auditRouter.Setup(router.Group("/api"))
+
+	notificationRouter := NewNotificationRouter(controller.ProvideNotificationController(), authMiddleware)
+	notificationRouter.Setup(router.Group("/api"))
+
+	workerRouter := NewWorkerRouter(controller.ProvideWorkerController(), authMiddleware)
+	workerRouter.Setup(router.Group("/api"))
+
+	autoAssignRouter := NewAutoAssignRouter(controller.ProvideAutoAssignController(), authMiddleware)
+	autoAssignRouter.Setup(router.Group("/api"))
+
+	slaRouter := NewSLARouter(controller.ProvideSLAController(), authMiddleware)
+	slaRouter.Setup(router.Group("/api"))
+
+	routeRouter := NewRouteRouter(controller.ProvideRouteController(), authMiddleware)
+	routeRouter.Setup(router.Group("/api"))
+
+	locationRouter := NewLocationRouter(controller.ProvideLocationController(), authMiddleware)
+	locationRouter.Setup(router.Group("/api"))
+
+	teamRouter := NewTeamRouter(controller.ProvideTeamController(), authMiddleware)
+	teamRouter.Setup(router.Group("/api"))
+
+	materialRouter := NewMaterialRouter(controller.ProvideMaterialController(), authMiddleware)
+	materialRouter.Setup(router.Group("/api"))
+
+	mapRouter := NewMapRouter(controller.ProvideMapController())
+	mapRouter.Setup(router.Group("/api"))
+
+	regionRouter := NewRegionRouter(controller.ProvideRegionController(), authMiddleware)
+	regionRouter.Setup(router.Group("/api"))
+
+	roadRouter := NewRoadRouter(controller.ProvideRoadController(), authMiddleware)
+	roadRouter.Setup(router.Group("/api"))
+
+	geocodeRouter := NewGeocodeRouter(controller.ProvideGeocodeController(), authMiddleware)
+	geocodeRouter.Setup(router.Group("/api"))
+
+	serviceAreaRouter := NewServiceAreaRouter(controller.ProvideServiceAreaController(), authMiddleware)
+	serviceAreaRouter.Setup(router.Group("/api"))
+
+	recurrenceRouter := NewRecurrenceRouter(controller.ProvideRecurrenceController(), authMiddleware)
+	recurrenceRouter.Setup(router.Group("/api"))
+
+	open311Router := NewOpen311Router(controller.ProvideOpen311Controller())
+	open311Router.Setup(router.Group("/api"))
+
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	err := router.Run(config.ProvideEnvConfig().GetTCPAddress())
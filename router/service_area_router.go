@@ -0,0 +1,32 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceAreaRouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type serviceAreaRouter struct {
+	serviceAreaController controllers.ServiceAreaController
+	authMiddleware        gin.HandlerFunc
+}
+
+func NewServiceAreaRouter(serviceAreaController controllers.ServiceAreaController, authMiddleware gin.HandlerFunc) ServiceAreaRouter {
+	return &serviceAreaRouter{serviceAreaController: serviceAreaController, authMiddleware: authMiddleware}
+}
+
+func (r *serviceAreaRouter) Setup(router *gin.RouterGroup) {
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(r.authMiddleware)
+	adminGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN))
+	adminGroup.GET("/service-areas", r.serviceAreaController.GetServiceAreas)
+	adminGroup.POST("/service-areas", r.serviceAreaController.CreateServiceArea)
+	adminGroup.PUT("/service-areas/:id", r.serviceAreaController.UpdateServiceArea)
+	adminGroup.DELETE("/service-areas/:id", r.serviceAreaController.DeleteServiceArea)
+}
@@ -0,0 +1,39 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SLARouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type slaRouter struct {
+	slaController  controllers.SLAController
+	authMiddleware gin.HandlerFunc
+}
+
+func NewSLARouter(slaController controllers.SLAController, authMiddleware gin.HandlerFunc) SLARouter {
+	return &slaRouter{slaController: slaController, authMiddleware: authMiddleware}
+}
+
+func (r *slaRouter) Setup(router *gin.RouterGroup) {
+	adminGroup := router.Group("/admin/sla")
+	adminGroup.Use(r.authMiddleware)
+	adminGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN, entity.ROLE_SUPERVISOR))
+	adminGroup.GET("/overdue", r.slaController.GetOverdueReports)
+	adminGroup.GET("/workers", r.slaController.GetWorkerCompliance)
+	adminGroup.GET("/roads", r.slaController.GetRoadCompliance)
+	adminGroup.GET("/policies", r.slaController.GetPolicies)
+
+	policyGroup := router.Group("/admin/sla/policies")
+	policyGroup.Use(r.authMiddleware)
+	policyGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN))
+	policyGroup.POST("", r.slaController.CreatePolicy)
+	policyGroup.PUT("/:id", r.slaController.UpdatePolicy)
+	policyGroup.DELETE("/:id", r.slaController.DeletePolicy)
+}
@@ -0,0 +1,38 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TeamRouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type teamRouter struct {
+	teamController controllers.TeamController
+	authMiddleware gin.HandlerFunc
+}
+
+func NewTeamRouter(teamController controllers.TeamController, authMiddleware gin.HandlerFunc) TeamRouter {
+	return &teamRouter{teamController: teamController, authMiddleware: authMiddleware}
+}
+
+func (r *teamRouter) Setup(router *gin.RouterGroup) {
+	adminGroup := router.Group("/admin/teams")
+	adminGroup.Use(r.authMiddleware)
+	adminGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN))
+	adminGroup.GET("", r.teamController.GetTeams)
+	adminGroup.POST("", r.teamController.CreateTeam)
+	adminGroup.GET("/:id", r.teamController.GetTeam)
+	adminGroup.PUT("/:id", r.teamController.UpdateTeam)
+	adminGroup.DELETE("/:id", r.teamController.DeleteTeam)
+
+	workerGroup := router.Group("/worker")
+	workerGroup.Use(r.authMiddleware)
+	workerGroup.Use(middleware.RoleMiddleware(entity.ROLE_WORKER))
+	workerGroup.GET("/team", r.teamController.GetMyTeam)
+}
@@ -0,0 +1,43 @@
+package router
+
+import (
+	"dinacom-11.0-backend/controllers"
+	"dinacom-11.0-backend/middleware"
+	"dinacom-11.0-backend/models/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkerRouter interface {
+	Setup(router *gin.RouterGroup)
+}
+
+type workerRouter struct {
+	workerController controllers.WorkerController
+	authMiddleware   gin.HandlerFunc
+}
+
+func NewWorkerRouter(workerController controllers.WorkerController, authMiddleware gin.HandlerFunc) WorkerRouter {
+	return &workerRouter{workerController: workerController, authMiddleware: authMiddleware}
+}
+
+func (r *workerRouter) Setup(router *gin.RouterGroup) {
+	adminGroup := router.Group("/admin/workers")
+	adminGroup.Use(r.authMiddleware)
+	adminGroup.Use(middleware.RoleMiddleware(entity.ROLE_ADMIN))
+	adminGroup.GET("/:id/profile", r.workerController.GetWorkerProfile)
+	adminGroup.PUT("/:id/profile", r.workerController.UpdateWorkerProfile)
+	adminGroup.GET("/:id/schedule", r.workerController.GetWorkerSchedule)
+	adminGroup.PUT("/:id/shifts", r.workerController.UpdateWorkerShifts)
+	adminGroup.POST("/:id/leaves", r.workerController.CreateWorkerLeave)
+	adminGroup.DELETE("/:id/leaves/:leave_id", r.workerController.DeleteWorkerLeave)
+	adminGroup.GET("/availability", r.workerController.GetAvailability)
+
+	workerGroup := router.Group("/worker")
+	workerGroup.Use(r.authMiddleware)
+	workerGroup.Use(middleware.RoleMiddleware(entity.ROLE_WORKER))
+	workerGroup.GET("/profile", r.workerController.GetMyWorkerProfile)
+	workerGroup.GET("/schedule", r.workerController.GetMySchedule)
+	workerGroup.POST("/leaves", r.workerController.CreateMyLeave)
+	workerGroup.DELETE("/leaves/:id", r.workerController.DeleteMyLeave)
+}
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"dinacom-11.0-backend/utils"
+)
+
+// Scheduler runs registered jobs in the background at fixed intervals. A job
+// never overlaps with itself, and a panicking job is logged and tried again on
+// the next tick. Jobs must be safe to run on several instances at once.
+type Scheduler interface {
+	Register(name string, interval time.Duration, job func())
+	Start()
+	Stop()
+}
+
+type scheduledJob struct {
+	name     string
+	interval time.Duration
+	run      func()
+}
+
+type scheduler struct {
+	jobs []scheduledJob
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewScheduler() Scheduler {
+	return &scheduler{stop: make(chan struct{})}
+}
+
+// Register adds a job. A non-positive interval disables it.
+func (s *scheduler) Register(name string, interval time.Duration, job func()) {
+	if interval <= 0 {
+		utils.InfoLog("scheduler job disabled", "job", name)
+		return
+	}
+	s.jobs = append(s.jobs, scheduledJob{name: name, interval: interval, run: job})
+}
+
+func (s *scheduler) Start() {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(job)
+	}
+}
+
+func (s *scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+func (s *scheduler) loop(job scheduledJob) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	utils.InfoLog("scheduler job started", "job", job.name, "interval", job.interval.String())
+	for {
+		select {
+		case <-ticker.C:
+			s.runOnce(job)
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *scheduler) runOnce(job scheduledJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			utils.InternalErrorLog(fmt.Errorf("scheduler job panicked: %v", r), "job", job.name)
+		}
+	}()
+	job.run()
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/repositories"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/google/uuid"
+)
+
+type APIKeyService interface {
+	CreateAPIKey(actx dto.AuditContext, req dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error)
+	GetAPIKeys() ([]dto.APIKeyResponse, error)
+	RevokeAPIKey(actx dto.AuditContext, id uuid.UUID) error
+	GetAPIKeyUsage(id uuid.UUID, days int) ([]dto.APIKeyUsageResponse, error)
+	Authenticate(rawKey string, ip string) (*entity.APIKey, error)
+	FlushUsage()
+}
+
+// Usage of a key is written at most once per apiKeyUsageFlushInterval, and
+// on the first request of a day; requests in between are counted in memory.
+const apiKeyUsageFlushInterval = time.Minute
+
+type apiKeyService struct {
+	apiKeyRepo   repositories.APIKeyRepository
+	auditService AuditService
+	usage        map[uuid.UUID]*pendingAPIKeyUsage
+	usageMutex   sync.Mutex
+}
+
+// pendingAPIKeyUsage counts the requests made with a key since its usage was
+// last written, per day so requests from before midnight are not written to
+// the next day.
+type pendingAPIKeyUsage struct {
+	counts    map[time.Time]int64
+	lastIP    string
+	lastUsed  time.Time
+	flushedAt time.Time
+}
+
+func NewAPIKeyService(apiKeyRepo repositories.APIKeyRepository, auditService AuditService) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo:   apiKeyRepo,
+		auditService: auditService,
+		usage:        make(map[uuid.UUID]*pendingAPIKeyUsage),
+	}
+}
+
+func (s *apiKeyService) CreateAPIKey(actx dto.AuditContext, req dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error) {
+	permissions := make([]string, 0, len(req.Permissions))
+	seen := map[string]bool{}
+	for _, p := range req.Permissions {
+		p = strings.TrimSpace(p)
+		if !entity.API_KEY_PERMISSIONS[p] {
+			return nil, http_error.INVALID_API_KEY_PERMISSION
+		}
+		if !seen[p] {
+			seen[p] = true
+			permissions = append(permissions, p)
+		}
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, http_error.INVALID_API_KEY_EXPIRY
+	}
+
+	rawKey, prefix, hash, err := utils.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &entity.APIKey{
+		Name:        req.Name,
+		Prefix:      prefix,
+		KeyHash:     hash,
+		Permissions: strings.Join(permissions, ","),
+		ExpiresAt:   req.ExpiresAt,
+	}
+	if actx.ActorID != nil {
+		key.CreatedBy = *actx.ActorID
+	}
+
+	if err := s.apiKeyRepo.CreateAPIKey(key); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_API_KEY_CREATE, entity.AUDIT_TARGET_API_KEY, key.ID.String(), map[string]interface{}{
+		"name":        key.Name,
+		"prefix":      key.Prefix,
+		"permissions": permissions,
+	})
+
+	return &dto.CreateAPIKeyResponse{
+		APIKeyResponse: toAPIKeyResponse(*key),
+		Key:            rawKey,
+	}, nil
+}
+
+func (s *apiKeyService) GetAPIKeys() ([]dto.APIKeyResponse, error) {
+	keys, err := s.apiKeyRepo.GetAPIKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	var response []dto.APIKeyResponse
+	for _, key := range keys {
+		response = append(response, toAPIKeyResponse(key))
+	}
+	return response, nil
+}
+
+func (s *apiKeyService) RevokeAPIKey(actx dto.AuditContext, id uuid.UUID) error {
+	key, err := s.apiKeyRepo.GetAPIKeyByID(id)
+	if err != nil {
+		return http_error.API_KEY_NOT_FOUND
+	}
+
+	if err := s.apiKeyRepo.RevokeAPIKey(id, time.Now()); err != nil {
+		return err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_API_KEY_REVOKE, entity.AUDIT_TARGET_API_KEY, id.String(), map[string]interface{}{
+		"name":   key.Name,
+		"prefix": key.Prefix,
+	})
+	return nil
+}
+
+func (s *apiKeyService) GetAPIKeyUsage(id uuid.UUID, days int) ([]dto.APIKeyUsageResponse, error) {
+	if _, err := s.apiKeyRepo.GetAPIKeyByID(id); err != nil {
+		return nil, http_error.API_KEY_NOT_FOUND
+	}
+
+	now := time.Now()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -(days - 1))
+	usage, err := s.apiKeyRepo.GetUsage(id, since)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []dto.APIKeyUsageResponse
+	for _, u := range usage {
+		response = append(response, dto.APIKeyUsageResponse{
+			Day:   u.Day.Format("2006-01-02"),
+			Count: u.Count,
+		})
+	}
+	return response, nil
+}
+
+func (s *apiKeyService) Authenticate(rawKey string, ip string) (*entity.APIKey, error) {
+	key, err := s.apiKeyRepo.GetAPIKeyByHash(utils.HashAPIKey(rawKey))
+	if err != nil || key == nil {
+		return nil, http_error.INVALID_API_KEY
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil || (key.ExpiresAt != nil && key.ExpiresAt.Before(now)) {
+		return nil, http_error.INVALID_API_KEY
+	}
+
+	s.recordUsage(key.ID, ip, now)
+	return key, nil
+}
+
+// recordUsage counts the request and writes the key's usage once the last
+// write is a minute old or from an earlier day.
+func (s *apiKeyService) recordUsage(id uuid.UUID, ip string, now time.Time) {
+	s.usageMutex.Lock()
+	usage, ok := s.usage[id]
+	if !ok {
+		usage = &pendingAPIKeyUsage{counts: map[time.Time]int64{}}
+		s.usage[id] = usage
+	}
+	usage.counts[usageDay(now)]++
+	usage.lastIP, usage.lastUsed = ip, now
+
+	if usageDay(usage.flushedAt).Equal(usageDay(now)) && now.Sub(usage.flushedAt) < apiKeyUsageFlushInterval {
+		s.usageMutex.Unlock()
+		return
+	}
+	counts := usage.counts
+	usage.counts, usage.flushedAt = map[time.Time]int64{}, now
+	s.usageMutex.Unlock()
+
+	s.writeUsage(id, ip, now, counts)
+}
+
+// FlushUsage writes the requests still counted in memory, so keys that went
+// quiet are not left behind. It runs as a job.
+func (s *apiKeyService) FlushUsage() {
+	now := time.Now()
+	type flush struct {
+		id       uuid.UUID
+		ip       string
+		lastUsed time.Time
+		counts   map[time.Time]int64
+	}
+	var flushes []flush
+
+	s.usageMutex.Lock()
+	for id, usage := range s.usage {
+		if len(usage.counts) > 0 {
+			flushes = append(flushes, flush{id: id, ip: usage.lastIP, lastUsed: usage.lastUsed, counts: usage.counts})
+			usage.counts, usage.flushedAt = map[time.Time]int64{}, now
+		} else if now.Sub(usage.flushedAt) >= apiKeyUsageFlushInterval {
+			delete(s.usage, id)
+		}
+	}
+	s.usageMutex.Unlock()
+
+	for _, f := range flushes {
+		s.writeUsage(f.id, f.ip, f.lastUsed, f.counts)
+	}
+}
+
+// writeUsage stores the counted requests, putting them back to be retried
+// when the write fails.
+func (s *apiKeyService) writeUsage(id uuid.UUID, ip string, usedAt time.Time, counts map[time.Time]int64) {
+	if err := s.apiKeyRepo.RecordUsage(id, ip, usedAt, counts); err != nil {
+		utils.InternalErrorLog(err, "api_key_id", id)
+		s.usageMutex.Lock()
+		usage, ok := s.usage[id]
+		if !ok {
+			usage = &pendingAPIKeyUsage{counts: map[time.Time]int64{}, lastIP: ip, lastUsed: usedAt}
+			s.usage[id] = usage
+		}
+		for day, count := range counts {
+			usage.counts[day] += count
+		}
+		s.usageMutex.Unlock()
+	}
+}
+
+// usageDay is the start of the day the usage is counted under.
+func usageDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func toAPIKeyResponse(key entity.APIKey) dto.APIKeyResponse {
+	active := key.RevokedAt == nil && (key.ExpiresAt == nil || key.ExpiresAt.After(time.Now()))
+	return dto.APIKeyResponse{
+		ID:          key.ID,
+		Name:        key.Name,
+		Prefix:      key.Prefix,
+		Permissions: key.PermissionList(),
+		CreatedBy:   key.CreatedBy,
+		ExpiresAt:   key.ExpiresAt,
+		LastUsedAt:  key.LastUsedAt,
+		LastUsedIP:  key.LastUsedIP,
+		UsageCount:  key.UsageCount,
+		RevokedAt:   key.RevokedAt,
+		Active:      active,
+		CreatedAt:   key.CreatedAt,
+	}
+}
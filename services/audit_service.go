@@ -0,0 +1,114 @@
+package services
+
+import (
+	"encoding/json"
+
+	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+	"dinacom-11.0-backend/repositories"
+	"dinacom-11.0-backend/utils"
+)
+
+const maxAuditExportRows = 10000
+
+type AuditService interface {
+	Record(actx dto.AuditContext, action, targetType, targetID string, metadata map[string]interface{})
+	GetAuditLogs(filter dto.AuditLogFilter, page, limit int) (*dto.PaginatedAuditLogsResponse, error)
+	ExportAuditLogs(filter dto.AuditLogFilter) ([]dto.AuditLogResponse, error)
+}
+
+type auditService struct {
+	auditRepo repositories.AuditRepository
+}
+
+func NewAuditService(auditRepo repositories.AuditRepository) AuditService {
+	return &auditService{auditRepo: auditRepo}
+}
+
+// Record stores an audit event. Failures are logged but never returned so that
+// auditing can not break the action being audited.
+func (s *auditService) Record(actx dto.AuditContext, action, targetType, targetID string, metadata map[string]interface{}) {
+	encoded := ""
+	if len(metadata) > 0 {
+		if raw, err := json.Marshal(metadata); err == nil {
+			encoded = string(raw)
+		}
+	}
+
+	log := &entity.AuditLog{
+		Action:     action,
+		ActorID:    actx.ActorID,
+		ActorRole:  actx.ActorRole,
+		TargetType: targetType,
+		TargetID:   targetID,
+		IP:         actx.IP,
+		RequestID:  actx.RequestID,
+		Metadata:   encoded,
+	}
+
+	switch {
+	case actx.APIKeyID != nil:
+		log.ActorType, log.ActorID = entity.AUDIT_ACTOR_API_KEY, actx.APIKeyID
+	case actx.ActorID != nil:
+		log.ActorType = entity.AUDIT_ACTOR_USER
+	}
+
+	if err := s.auditRepo.CreateAuditLog(log); err != nil {
+		utils.InternalErrorLog(err, "audit_action", action, "request_id", actx.RequestID)
+	}
+}
+
+func (s *auditService) GetAuditLogs(filter dto.AuditLogFilter, page, limit int) (*dto.PaginatedAuditLogsResponse, error) {
+	offset := (page - 1) * limit
+	logs, total, err := s.auditRepo.GetAuditLogs(filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit != 0 {
+		totalPages++
+	}
+
+	return &dto.PaginatedAuditLogsResponse{
+		Logs:       toAuditLogResponses(logs),
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *auditService) ExportAuditLogs(filter dto.AuditLogFilter) ([]dto.AuditLogResponse, error) {
+	logs, _, err := s.auditRepo.GetAuditLogs(filter, maxAuditExportRows, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAuditLogResponses(logs), nil
+}
+
+func toAuditLogResponses(logs []entity.AuditLog) []dto.AuditLogResponse {
+	var response []dto.AuditLogResponse
+	for _, log := range logs {
+		var metadata map[string]interface{}
+		if log.Metadata != "" {
+			json.Unmarshal([]byte(log.Metadata), &metadata)
+		}
+
+		response = append(response, dto.AuditLogResponse{
+			ID:         log.ID,
+			Action:     log.Action,
+			ActorType:  log.ActorType,
+			ActorID:    log.ActorID,
+			ActorRole:  log.ActorRole,
+			TargetType: log.TargetType,
+			TargetID:   log.TargetID,
+			IP:         log.IP,
+			RequestID:  log.RequestID,
+			Metadata:   metadata,
+			CreatedAt:  log.CreatedAt,
+		})
+	}
+	return response
+}
@@ -6,6 +6,7 @@ import (
 
 	"dinacom-11.0-backend/models/dto"
 	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
 	"dinacom-11.0-backend/repositories"
 	"dinacom-11.0-backend/utils"
 
@@ -14,26 +15,29 @@ import (
 
 type AuthService interface {
 	RegisterUser(req dto.RegisterRequest) error
-	VerifyOTP(req dto.VerifyOTPRequest) (string, error)
-	LoginUser(req dto.LoginRequest) (string, error)
-	LoginAdmin(req dto.LoginRequest) (string, error)
-	LoginWorker(req dto.LoginRequest) (string, error)
-	GoogleAuth(req dto.GoogleAuthRequest) (*dto.GoogleAuthResponse, error)
+	VerifyOTP(actx dto.AuditContext, req dto.VerifyOTPRequest) (string, error)
+	LoginUser(actx dto.AuditContext, req dto.LoginRequest) (string, error)
+	LoginAdmin(actx dto.AuditContext, req dto.LoginRequest) (string, error)
+	LoginWorker(actx dto.AuditContext, req dto.LoginRequest) (string, error)
+	GoogleAuth(actx dto.AuditContext, req dto.GoogleAuthRequest) (*dto.GoogleAuthResponse, error)
 	GetProfile(userID uuid.UUID) (*dto.UserResponse, error)
-	GetAllUsers() ([]dto.UserResponse, error)
+	GetAllUsers(regionCodes []string) ([]dto.UserResponse, error)
 	GetAllWorkers() ([]dto.UserResponse, error)
+	ChangeUserRole(actx dto.AuditContext, userID uuid.UUID, role string) error
 }
 
 type authService struct {
-	userRepo repositories.UserRepository
-	otpStore map[string]string
-	mutex    sync.RWMutex
+	userRepo     repositories.UserRepository
+	auditService AuditService
+	otpStore     map[string]string
+	mutex        sync.RWMutex
 }
 
-func NewAuthService(userRepo repositories.UserRepository) AuthService {
+func NewAuthService(userRepo repositories.UserRepository, auditService AuditService) AuthService {
 	return &authService{
-		userRepo: userRepo,
-		otpStore: make(map[string]string),
+		userRepo:     userRepo,
+		auditService: auditService,
+		otpStore:     make(map[string]string),
 	}
 }
 
@@ -75,13 +79,13 @@ func (s *authService) RegisterUser(req dto.RegisterRequest) error {
 	return nil
 }
 
-func (s *authService) VerifyOTP(req dto.VerifyOTPRequest) (string, error) {
+func (s *authService) VerifyOTP(actx dto.AuditContext, req dto.VerifyOTPRequest) (string, error) {
 	s.mutex.RLock()
 	storedOTP, exists := s.otpStore[req.Email]
 	s.mutex.RUnlock()
 
 	if !exists || storedOTP != req.OTP {
-		return "", errors.New("invalid or expired OTP")
+		return s.completeLogin(actx, req.Email, nil, errors.New("invalid or expired OTP"))
 	}
 
 	if err := s.userRepo.UpdateUserVerified(req.Email, true); err != nil {
@@ -97,70 +101,108 @@ func (s *authService) VerifyOTP(req dto.VerifyOTPRequest) (string, error) {
 	delete(s.otpStore, req.Email)
 	s.mutex.Unlock()
 
-	return utils.GenerateAccessToken(user.ID, user.Role, user.Email)
+	return s.completeLogin(actx, req.Email, user, nil)
+}
+
+func (s *authService) LoginUser(actx dto.AuditContext, req dto.LoginRequest) (string, error) {
+	user, err := s.loginUserAccount(req)
+	return s.completeLogin(actx, req.Email, user, err)
 }
 
-func (s *authService) LoginUser(req dto.LoginRequest) (string, error) {
+func (s *authService) loginUserAccount(req dto.LoginRequest) (*entity.User, error) {
 	user, err := s.userRepo.FindUserByEmail(req.Email)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if user == nil {
-		return "", errors.New("invalid email or password")
+		return nil, errors.New("invalid email or password")
 	}
 
 	if user.Role != "user" {
-		return "", errors.New("unauthorized: user role required")
+		return user, errors.New("unauthorized: user role required")
 	}
 
 	if !user.Verified {
-		return "", errors.New("account not verified. please verify OTP")
+		return user, errors.New("account not verified. please verify OTP")
 	}
 
 	if err := utils.ComparePassword(user.Password, req.Password); err != nil {
-		return "", errors.New("invalid email or password")
+		return user, errors.New("invalid email or password")
 	}
 
-	return utils.GenerateAccessToken(user.ID, user.Role, user.Email)
+	return user, nil
 }
 
-func (s *authService) LoginAdmin(req dto.LoginRequest) (string, error) {
+func (s *authService) LoginAdmin(actx dto.AuditContext, req dto.LoginRequest) (string, error) {
+	user, err := s.loginAdminAccount(req)
+	return s.completeLogin(actx, req.Email, user, err)
+}
+
+func (s *authService) loginAdminAccount(req dto.LoginRequest) (*entity.User, error) {
 	user, err := s.userRepo.FindUserByEmail(req.Email)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if user == nil {
-		return "", errors.New("invalid email or password")
+		return nil, errors.New("invalid email or password")
 	}
 
-	if user.Role != "admin" {
-		return "", errors.New("unauthorized: admin role required")
+	if user.Role != "admin" && user.Role != entity.ROLE_SUPERVISOR {
+		return user, errors.New("unauthorized: admin role required")
 	}
 
 	if err := utils.ComparePassword(user.Password, req.Password); err != nil {
-		return "", errors.New("invalid email or password")
+		return user, errors.New("invalid email or password")
 	}
 
-	return utils.GenerateAccessToken(user.ID, user.Role, user.Email)
+	return user, nil
+}
+
+func (s *authService) LoginWorker(actx dto.AuditContext, req dto.LoginRequest) (string, error) {
+	user, err := s.loginWorkerAccount(req)
+	return s.completeLogin(actx, req.Email, user, err)
 }
 
-func (s *authService) LoginWorker(req dto.LoginRequest) (string, error) {
+func (s *authService) loginWorkerAccount(req dto.LoginRequest) (*entity.User, error) {
 	user, err := s.userRepo.FindUserByEmail(req.Email)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if user == nil {
-		return "", errors.New("invalid email or password")
+		return nil, errors.New("invalid email or password")
 	}
 
 	if user.Role != "worker" {
-		return "", errors.New("unauthorized: worker role required")
+		return user, errors.New("unauthorized: worker role required")
 	}
 
 	if err := utils.ComparePassword(user.Password, req.Password); err != nil {
-		return "", errors.New("invalid email or password")
+		return user, errors.New("invalid email or password")
 	}
 
+	return user, nil
+}
+
+// completeLogin audits the login attempt and issues the access token on success.
+func (s *authService) completeLogin(actx dto.AuditContext, email string, user *entity.User, loginErr error) (string, error) {
+	targetID := ""
+	if user != nil {
+		targetID = user.ID.String()
+	}
+
+	if loginErr != nil {
+		s.auditService.Record(actx, entity.AUDIT_LOGIN_FAILED, entity.AUDIT_TARGET_USER, targetID, map[string]interface{}{
+			"email":  email,
+			"reason": loginErr.Error(),
+		})
+		return "", loginErr
+	}
+
+	actx.ActorID, actx.ActorRole = &user.ID, user.Role
+	s.auditService.Record(actx, entity.AUDIT_LOGIN, entity.AUDIT_TARGET_USER, targetID, map[string]interface{}{
+		"email": email,
+	})
+
 	return utils.GenerateAccessToken(user.ID, user.Role, user.Email)
 }
 
@@ -183,21 +225,30 @@ func (s *authService) GetProfile(userID uuid.UUID) (*dto.UserResponse, error) {
 	}, nil
 }
 
-func (s *authService) GetAllUsers() ([]dto.UserResponse, error) {
-	users, err := s.userRepo.GetUsersByRole("user")
+// GetAllUsers lists citizens; with regions, only those who reported inside
+// them, and their rejected reports there.
+func (s *authService) GetAllUsers(regionCodes []string) ([]dto.UserResponse, error) {
+	users, err := s.userRepo.GetReportersInRegions(regionCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	rejectedCounts, err := s.userRepo.CountReportsByStatusPerUser(entity.STATUS_REJECTED, regionCodes)
 	if err != nil {
 		return nil, err
 	}
 
 	var response []dto.UserResponse
 	for _, user := range users {
+		rejected := rejectedCounts[user.ID]
 		response = append(response, dto.UserResponse{
-			ID:       user.ID,
-			Username: user.Username,
-			Fullname: user.Fullname,
-			Email:    user.Email,
-			Role:     user.Role,
-			Verified: user.Verified,
+			ID:                  user.ID,
+			Username:            user.Username,
+			Fullname:            user.Fullname,
+			Email:               user.Email,
+			Role:                user.Role,
+			Verified:            user.Verified,
+			RejectedReportCount: &rejected,
 		})
 	}
 	return response, nil
@@ -209,23 +260,34 @@ func (s *authService) GetAllWorkers() ([]dto.UserResponse, error) {
 		return nil, err
 	}
 
+	reworkCounts, err := s.userRepo.CountReworksPerWorker()
+	if err != nil {
+		return nil, err
+	}
+
 	var response []dto.UserResponse
 	for _, user := range users {
+		reworks := reworkCounts[user.ID]
 		response = append(response, dto.UserResponse{
-			ID:       user.ID,
-			Username: user.Username,
-			Fullname: user.Fullname,
-			Email:    user.Email,
-			Role:     user.Role,
-			Verified: user.Verified,
+			ID:          user.ID,
+			Username:    user.Username,
+			Fullname:    user.Fullname,
+			Email:       user.Email,
+			Role:        user.Role,
+			Verified:    user.Verified,
+			ReworkCount: &reworks,
 		})
 	}
 	return response, nil
 }
 
-func (s *authService) GoogleAuth(req dto.GoogleAuthRequest) (*dto.GoogleAuthResponse, error) {
+func (s *authService) GoogleAuth(actx dto.AuditContext, req dto.GoogleAuthRequest) (*dto.GoogleAuthResponse, error) {
 	tokenInfo, err := utils.VerifyGoogleToken(req.IDToken)
 	if err != nil {
+		s.auditService.Record(actx, entity.AUDIT_LOGIN_FAILED, entity.AUDIT_TARGET_USER, "", map[string]interface{}{
+			"method": "google",
+			"reason": "invalid Google token",
+		})
 		return nil, errors.New("invalid Google token")
 	}
 
@@ -253,6 +315,13 @@ func (s *authService) GoogleAuth(req dto.GoogleAuthRequest) (*dto.GoogleAuthResp
 		return nil, err
 	}
 
+	actx.ActorID, actx.ActorRole = &existingUser.ID, existingUser.Role
+	s.auditService.Record(actx, entity.AUDIT_LOGIN, entity.AUDIT_TARGET_USER, existingUser.ID.String(), map[string]interface{}{
+		"method":      "google",
+		"email":       existingUser.Email,
+		"is_new_user": isNewUser,
+	})
+
 	return &dto.GoogleAuthResponse{
 		Token: token,
 		User: dto.GoogleUserDetails{
@@ -262,3 +331,35 @@ func (s *authService) GoogleAuth(req dto.GoogleAuthRequest) (*dto.GoogleAuthResp
 		},
 	}, nil
 }
+
+func (s *authService) ChangeUserRole(actx dto.AuditContext, userID uuid.UUID, role string) error {
+	if !entity.USER_ROLES[role] {
+		return http_error.INVALID_ROLE
+	}
+
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return http_error.ACCOUNT_NOT_FOUND
+	}
+
+	if actx.ActorID != nil && *actx.ActorID == userID {
+		return http_error.CANNOT_CHANGE_OWN_ROLE
+	}
+
+	if user.Role == role {
+		return nil
+	}
+
+	if err := s.userRepo.UpdateUserRole(userID, role); err != nil {
+		return err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_ROLE_CHANGE, entity.AUDIT_TARGET_USER, userID.String(), map[string]interface{}{
+		"from": user.Role,
+		"to":   role,
+	})
+	return nil
+}
@@ -0,0 +1,405 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/repositories"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/google/uuid"
+)
+
+// One open job weighs as much as a five kilometre detour.
+const (
+	autoAssignDistanceWeight = 1.0
+	autoAssignWorkloadWeight = 5.0
+)
+
+type AutoAssignService interface {
+	GetCandidates(reportID string) ([]dto.WorkerCandidateResponse, error)
+	HandleClassified(reportID string)
+	GetSuggestions(status string, regionCodes []string, page, limit int) (*dto.PaginatedSuggestionsResponse, error)
+	GetSuggestionReportID(id uuid.UUID) (string, error)
+	ApplySuggestion(actx dto.AuditContext, id uuid.UUID) (string, error)
+	DismissSuggestion(actx dto.AuditContext, id uuid.UUID) error
+	OutdateSuggestions(reportID string)
+}
+
+type autoAssignService struct {
+	reportRepo          repositories.ReportRepository
+	userRepo            repositories.UserRepository
+	workerRepo          repositories.WorkerRepository
+	suggestionRepo      repositories.AssignmentSuggestionRepository
+	locationRepo        repositories.LocationRepository
+	workerService       WorkerService
+	slaService          SLAService
+	auditService        AuditService
+	notificationService NotificationService
+	mode                string
+}
+
+func NewAutoAssignService(reportRepo repositories.ReportRepository, userRepo repositories.UserRepository, workerRepo repositories.WorkerRepository, suggestionRepo repositories.AssignmentSuggestionRepository, locationRepo repositories.LocationRepository, workerService WorkerService, slaService SLAService, auditService AuditService, notificationService NotificationService, mode string) AutoAssignService {
+	if mode != entity.AUTO_ASSIGN_OFF && mode != entity.AUTO_ASSIGN_APPLY {
+		mode = entity.AUTO_ASSIGN_SUGGEST
+	}
+	return &autoAssignService{
+		reportRepo:          reportRepo,
+		userRepo:            userRepo,
+		workerRepo:          workerRepo,
+		suggestionRepo:      suggestionRepo,
+		locationRepo:        locationRepo,
+		workerService:       workerService,
+		slaService:          slaService,
+		auditService:        auditService,
+		notificationService: notificationService,
+		mode:                mode,
+	}
+}
+
+func (s *autoAssignService) GetCandidates(reportID string) ([]dto.WorkerCandidateResponse, error) {
+	report, err := s.reportRepo.GetReportByID(reportID)
+	if err != nil {
+		return nil, http_error.REPORT_NOT_FOUND
+	}
+	return s.rankWorkers(report, time.Now())
+}
+
+// HandleClassified runs after a report is classified. Depending on the mode it
+// stores the best worker as a suggestion for dispatchers or assigns it right
+// away. It runs in the background, so failures are only logged.
+func (s *autoAssignService) HandleClassified(reportID string) {
+	if s.mode == entity.AUTO_ASSIGN_OFF {
+		return
+	}
+
+	report, err := s.reportRepo.GetReportByID(reportID)
+	if err != nil || report.WorkerID != nil || report.Status == entity.STATUS_REJECTED || report.ForwardedAt != nil {
+		return
+	}
+
+	candidates, err := s.rankWorkers(report, time.Now())
+	if err != nil {
+		utils.InternalErrorLog(err, "report_id", reportID)
+		return
+	}
+	if len(candidates) == 0 || !candidates[0].Eligible {
+		utils.InfoLog("no eligible worker for auto assignment", "report_id", reportID)
+		return
+	}
+
+	best := candidates[0]
+	suggestion := &entity.AssignmentSuggestion{
+		ReportID:   report.ID,
+		WorkerID:   best.WorkerID,
+		Score:      best.Score,
+		DistanceKm: best.DistanceKm,
+		OpenJobs:   best.OpenJobs,
+		Reasoning:  best.Reasoning,
+		Status:     entity.SUGGESTION_PENDING,
+	}
+
+	if s.mode == entity.AUTO_ASSIGN_APPLY {
+		actx := dto.AuditContext{ActorRole: entity.ROLE_SYSTEM}
+		if err := s.assign(actx, report, best.WorkerID, best.Reasoning); err != nil {
+			utils.InternalErrorLog(err, "report_id", reportID)
+			return
+		}
+		now := time.Now()
+		suggestion.Status = entity.SUGGESTION_APPLIED
+		suggestion.ReviewedAt = &now
+	}
+
+	if err := s.suggestionRepo.CreateSuggestion(suggestion); err != nil {
+		utils.InternalErrorLog(err, "report_id", reportID)
+		return
+	}
+
+	if s.mode == entity.AUTO_ASSIGN_SUGGEST {
+		s.notificationService.NotifyRole(entity.ROLE_ADMIN, entity.NOTIFICATION_ASSIGNMENT_SUGGESTED, "Assignment suggested",
+			fmt.Sprintf("%s is suggested for the report on %s: %s", best.WorkerName, report.RoadName, best.Reasoning), &report.ID)
+	}
+}
+
+func (s *autoAssignService) GetSuggestions(status string, regionCodes []string, page, limit int) (*dto.PaginatedSuggestionsResponse, error) {
+	offset := (page - 1) * limit
+	suggestions, total, err := s.suggestionRepo.GetSuggestions(status, regionCodes, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []dto.AssignmentSuggestionResponse
+	for _, suggestion := range suggestions {
+		item := dto.AssignmentSuggestionResponse{
+			ID:         suggestion.ID,
+			ReportID:   suggestion.ReportID,
+			WorkerID:   suggestion.WorkerID,
+			Score:      suggestion.Score,
+			DistanceKm: suggestion.DistanceKm,
+			OpenJobs:   suggestion.OpenJobs,
+			Reasoning:  suggestion.Reasoning,
+			Status:     suggestion.Status,
+			ReviewedBy: suggestion.ReviewedBy,
+			ReviewedAt: suggestion.ReviewedAt,
+			CreatedAt:  suggestion.CreatedAt,
+		}
+		if report, _ := s.reportRepo.GetReportByID(suggestion.ReportID); report != nil {
+			item.RoadName = report.RoadName
+		}
+		if worker, _ := s.userRepo.FindUserByID(suggestion.WorkerID); worker != nil {
+			item.WorkerName = worker.Fullname
+		}
+		response = append(response, item)
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit != 0 {
+		totalPages++
+	}
+
+	return &dto.PaginatedSuggestionsResponse{
+		Mode:        s.mode,
+		Suggestions: response,
+		TotalCount:  total,
+		Page:        page,
+		Limit:       limit,
+		TotalPages:  totalPages,
+	}, nil
+}
+
+func (s *autoAssignService) GetSuggestionReportID(id uuid.UUID) (string, error) {
+	suggestion, err := s.suggestionRepo.GetSuggestionByID(id)
+	if err != nil {
+		return "", http_error.SUGGESTION_NOT_FOUND
+	}
+	return suggestion.ReportID, nil
+}
+
+func (s *autoAssignService) ApplySuggestion(actx dto.AuditContext, id uuid.UUID) (string, error) {
+	suggestion, err := s.suggestionRepo.GetSuggestionByID(id)
+	if err != nil {
+		return "", http_error.SUGGESTION_NOT_FOUND
+	}
+
+	if suggestion.Status != entity.SUGGESTION_PENDING {
+		return "", http_error.SUGGESTION_NOT_PENDING
+	}
+
+	report, err := s.reportRepo.GetReportByID(suggestion.ReportID)
+	if err != nil {
+		return "", http_error.REPORT_NOT_FOUND
+	}
+
+	worker, err := s.userRepo.FindUserByID(suggestion.WorkerID)
+	if err != nil || worker == nil || worker.Role != entity.ROLE_WORKER {
+		return "", http_error.WORKER_NOT_FOUND
+	}
+
+	if err := s.assign(actx, report, suggestion.WorkerID, suggestion.Reasoning); err != nil {
+		if err == http_error.REPORT_ALREADY_ASSIGNED || err == http_error.REPORT_REJECTED || err == http_error.REPORT_FORWARDED {
+			s.OutdateSuggestions(report.ID)
+		}
+		return "", err
+	}
+
+	if _, err := s.suggestionRepo.UpdateSuggestionStatus(id, entity.SUGGESTION_APPLIED, actx.ActorID, time.Now()); err != nil {
+		utils.InternalErrorLog(err, "suggestion_id", id.String())
+	}
+	s.OutdateSuggestions(report.ID)
+
+	return fmt.Sprintf("%s is successfully assigned", worker.Fullname), nil
+}
+
+// OutdateSuggestions retires the pending suggestions of a report that can no
+// longer be assigned through them.
+func (s *autoAssignService) OutdateSuggestions(reportID string) {
+	if err := s.suggestionRepo.OutdatePendingSuggestions(reportID); err != nil {
+		utils.InternalErrorLog(err, "report_id", reportID)
+	}
+}
+
+func (s *autoAssignService) DismissSuggestion(actx dto.AuditContext, id uuid.UUID) error {
+	suggestion, err := s.suggestionRepo.GetSuggestionByID(id)
+	if err != nil {
+		return http_error.SUGGESTION_NOT_FOUND
+	}
+
+	updated, err := s.suggestionRepo.UpdateSuggestionStatus(id, entity.SUGGESTION_DISMISSED, actx.ActorID, time.Now())
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return http_error.SUGGESTION_NOT_PENDING
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_SUGGESTION_DISMISS, entity.AUDIT_TARGET_REPORT, suggestion.ReportID, map[string]interface{}{
+		"suggestion_id": id,
+		"worker_id":     suggestion.WorkerID,
+	})
+	return nil
+}
+
+// assign creates the assignment with the engine's reasoning attached. The
+// report version read by the caller guards against a concurrent manual
+// assignment.
+func (s *autoAssignService) assign(actx dto.AuditContext, report *entity.Report, workerID uuid.UUID, reasoning string) error {
+	if report.Status == entity.STATUS_REJECTED {
+		return http_error.REPORT_REJECTED
+	}
+
+	if report.ForwardedAt != nil {
+		return http_error.REPORT_FORWARDED
+	}
+
+	if report.WorkerID != nil {
+		return http_error.REPORT_ALREADY_ASSIGNED
+	}
+
+	assignment := &entity.ReportAssignment{
+		ReportID:   report.ID,
+		WorkerID:   workerID,
+		AssignedBy: actx.ActorID,
+		Status:     entity.ASSIGNMENT_PENDING,
+		Reasoning:  reasoning,
+	}
+
+	deadline, policy, err := s.slaService.ResolveDeadline(report, time.Now())
+	if err != nil {
+		return err
+	}
+	if policy != nil {
+		assignment.Deadline = deadline
+		assignment.SLAPolicyID = &policy.ID
+	}
+
+	if err := s.reportRepo.AssignWorker(assignment, report.Version); err != nil {
+		return err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_REPORT_AUTO_ASSIGN, entity.AUDIT_TARGET_REPORT, report.ID, map[string]interface{}{
+		"worker_id": workerID,
+		"mode":      s.mode,
+		"reasoning": reasoning,
+		"deadline":  assignment.Deadline,
+	})
+	s.notificationService.Notify(workerID, entity.NOTIFICATION_REPORT_ASSIGNED, "New assignment",
+		fmt.Sprintf("You have been assigned to the report on %s. Please accept or decline it.", report.RoadName), &report.ID)
+
+	return nil
+}
+
+// rankWorkers scores every worker with a profile for the report. Lower scores
+// are better; workers outside their base area, lacking the skill for the
+// damage class, unavailable, off shift or opted out of auto assignment are
+// ineligible.
+func (s *autoAssignService) rankWorkers(report *entity.Report, now time.Time) ([]dto.WorkerCandidateResponse, error) {
+	workers, err := s.userRepo.GetUsersByRole(entity.ROLE_WORKER)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles, err := s.workerRepo.GetWorkerProfiles()
+	if err != nil {
+		return nil, err
+	}
+	profileByWorker := make(map[uuid.UUID]entity.WorkerProfile, len(profiles))
+	for _, profile := range profiles {
+		profileByWorker[profile.WorkerID] = profile
+	}
+
+	workerIDs := make([]uuid.UUID, 0, len(workers))
+	for _, worker := range workers {
+		if _, ok := profileByWorker[worker.ID]; ok {
+			workerIDs = append(workerIDs, worker.ID)
+		}
+	}
+	availabilities, err := s.workerService.CheckAvailabilities(workerIDs, now)
+	if err != nil {
+		return nil, err
+	}
+
+	pings, err := s.locationRepo.GetLatestPings(now.Add(-locationStaleAfter))
+	if err != nil {
+		return nil, err
+	}
+	pingByWorker := make(map[uuid.UUID]entity.WorkerLocationPing, len(pings))
+	for _, ping := range pings {
+		pingByWorker[ping.WorkerID] = ping
+	}
+
+	candidates := []dto.WorkerCandidateResponse{}
+	for _, worker := range workers {
+		profile, ok := profileByWorker[worker.ID]
+		if !ok {
+			continue
+		}
+
+		availability := availabilities[worker.ID]
+		openJobs := availability.OpenJobs
+
+		distance := utils.HaversineKm(profile.BaseLatitude, profile.BaseLongitude, report.Latitude, report.Longitude)
+		candidate := dto.WorkerCandidateResponse{
+			WorkerID:   worker.ID,
+			WorkerName: worker.Fullname,
+			Eligible:   true,
+			DistanceKm: distance,
+			OpenJobs:   openJobs,
+		}
+
+		var reasons []string
+		if profile.BaseRadiusKm > 0 {
+			reasons = append(reasons, fmt.Sprintf("%.1f km from base (radius %.1f km)", distance, profile.BaseRadiusKm))
+			if distance > profile.BaseRadiusKm {
+				candidate.Eligible = false
+			}
+		} else {
+			reasons = append(reasons, fmt.Sprintf("%.1f km from base", distance))
+		}
+
+		// The base area decides eligibility, but a fresh live position is a
+		// better measure of how far the worker has to travel.
+		if ping, ok := pingByWorker[worker.ID]; ok {
+			candidate.DistanceKm = utils.HaversineKm(ping.Latitude, ping.Longitude, report.Latitude, report.Longitude)
+			reasons = append(reasons, fmt.Sprintf("%.1f km from live position", candidate.DistanceKm))
+		}
+		candidate.Score = candidate.DistanceKm*autoAssignDistanceWeight + float64(openJobs)*autoAssignWorkloadWeight
+		reasons = append(reasons, fmt.Sprintf("%d open job(s)", openJobs))
+
+		if !profile.HasSkill(report.DestructClass) {
+			candidate.Eligible = false
+			reasons = append(reasons, fmt.Sprintf("no skill for %q", report.DestructClass))
+		} else if report.DestructClass != "" && profile.Skills != "" {
+			reasons = append(reasons, fmt.Sprintf("skilled for %q", report.DestructClass))
+		}
+
+		if !availability.Available {
+			candidate.Eligible = false
+			reasons = append(reasons, availability.Reasons...)
+		}
+		if !availability.OnShift {
+			candidate.Eligible = false
+			reasons = append(reasons, availability.Warnings...)
+		}
+
+		if !profile.AutoAssign {
+			candidate.Eligible = false
+			reasons = append(reasons, "opted out of auto assignment")
+		}
+
+		candidate.Reasoning = strings.Join(reasons, "; ")
+		candidates = append(candidates, candidate)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Eligible != candidates[j].Eligible {
+			return candidates[i].Eligible
+		}
+		return candidates[i].Score < candidates[j].Score
+	})
+	return candidates, nil
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"dinacom-11.0-backend/models/dto"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/utils"
+)
+
+const (
+	// Roads further than geocodeSearchMeters are not suggested.
+	geocodeSearchMeters   = 100.0
+	geocodeCellDegrees    = 0.01
+	maxGeocodeSuggestions = 5
+)
+
+// roadNamePrefixes are dropped when comparing road names, so "Jl. Sudirman"
+// matches "Jalan Jenderal Sudirman".
+var roadNamePrefixes = map[string]bool{
+	"jalan":  true,
+	"jl":     true,
+	"jln":    true,
+	"street": true,
+	"st":     true,
+	"road":   true,
+	"rd":     true,
+}
+
+type GeocodeService interface {
+	LoadExtract() error
+	ReverseGeocode(lat, lng float64, roadName string) (*dto.ReverseGeocodeResponse, error)
+	CanonicalRoadName(lat, lng float64, roadName string) string
+}
+
+type geocodeRoad struct {
+	name    string
+	highway string
+}
+
+type geocodeService struct {
+	extractPath string
+	roads       []geocodeRoad
+	index       *utils.LineIndex
+	mutex       sync.RWMutex
+}
+
+func NewGeocodeService(extractPath string) GeocodeService {
+	return &geocodeService{extractPath: extractPath, index: utils.NewLineIndex(geocodeCellDegrees)}
+}
+
+// LoadExtract reads the named roads of the OSM PBF and GeoJSON files at the
+// extract path into memory. It runs at startup.
+func (s *geocodeService) LoadExtract() error {
+	if s.extractPath == "" {
+		return nil
+	}
+
+	pbfFiles, geojsonPath, err := s.extractFiles()
+	if err != nil {
+		return err
+	}
+
+	var roads []geocodeRoad
+	index := utils.NewLineIndex(geocodeCellDegrees)
+	add := func(name, highway string, lines [][][2]float64) {
+		if name = strings.TrimSpace(name); name != "" {
+			index.Add(lines)
+			roads = append(roads, geocodeRoad{name: name, highway: highway})
+		}
+	}
+
+	for _, file := range pbfFiles {
+		ways, err := utils.ReadOSMWays(file, func(tags map[string]string) bool {
+			return tags["highway"] != "" && tags["name"] != ""
+		})
+		if err != nil {
+			return err
+		}
+		for _, way := range ways {
+			add(way.Tags["name"], way.Tags["highway"], [][][2]float64{way.Line})
+		}
+	}
+
+	if geojsonPath != "" {
+		files, collections, err := utils.ReadFeatureCollections(geojsonPath)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			for _, feature := range collections[file].Features {
+				lines, err := parseRoadGeometry(&feature.Geometry)
+				if err != nil {
+					continue
+				}
+				name, _ := feature.Properties["name"].(string)
+				highway, _ := feature.Properties["highway"].(string)
+				add(name, highway, lines)
+			}
+		}
+	}
+
+	s.mutex.Lock()
+	s.roads, s.index = roads, index
+	s.mutex.Unlock()
+
+	utils.InfoLog("road name extract loaded", "roads", len(roads))
+	return nil
+}
+
+// extractFiles splits the extract path into the PBF files to read and the
+// path to read GeoJSON from, if any.
+func (s *geocodeService) extractFiles() ([]string, string, error) {
+	info, err := os.Stat(s.extractPath)
+	if err != nil {
+		return nil, "", err
+	}
+	if !info.IsDir() {
+		if strings.EqualFold(filepath.Ext(s.extractPath), ".pbf") {
+			return []string{s.extractPath}, "", nil
+		}
+		return nil, s.extractPath, nil
+	}
+
+	pbfFiles, err := filepath.Glob(filepath.Join(s.extractPath, "*.pbf"))
+	if err != nil {
+		return nil, "", err
+	}
+	sort.Strings(pbfFiles)
+	return pbfFiles, s.extractPath, nil
+}
+
+// ReverseGeocode suggests the names of the roads around a position, nearest
+// first. The road name is the suggestion matching the given name or else the
+// nearest road.
+func (s *geocodeService) ReverseGeocode(lat, lng float64, roadName string) (*dto.ReverseGeocodeResponse, error) {
+	if !validCoordinate(lat, lng) {
+		return nil, http_error.INVALID_COORDINATES
+	}
+
+	s.mutex.RLock()
+	loaded := len(s.roads) > 0
+	s.mutex.RUnlock()
+	if !loaded {
+		return nil, http_error.GEOCODER_NOT_LOADED
+	}
+
+	return s.reverseGeocode(lat, lng, roadName), nil
+}
+
+// CanonicalRoadName returns the road name to store next to the one the
+// citizen typed, or an empty string when no named road is nearby.
+func (s *geocodeService) CanonicalRoadName(lat, lng float64, roadName string) string {
+	if !validCoordinate(lat, lng) {
+		return ""
+	}
+	return s.reverseGeocode(lat, lng, roadName).RoadName
+}
+
+func (s *geocodeService) reverseGeocode(lat, lng float64, roadName string) *dto.ReverseGeocodeResponse {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	response := &dto.ReverseGeocodeResponse{Suggestions: []dto.RoadNameSuggestion{}}
+	seen := map[string]bool{}
+	for _, match := range s.index.Within(lat, lng, geocodeSearchMeters) {
+		road := s.roads[match.Index]
+		if seen[road.name] {
+			continue
+		}
+		seen[road.name] = true
+		response.Suggestions = append(response.Suggestions, dto.RoadNameSuggestion{
+			RoadName:       road.name,
+			Highway:        road.highway,
+			DistanceMeters: match.DistanceMeters,
+		})
+		if len(response.Suggestions) == maxGeocodeSuggestions {
+			break
+		}
+	}
+
+	if len(response.Suggestions) == 0 {
+		return response
+	}
+	response.RoadName = response.Suggestions[0].RoadName
+	for _, suggestion := range response.Suggestions {
+		if roadNameMatches(roadName, suggestion.RoadName) {
+			response.RoadName, response.Matched = suggestion.RoadName, true
+			break
+		}
+	}
+	return response
+}
+
+// roadNameMatches tells whether every word of the typed name, ignoring case,
+// punctuation and street prefixes, appears in the candidate.
+func roadNameMatches(typed, candidate string) bool {
+	typedWords := roadNameWords(typed)
+	if len(typedWords) == 0 {
+		return false
+	}
+
+	candidateWords := map[string]bool{}
+	for _, word := range roadNameWords(candidate) {
+		candidateWords[word] = true
+	}
+	for _, word := range typedWords {
+		if !candidateWords[word] {
+			return false
+		}
+	}
+	return true
+}
+
+func roadNameWords(name string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	words := fields[:0]
+	for _, field := range fields {
+		if !roadNamePrefixes[field] {
+			words = append(words, field)
+		}
+	}
+	return words
+}
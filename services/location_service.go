@@ -0,0 +1,250 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/repositories"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// A position older than locationStaleAfter is shown as stale and no longer
+// used for assignment or routing. Device clocks may run slightly ahead.
+const (
+	locationStaleAfter    = 15 * time.Minute
+	locationClockSkew     = 2 * time.Minute
+	maxLocationTrackPings = 2000
+)
+
+type LocationService interface {
+	StartShift(actx dto.AuditContext, workerID uuid.UUID, req dto.StartShiftRequest) (*dto.ShiftSessionResponse, error)
+	EndShift(actx dto.AuditContext, workerID uuid.UUID) (*dto.ShiftSessionResponse, error)
+	GetCurrentShift(workerID uuid.UUID) (*dto.ShiftSessionResponse, error)
+	RecordPings(workerID uuid.UUID, req dto.LocationBatchRequest) (*dto.LocationBatchResponse, error)
+	GetLatestPositions() ([]dto.WorkerPositionResponse, error)
+	GetTrack(workerID uuid.UUID, from, to time.Time) ([]dto.LocationPingResponse, error)
+	PurgeExpired()
+}
+
+type locationService struct {
+	locationRepo      repositories.LocationRepository
+	userRepo          repositories.UserRepository
+	auditService      AuditService
+	retention         time.Duration
+	maxPingsPerWorker int
+}
+
+func NewLocationService(locationRepo repositories.LocationRepository, userRepo repositories.UserRepository, auditService AuditService, retention time.Duration, maxPingsPerWorker int) LocationService {
+	return &locationService{
+		locationRepo:      locationRepo,
+		userRepo:          userRepo,
+		auditService:      auditService,
+		retention:         retention,
+		maxPingsPerWorker: maxPingsPerWorker,
+	}
+}
+
+func (s *locationService) StartShift(actx dto.AuditContext, workerID uuid.UUID, req dto.StartShiftRequest) (*dto.ShiftSessionResponse, error) {
+	if _, err := s.locationRepo.GetOpenSession(workerID); err == nil {
+		return nil, http_error.SHIFT_ALREADY_STARTED
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	session := &entity.WorkerShiftSession{
+		WorkerID:        workerID,
+		TrackingConsent: *req.TrackingConsent,
+		StartedAt:       time.Now(),
+	}
+	if err := s.locationRepo.CreateSession(session); err != nil {
+		// A concurrent start lost the race on the open session index.
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, http_error.SHIFT_ALREADY_STARTED
+		}
+		return nil, err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_SHIFT_START, entity.AUDIT_TARGET_USER, workerID.String(), map[string]interface{}{
+		"session_id":       session.ID,
+		"tracking_consent": session.TrackingConsent,
+	})
+
+	response := toShiftSessionResponse(*session)
+	return &response, nil
+}
+
+func (s *locationService) EndShift(actx dto.AuditContext, workerID uuid.UUID) (*dto.ShiftSessionResponse, error) {
+	session, err := s.locationRepo.GetOpenSession(workerID)
+	if err != nil {
+		return nil, http_error.SHIFT_NOT_STARTED
+	}
+
+	now := time.Now()
+	if err := s.locationRepo.EndSession(session.ID, now); err != nil {
+		return nil, err
+	}
+	session.EndedAt = &now
+
+	s.auditService.Record(actx, entity.AUDIT_SHIFT_END, entity.AUDIT_TARGET_USER, workerID.String(), map[string]interface{}{
+		"session_id": session.ID,
+	})
+
+	response := toShiftSessionResponse(*session)
+	return &response, nil
+}
+
+func (s *locationService) GetCurrentShift(workerID uuid.UUID) (*dto.ShiftSessionResponse, error) {
+	session, err := s.locationRepo.GetOpenSession(workerID)
+	if err != nil {
+		return nil, http_error.SHIFT_NOT_STARTED
+	}
+
+	response := toShiftSessionResponse(*session)
+	return &response, nil
+}
+
+func (s *locationService) RecordPings(workerID uuid.UUID, req dto.LocationBatchRequest) (*dto.LocationBatchResponse, error) {
+	session, err := s.locationRepo.GetOpenSession(workerID)
+	if err != nil {
+		return nil, http_error.SHIFT_NOT_STARTED
+	}
+	if !session.TrackingConsent {
+		return nil, http_error.TRACKING_NOT_ENABLED
+	}
+
+	latest := time.Now().Add(locationClockSkew)
+	pings := make([]entity.WorkerLocationPing, 0, len(req.Pings))
+	for _, ping := range req.Pings {
+		if ping.RecordedAt.Before(session.StartedAt) || ping.RecordedAt.After(latest) {
+			continue
+		}
+		pings = append(pings, entity.WorkerLocationPing{
+			WorkerID:   workerID,
+			SessionID:  session.ID,
+			Latitude:   *ping.Latitude,
+			Longitude:  *ping.Longitude,
+			AccuracyM:  ping.AccuracyM,
+			RecordedAt: ping.RecordedAt,
+		})
+	}
+
+	if len(pings) > 0 {
+		if err := s.locationRepo.CreatePings(pings); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.LocationBatchResponse{Accepted: len(pings), Rejected: len(req.Pings) - len(pings)}, nil
+}
+
+func (s *locationService) GetLatestPositions() ([]dto.WorkerPositionResponse, error) {
+	sessions, err := s.locationRepo.GetOpenSessions()
+	if err != nil {
+		return nil, err
+	}
+	pings, err := s.locationRepo.GetLatestPingsOfOpenSessions()
+	if err != nil {
+		return nil, err
+	}
+	pingBySession := make(map[uuid.UUID]entity.WorkerLocationPing, len(pings))
+	for _, ping := range pings {
+		pingBySession[ping.SessionID] = ping
+	}
+
+	workerIDs := make([]uuid.UUID, 0, len(sessions))
+	for _, session := range sessions {
+		if session.TrackingConsent {
+			workerIDs = append(workerIDs, session.WorkerID)
+		}
+	}
+	workers, err := s.userRepo.FindUsersByIDs(workerIDs)
+	if err != nil {
+		return nil, err
+	}
+	nameByWorker := make(map[uuid.UUID]string, len(workers))
+	for _, worker := range workers {
+		nameByWorker[worker.ID] = worker.Fullname
+	}
+
+	now := time.Now()
+	response := []dto.WorkerPositionResponse{}
+	for _, session := range sessions {
+		if !session.TrackingConsent {
+			continue
+		}
+
+		position := dto.WorkerPositionResponse{
+			WorkerID:       session.WorkerID,
+			SessionID:      session.ID,
+			ShiftStartedAt: session.StartedAt,
+			WorkerName:     nameByWorker[session.WorkerID],
+			Stale:          true,
+		}
+		if ping, ok := pingBySession[session.ID]; ok {
+			position.Latitude = &ping.Latitude
+			position.Longitude = &ping.Longitude
+			position.AccuracyM = &ping.AccuracyM
+			position.RecordedAt = &ping.RecordedAt
+			position.Stale = now.Sub(ping.RecordedAt) > locationStaleAfter
+		}
+		response = append(response, position)
+	}
+	return response, nil
+}
+
+func (s *locationService) GetTrack(workerID uuid.UUID, from, to time.Time) ([]dto.LocationPingResponse, error) {
+	if to.Before(from) {
+		return nil, http_error.INVALID_DATE_RANGE
+	}
+
+	pings, err := s.locationRepo.GetPings(workerID, from, to, maxLocationTrackPings)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.LocationPingResponse, 0, len(pings))
+	for _, ping := range pings {
+		response = append(response, dto.LocationPingResponse{
+			Latitude:   ping.Latitude,
+			Longitude:  ping.Longitude,
+			AccuracyM:  ping.AccuracyM,
+			RecordedAt: ping.RecordedAt,
+		})
+	}
+	return response, nil
+}
+
+// PurgeExpired is run by the scheduler and enforces the retention limits.
+func (s *locationService) PurgeExpired() {
+	expired, err := s.locationRepo.DeletePingsBefore(time.Now().Add(-s.retention))
+	if err != nil {
+		utils.InternalErrorLog(err, "job", "location_retention")
+		return
+	}
+	var trimmed int64
+	if s.maxPingsPerWorker > 0 {
+		if trimmed, err = s.locationRepo.TrimPings(s.maxPingsPerWorker); err != nil {
+			utils.InternalErrorLog(err, "job", "location_retention")
+			return
+		}
+	}
+	if expired > 0 || trimmed > 0 {
+		utils.InfoLog("location pings purged", "expired", expired, "trimmed", trimmed)
+	}
+}
+
+func toShiftSessionResponse(session entity.WorkerShiftSession) dto.ShiftSessionResponse {
+	return dto.ShiftSessionResponse{
+		ID:              session.ID,
+		WorkerID:        session.WorkerID,
+		TrackingConsent: session.TrackingConsent,
+		StartedAt:       session.StartedAt,
+		EndedAt:         session.EndedAt,
+	}
+}
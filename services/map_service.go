@@ -0,0 +1,251 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/repositories"
+	"dinacom-11.0-backend/utils"
+)
+
+const (
+	// Tiles are rendered from the same search as the map list, so a tile
+	// holds at most tileReportLimit reports.
+	tileReportLimit = 2000
+	tileLayerName   = "reports"
+	maxTileZoom     = 22
+
+	// Rendered tiles are kept for tileCacheTTL; once tileCacheSize tiles are
+	// cached, expired ones are dropped and, if still full, the whole cache.
+	tileCacheTTL  = 5 * time.Minute
+	tileCacheSize = 2048
+
+	// Clusters and heatmaps are aggregated per tile on a pixel grid, so
+	// their cell sizes must divide the 256 pixel tile. A request may cover
+	// at most maxAggregateTiles tiles.
+	clusterCellPixels = 64
+	heatmapCellPixels = 16
+	tilePixels        = 256
+	maxAggregateTiles = 64
+)
+
+type MapService interface {
+	GetReportsGeoJSON(req dto.ReportSearchRequest) ([]byte, error)
+	GetTile(z, x, y int) ([]byte, error)
+	GetClusters(req dto.ReportAggregateRequest) (*dto.ReportClustersResponse, error)
+	GetHeatmap(req dto.ReportAggregateRequest) (*dto.HeatmapResponse, error)
+}
+
+type cachedTile struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+type mapService struct {
+	reportRepo    repositories.ReportRepository
+	reportService ReportService
+	tiles         map[string]cachedTile
+	mutex         sync.RWMutex
+}
+
+func NewMapService(reportRepo repositories.ReportRepository, reportService ReportService) MapService {
+	return &mapService{
+		reportRepo:    reportRepo,
+		reportService: reportService,
+		tiles:         make(map[string]cachedTile),
+	}
+}
+
+// GetReportsGeoJSON returns the public map reports matching the search as an
+// encoded GeoJSON FeatureCollection of points.
+func (s *mapService) GetReportsGeoJSON(req dto.ReportSearchRequest) ([]byte, error) {
+	result, err := s.reportService.SearchReports(req)
+	if err != nil {
+		return nil, err
+	}
+
+	features := make([]dto.GeoJSONFeature, 0, len(result.Reports))
+	for _, report := range result.Reports {
+		features = append(features, dto.GeoJSONFeature{
+			Type:       "Feature",
+			ID:         report.ID,
+			Geometry:   dto.GeoJSONGeometry{Type: "Point", Coordinates: []float64{report.Longitude, report.Latitude}},
+			Properties: reportProperties(report),
+		})
+	}
+
+	return json.Marshal(dto.GeoJSONFeatureCollection{Type: "FeatureCollection", Features: features})
+}
+
+// GetTile renders the public map reports inside a web mercator tile as a
+// Mapbox Vector Tile with a single "reports" point layer.
+func (s *mapService) GetTile(z, x, y int) ([]byte, error) {
+	if z < 0 || z > maxTileZoom || x < 0 || y < 0 || x >= 1<<z || y >= 1<<z {
+		return nil, http_error.INVALID_TILE
+	}
+
+	key := fmt.Sprintf("mvt/%d/%d/%d", z, x, y)
+	if cached, ok := s.cachedTile(key); ok {
+		return cached.([]byte), nil
+	}
+
+	minLat, minLng, maxLat, maxLng := utils.TileBounds(z, x, y)
+	zoom := z
+	result, err := s.reportService.SearchReports(dto.ReportSearchRequest{
+		BBox:  []float64{minLng, minLat, maxLng, maxLat},
+		Zoom:  &zoom,
+		Limit: tileReportLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]utils.MVTPoint, 0, len(result.Reports))
+	for _, report := range result.Reports {
+		points = append(points, utils.MVTPoint{Latitude: report.Latitude, Longitude: report.Longitude, Properties: reportProperties(report)})
+	}
+	data := utils.EncodeMVTPoints(tileLayerName, z, x, y, points)
+
+	s.storeTile(key, data)
+	return data, nil
+}
+
+// GetClusters merges nearby public map reports in the bbox into clusters of a
+// 64 pixel grid at the zoom level.
+func (s *mapService) GetClusters(req dto.ReportAggregateRequest) (*dto.ReportClustersResponse, error) {
+	cells, err := s.aggregate(req, clusterCellPixels)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ReportClustersResponse{Zoom: req.Zoom, Clusters: make([]dto.ReportClusterResponse, 0, len(cells))}
+	for _, cell := range cells {
+		response.Total += cell.Count
+		response.Clusters = append(response.Clusters, dto.ReportClusterResponse{
+			ID:            cell.ReportID,
+			Latitude:      cell.CenterLat,
+			Longitude:     cell.CenterLng,
+			Count:         cell.Count,
+			MaxScore:      cell.MaxScore,
+			DestructClass: cell.MaxClass,
+		})
+	}
+	return response, nil
+}
+
+// GetHeatmap sums the total scores of the public map reports in the bbox on a
+// 16 pixel grid at the zoom level.
+func (s *mapService) GetHeatmap(req dto.ReportAggregateRequest) (*dto.HeatmapResponse, error) {
+	cells, err := s.aggregate(req, heatmapCellPixels)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.HeatmapResponse{Zoom: req.Zoom, CellPixels: heatmapCellPixels, Cells: make([]dto.HeatmapCellResponse, 0, len(cells))}
+	for _, cell := range cells {
+		response.MaxWeight = math.Max(response.MaxWeight, cell.TotalScore)
+		response.Cells = append(response.Cells, dto.HeatmapCellResponse{
+			Latitude:  cell.CenterLat,
+			Longitude: cell.CenterLng,
+			Count:     cell.Count,
+			Weight:    cell.TotalScore,
+		})
+	}
+	return response, nil
+}
+
+// aggregate collects the grid cells of every tile covering the bbox, each
+// tile's cells coming from the cache when possible, and keeps the cells
+// centred inside the bbox.
+func (s *mapService) aggregate(req dto.ReportAggregateRequest, cellPixels int) ([]repositories.ReportGridCell, error) {
+	if req.Zoom < 0 || req.Zoom > maxTileZoom {
+		return nil, http_error.INVALID_ZOOM
+	}
+	if req.BBox == nil {
+		return nil, http_error.INVALID_BBOX
+	}
+	if _, _, err := buildSpatialFilter(dto.ReportSearchRequest{BBox: req.BBox}); err != nil {
+		return nil, err
+	}
+	minLng, minLat, maxLng, maxLat := req.BBox[0], req.BBox[1], req.BBox[2], req.BBox[3]
+
+	minX, minY, maxX, maxY := utils.TileRange(req.Zoom, minLat, minLng, maxLat, maxLng)
+	if (maxX-minX+1)*(maxY-minY+1) > maxAggregateTiles {
+		return nil, http_error.AGGREGATE_AREA_TOO_LARGE
+	}
+
+	cellsAcross := math.Pow(2, float64(req.Zoom)) * tilePixels / float64(cellPixels)
+	var result []repositories.ReportGridCell
+	for x := minX; x <= maxX; x++ {
+		for y := minY; y <= maxY; y++ {
+			key := fmt.Sprintf("grid%d/%d/%d/%d", cellPixels, req.Zoom, x, y)
+			cells, ok := s.cachedTile(key)
+			if !ok {
+				tileMinLat, tileMinLng, tileMaxLat, tileMaxLng := utils.TileBounds(req.Zoom, x, y)
+				filter, _, err := buildSpatialFilter(dto.ReportSearchRequest{BBox: []float64{tileMinLng, tileMinLat, tileMaxLng, tileMaxLat}})
+				if err != nil {
+					return nil, err
+				}
+				if cells, err = s.reportRepo.AggregateReports(filter, cellsAcross); err != nil {
+					return nil, err
+				}
+				s.storeTile(key, cells)
+			}
+
+			for _, cell := range cells.([]repositories.ReportGridCell) {
+				if cell.CenterLat >= minLat && cell.CenterLat <= maxLat && cell.CenterLng >= minLng && cell.CenterLng <= maxLng {
+					result = append(result, cell)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s *mapService) cachedTile(key string) (interface{}, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	cached, ok := s.tiles[key]
+	if !ok || time.Now().After(cached.expiresAt) {
+		return nil, false
+	}
+	return cached.value, true
+}
+
+func (s *mapService) storeTile(key string, value interface{}) {
+	now := time.Now()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.tiles) >= tileCacheSize {
+		for k, tile := range s.tiles {
+			if now.After(tile.expiresAt) {
+				delete(s.tiles, k)
+			}
+		}
+		if len(s.tiles) >= tileCacheSize {
+			s.tiles = make(map[string]cachedTile)
+		}
+	}
+	s.tiles[key] = cachedTile{value: value, expiresAt: now.Add(tileCacheTTL)}
+}
+
+func reportProperties(report dto.ReportLocationResponse) map[string]interface{} {
+	properties := map[string]interface{}{
+		"id":        report.ID,
+		"class":     report.DestructClass,
+		"score":     report.TotalScore,
+		"status":    report.Status,
+		"road_name": report.RoadName,
+	}
+	if report.Count > 0 {
+		properties["count"] = report.Count
+	}
+	return properties
+}
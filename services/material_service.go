@@ -0,0 +1,269 @@
+package services
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/repositories"
+
+	"github.com/google/uuid"
+)
+
+type MaterialService interface {
+	GetMaterials(activeOnly bool) ([]dto.MaterialResponse, error)
+	CreateMaterial(actx dto.AuditContext, req dto.MaterialRequest) (*dto.MaterialResponse, error)
+	UpdateMaterial(actx dto.AuditContext, id uuid.UUID, req dto.MaterialRequest) (*dto.MaterialResponse, error)
+	DeleteMaterial(actx dto.AuditContext, id uuid.UUID) error
+	BuildUsage(reportID string, workerID uuid.UUID, usage []dto.MaterialUsageRequest) ([]entity.ReportMaterial, error)
+	GetReportCost(reportID string) (*dto.ReportCostResponse, error)
+	GetCostReport(groupBy string, from, to *time.Time, regionCodes []string) ([]dto.MaterialCostGroupResponse, error)
+}
+
+type materialService struct {
+	materialRepo repositories.MaterialRepository
+	reportRepo   repositories.ReportRepository
+	auditService AuditService
+}
+
+func NewMaterialService(materialRepo repositories.MaterialRepository, reportRepo repositories.ReportRepository, auditService AuditService) MaterialService {
+	return &materialService{
+		materialRepo: materialRepo,
+		reportRepo:   reportRepo,
+		auditService: auditService,
+	}
+}
+
+func (s *materialService) GetMaterials(activeOnly bool) ([]dto.MaterialResponse, error) {
+	materials, err := s.materialRepo.GetMaterials(activeOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.MaterialResponse{}
+	for _, material := range materials {
+		response = append(response, toMaterialResponse(material))
+	}
+	return response, nil
+}
+
+func (s *materialService) CreateMaterial(actx dto.AuditContext, req dto.MaterialRequest) (*dto.MaterialResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if _, err := s.materialRepo.GetMaterialByName(name); err == nil {
+		return nil, http_error.MATERIAL_NAME_TAKEN
+	}
+
+	material := &entity.Material{
+		Name:     name,
+		Unit:     strings.TrimSpace(req.Unit),
+		UnitCost: req.UnitCost,
+		Active:   req.Active == nil || *req.Active,
+	}
+	if err := s.materialRepo.CreateMaterial(material); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_MATERIAL_CREATE, entity.AUDIT_TARGET_MATERIAL, material.ID.String(), map[string]interface{}{
+		"name":      material.Name,
+		"unit":      material.Unit,
+		"unit_cost": material.UnitCost,
+	})
+
+	response := toMaterialResponse(*material)
+	return &response, nil
+}
+
+// UpdateMaterial changes a catalogue entry. Costs already recorded keep the
+// unit cost they were recorded with.
+func (s *materialService) UpdateMaterial(actx dto.AuditContext, id uuid.UUID, req dto.MaterialRequest) (*dto.MaterialResponse, error) {
+	material, err := s.materialRepo.GetMaterialByID(id)
+	if err != nil {
+		return nil, http_error.MATERIAL_NOT_FOUND
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if existing, err := s.materialRepo.GetMaterialByName(name); err == nil && existing.ID != id {
+		return nil, http_error.MATERIAL_NAME_TAKEN
+	}
+
+	previousCost := material.UnitCost
+	material.Name = name
+	material.Unit = strings.TrimSpace(req.Unit)
+	material.UnitCost = req.UnitCost
+	if req.Active != nil {
+		material.Active = *req.Active
+	}
+
+	if err := s.materialRepo.UpdateMaterial(material); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_MATERIAL_UPDATE, entity.AUDIT_TARGET_MATERIAL, material.ID.String(), map[string]interface{}{
+		"name":               material.Name,
+		"unit":               material.Unit,
+		"previous_unit_cost": previousCost,
+		"unit_cost":          material.UnitCost,
+		"active":             material.Active,
+	})
+
+	response := toMaterialResponse(*material)
+	return &response, nil
+}
+
+// DeleteMaterial removes a material that was never used. Used materials are
+// kept for the cost history and can only be deactivated.
+func (s *materialService) DeleteMaterial(actx dto.AuditContext, id uuid.UUID) error {
+	used, err := s.materialRepo.CountUsage(id)
+	if err != nil {
+		return err
+	}
+	if used > 0 {
+		return http_error.MATERIAL_IN_USE
+	}
+
+	deleted, err := s.materialRepo.DeleteMaterial(id)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return http_error.MATERIAL_NOT_FOUND
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_MATERIAL_DELETE, entity.AUDIT_TARGET_MATERIAL, id.String(), nil)
+	return nil
+}
+
+// BuildUsage turns the quantities a worker reports into usage rows priced at
+// the current catalogue cost. Only active materials can be recorded.
+func (s *materialService) BuildUsage(reportID string, workerID uuid.UUID, usage []dto.MaterialUsageRequest) ([]entity.ReportMaterial, error) {
+	if len(usage) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(usage))
+	for _, item := range usage {
+		if item.Quantity <= 0 {
+			return nil, http_error.INVALID_MATERIAL_QUANTITY
+		}
+		ids = append(ids, item.MaterialID)
+	}
+
+	materials, err := s.materialRepo.GetMaterialsByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]entity.Material, len(materials))
+	for _, material := range materials {
+		byID[material.ID] = material
+	}
+
+	rows := make([]entity.ReportMaterial, 0, len(usage))
+	for _, item := range usage {
+		material, ok := byID[item.MaterialID]
+		if !ok {
+			return nil, http_error.MATERIAL_NOT_FOUND
+		}
+		if !material.Active {
+			return nil, http_error.MATERIAL_INACTIVE
+		}
+
+		rows = append(rows, entity.ReportMaterial{
+			ReportID:   reportID,
+			MaterialID: material.ID,
+			Quantity:   item.Quantity,
+			UnitCost:   material.UnitCost,
+			Cost:       roundCost(item.Quantity * material.UnitCost),
+			RecordedBy: workerID,
+		})
+	}
+	return rows, nil
+}
+
+func (s *materialService) GetReportCost(reportID string) (*dto.ReportCostResponse, error) {
+	if _, err := s.reportRepo.GetReportByID(reportID); err != nil {
+		return nil, http_error.REPORT_NOT_FOUND
+	}
+
+	usage, err := s.materialRepo.GetReportMaterials(reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ReportCostResponse{ReportID: reportID, Materials: []dto.MaterialUsageResponse{}}
+	for _, item := range usage {
+		line := dto.MaterialUsageResponse{
+			MaterialID: item.MaterialID,
+			Quantity:   item.Quantity,
+			UnitCost:   item.UnitCost,
+			Cost:       item.Cost,
+			RecordedBy: item.RecordedBy,
+			CreatedAt:  item.CreatedAt,
+		}
+		if item.Material != nil {
+			line.MaterialName = item.Material.Name
+			line.Unit = item.Material.Unit
+		}
+		response.Materials = append(response.Materials, line)
+		response.TotalCost += item.Cost
+	}
+	response.TotalCost = roundCost(response.TotalCost)
+	return response, nil
+}
+
+// GetCostReport totals the material cost per road, district or month of use.
+func (s *materialService) GetCostReport(groupBy string, from, to *time.Time, regionCodes []string) ([]dto.MaterialCostGroupResponse, error) {
+	if groupBy != entity.COST_GROUP_ROAD && groupBy != entity.COST_GROUP_DISTRICT && groupBy != entity.COST_GROUP_MONTH {
+		return nil, http_error.INVALID_COST_GROUP
+	}
+
+	stats, err := s.materialRepo.GetCostStats(groupBy, from, to, regionCodes)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := s.materialRepo.CountCostReports(groupBy, from, to, regionCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.MaterialCostGroupResponse{}
+	for _, stat := range stats {
+		if len(response) == 0 || response[len(response)-1].Group != stat.GroupKey {
+			response = append(response, dto.MaterialCostGroupResponse{
+				Group:     stat.GroupKey,
+				Reports:   reports[stat.GroupKey],
+				Materials: []dto.MaterialCostLine{},
+			})
+		}
+
+		group := &response[len(response)-1]
+		group.Materials = append(group.Materials, dto.MaterialCostLine{
+			MaterialID:   stat.MaterialID,
+			MaterialName: stat.MaterialName,
+			Unit:         stat.Unit,
+			Quantity:     stat.Quantity,
+			Cost:         roundCost(stat.Cost),
+		})
+		group.TotalCost = roundCost(group.TotalCost + stat.Cost)
+	}
+	return response, nil
+}
+
+func toMaterialResponse(material entity.Material) dto.MaterialResponse {
+	return dto.MaterialResponse{
+		ID:        material.ID,
+		Name:      material.Name,
+		Unit:      material.Unit,
+		UnitCost:  material.UnitCost,
+		Active:    material.Active,
+		CreatedAt: material.CreatedAt,
+		UpdatedAt: material.UpdatedAt,
+	}
+}
+
+// roundCost rounds a cost to two decimals.
+func roundCost(cost float64) float64 {
+	return math.Round(cost*100) / 100
+}
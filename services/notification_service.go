@@ -0,0 +1,128 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/repositories"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/google/uuid"
+)
+
+type NotificationService interface {
+	Notify(userID uuid.UUID, notificationType, title, message string, reportID *string)
+	NotifyRole(role string, notificationType, title, message string, reportID *string)
+	GetUserNotifications(userID uuid.UUID, page, limit int) (*dto.PaginatedNotificationsResponse, error)
+	MarkAsRead(userID uuid.UUID, id uuid.UUID) error
+	MarkAllAsRead(userID uuid.UUID) error
+}
+
+type notificationService struct {
+	notificationRepo repositories.NotificationRepository
+	userRepo         repositories.UserRepository
+}
+
+func NewNotificationService(notificationRepo repositories.NotificationRepository, userRepo repositories.UserRepository) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+	}
+}
+
+// Notify stores an in-app notification and mails a copy to the user in the
+// background. Like auditing, a failed notification never fails the caller.
+func (s *notificationService) Notify(userID uuid.UUID, notificationType, title, message string, reportID *string) {
+	notification := &entity.Notification{
+		UserID:   userID,
+		Type:     notificationType,
+		Title:    title,
+		Message:  message,
+		ReportID: reportID,
+	}
+
+	if err := s.notificationRepo.CreateNotification(notification); err != nil {
+		utils.InternalErrorLog(err, "notification_type", notificationType)
+		return
+	}
+
+	go func() {
+		user, err := s.userRepo.FindUserByID(userID)
+		if err != nil || user == nil || user.Email == "" {
+			return
+		}
+		if err := utils.SendEmail(user.Email, title, fmt.Sprintf("Hello %s,\n\n%s\n\nBest regards,\nDinacom Team\n", user.Fullname, message)); err != nil {
+			utils.InternalErrorLog(err, "notification_type", notificationType)
+		}
+	}()
+}
+
+func (s *notificationService) NotifyRole(role string, notificationType, title, message string, reportID *string) {
+	users, err := s.userRepo.GetUsersByRole(role)
+	if err != nil {
+		utils.InternalErrorLog(err, "notification_type", notificationType)
+		return
+	}
+
+	for _, user := range users {
+		s.Notify(user.ID, notificationType, title, message, reportID)
+	}
+}
+
+func (s *notificationService) GetUserNotifications(userID uuid.UUID, page, limit int) (*dto.PaginatedNotificationsResponse, error) {
+	offset := (page - 1) * limit
+	notifications, total, err := s.notificationRepo.GetNotificationsByUserID(userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []dto.NotificationResponse
+	for _, n := range notifications {
+		response = append(response, dto.NotificationResponse{
+			ID:        n.ID,
+			Type:      n.Type,
+			Title:     n.Title,
+			Message:   n.Message,
+			ReportID:  n.ReportID,
+			ReadAt:    n.ReadAt,
+			CreatedAt: n.CreatedAt,
+		})
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit != 0 {
+		totalPages++
+	}
+
+	return &dto.PaginatedNotificationsResponse{
+		Notifications: response,
+		UnreadCount:   unread,
+		TotalCount:    total,
+		Page:          page,
+		Limit:         limit,
+		TotalPages:    totalPages,
+	}, nil
+}
+
+func (s *notificationService) MarkAsRead(userID uuid.UUID, id uuid.UUID) error {
+	affected, err := s.notificationRepo.MarkAsRead(userID, id, time.Now())
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return http_error.NOTIFICATION_NOT_FOUND
+	}
+	return nil
+}
+
+func (s *notificationService) MarkAllAsRead(userID uuid.UUID) error {
+	return s.notificationRepo.MarkAllAsRead(userID, time.Now())
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	"dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/repositories"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// GeoReport v2 lists at most 1000 requests and, without dates, those of
+	// the last 90 days.
+	open311RequestLimit   = 1000
+	open311DefaultHistory = 90 * 24 * time.Hour
+)
+
+// Open311Service exposes reports as GeoReport v2 service requests. Every
+// damage class is a service, next to a generic one for reports that were not
+// classified yet.
+type Open311Service interface {
+	GetServices() ([]dto.Open311Service, error)
+	CreateRequest(apiKeyID uuid.UUID, req dto.Open311CreateRequest) (*dto.Open311CreateResponse, error)
+	GetRequests(query dto.Open311RequestsQuery) ([]dto.Open311ServiceRequest, error)
+	GetRequest(serviceRequestID string) (*dto.Open311ServiceRequest, error)
+	GetToken(token string) (*dto.Open311Token, error)
+}
+
+type open311Service struct {
+	reportRepo    repositories.ReportRepository
+	reportService ReportService
+}
+
+func NewOpen311Service(reportRepo repositories.ReportRepository, reportService ReportService) Open311Service {
+	return &open311Service{
+		reportRepo:    reportRepo,
+		reportService: reportService,
+	}
+}
+
+func (s *open311Service) GetServices() ([]dto.Open311Service, error) {
+	classes, err := s.reportRepo.GetDamageClasses()
+	if err != nil {
+		return nil, err
+	}
+
+	services := []dto.Open311Service{{
+		ServiceCode: entity.OPEN311_SERVICE_ROAD_DAMAGE,
+		ServiceName: open311ServiceName(entity.OPEN311_SERVICE_ROAD_DAMAGE),
+		Description: "Damage to a road that was not classified yet",
+		Type:        "realtime",
+		Keywords:    "road, damage",
+		Group:       "Roads",
+	}}
+	for _, class := range classes {
+		name := open311ServiceName(class)
+		services = append(services, dto.Open311Service{
+			ServiceCode: class,
+			ServiceName: name,
+			Description: "Road damage classified as " + strings.ToLower(name),
+			Type:        "realtime",
+			Keywords:    "road, damage, " + strings.ToLower(name),
+			Group:       "Roads",
+		})
+	}
+	return services, nil
+}
+
+// CreateRequest files a pending report sent with the client's API key. A service
+// other than the generic one is kept as the requested class; the report is
+// still classified as usual.
+func (s *open311Service) CreateRequest(apiKeyID uuid.UUID, req dto.Open311CreateRequest) (*dto.Open311CreateResponse, error) {
+	if req.Lat == nil || req.Long == nil {
+		return nil, http_error.OPEN311_LOCATION_REQUIRED
+	}
+
+	requestedClass := ""
+	if req.ServiceCode != entity.OPEN311_SERVICE_ROAD_DAMAGE {
+		classes, err := s.reportRepo.GetDamageClasses()
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for _, class := range classes {
+			found = found || class == req.ServiceCode
+		}
+		if !found {
+			return nil, http_error.OPEN311_SERVICE_NOT_FOUND
+		}
+		requestedClass = req.ServiceCode
+	}
+
+	mediaURL := strings.TrimSpace(req.MediaURL)
+	if mediaURL != "" {
+		parsed, err := url.Parse(mediaURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, http_error.INVALID_MEDIA_URL
+		}
+	}
+
+	report, err := s.reportService.CreateExternalReport(apiKeyID, mediaURL, requestedClass, dto.ReportRequest{
+		Latitude:    *req.Lat,
+		Longitude:   *req.Long,
+		RoadName:    req.AddressString,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.Open311CreateResponse{
+		ServiceRequestID: report.ID,
+		Token:            open311Token(report.ID),
+		AccountID:        req.AccountID,
+	}, nil
+}
+
+func (s *open311Service) GetRequests(query dto.Open311RequestsQuery) ([]dto.Open311ServiceRequest, error) {
+	filter := repositories.ServiceRequestFilter{
+		IDs:         query.ServiceRequestIDs,
+		ServiceCode: query.ServiceCode,
+		From:        query.StartDate,
+		To:          query.EndDate,
+	}
+	switch query.Status {
+	case "":
+	case entity.OPEN311_STATUS_OPEN, entity.OPEN311_STATUS_CLOSED:
+		open := query.Status == entity.OPEN311_STATUS_OPEN
+		filter.Open = &open
+	default:
+		return nil, http_error.INVALID_OPEN311_STATUS
+	}
+	if filter.From == nil && filter.To == nil {
+		from := time.Now().Add(-open311DefaultHistory)
+		filter.From = &from
+	}
+
+	reports, err := s.reportRepo.GetServiceRequests(filter, open311RequestLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.Open311ServiceRequest{}
+	for _, report := range reports {
+		response = append(response, toOpen311ServiceRequest(report))
+	}
+	return response, nil
+}
+
+func (s *open311Service) GetRequest(serviceRequestID string) (*dto.Open311ServiceRequest, error) {
+	report, err := s.reportRepo.GetReportByID(serviceRequestID)
+	if err != nil {
+		return nil, http_error.REPORT_NOT_FOUND
+	}
+
+	response := toOpen311ServiceRequest(*report)
+	return &response, nil
+}
+
+func (s *open311Service) GetToken(token string) (*dto.Open311Token, error) {
+	if _, err := uuid.Parse(token); err != nil {
+		return nil, http_error.INVALID_OPEN311_TOKEN
+	}
+	report, err := s.reportRepo.GetReportByToken(token)
+	if err != nil {
+		return nil, http_error.INVALID_OPEN311_TOKEN
+	}
+
+	return &dto.Open311Token{ServiceRequestID: report.ID, Token: token}, nil
+}
+
+// open311Token is the random part a report ID starts with, handed out as the
+// token of a new service request.
+func open311Token(reportID string) string {
+	token, _, _ := strings.Cut(reportID, "_")
+	return token
+}
+
+// open311ServiceCode mirrors the service code filter of GetServiceRequests.
+func open311ServiceCode(report *entity.Report) string {
+	if report.DestructClass != "" && report.DestructClass != entity.DESTRUCT_CLASS_GOOD {
+		return report.DestructClass
+	}
+	if report.RequestedClass != "" {
+		return report.RequestedClass
+	}
+	return entity.OPEN311_SERVICE_ROAD_DAMAGE
+}
+
+// open311ServiceName turns a class code such as "road_damage" into "Road damage".
+func open311ServiceName(code string) string {
+	name := strings.TrimSpace(strings.NewReplacer("_", " ", "-", " ").Replace(code))
+	if name == "" {
+		return code
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// open311Status maps our statuses to open and closed. Rejected, repaired and
+// forwarded reports are closed; the notes say which.
+func open311Status(report *entity.Report) (string, string) {
+	if report.ForwardedAt != nil {
+		note := "Forwarded to the " + report.ForwardedTo + " road authority"
+		if report.ForwardNote != "" {
+			note += ": " + report.ForwardNote
+		}
+		return entity.OPEN311_STATUS_CLOSED, note
+	}
+
+	switch report.Status {
+	case entity.STATUS_PENDING:
+		return entity.OPEN311_STATUS_OPEN, "Waiting for review"
+	case entity.STATUS_COMPLETED:
+		return entity.OPEN311_STATUS_OPEN, "Reviewed, waiting for a repair crew"
+	case entity.STATUS_ASSIGNED:
+		return entity.OPEN311_STATUS_OPEN, "Assigned to a repair crew"
+	case entity.STATUS_FINISH_BY_WORKER:
+		return entity.OPEN311_STATUS_OPEN, "Repaired, waiting for verification"
+	case entity.STATUS_REJECTED:
+		note := entity.REJECT_REASONS[report.RejectReason]
+		if report.RejectNote != "" {
+			note = strings.TrimPrefix(note+": "+report.RejectNote, ": ")
+		}
+		return entity.OPEN311_STATUS_CLOSED, note
+	default:
+		return entity.OPEN311_STATUS_CLOSED, "Repaired"
+	}
+}
+
+func toOpen311ServiceRequest(report entity.Report) dto.Open311ServiceRequest {
+	status, notes := open311Status(&report)
+	code := open311ServiceCode(&report)
+
+	agency := report.RoadAuthority
+	if report.ForwardedAt != nil {
+		agency = report.ForwardedTo
+	}
+
+	updated := report.CreatedAt
+	for _, at := range []*time.Time{report.WorkStageAt, report.FinishedAt, report.RejectedAt, report.ForwardedAt} {
+		if at != nil && at.After(updated) {
+			updated = *at
+		}
+	}
+
+	request := dto.Open311ServiceRequest{
+		ServiceRequestID:  report.ID,
+		Status:            status,
+		StatusNotes:       notes,
+		ServiceName:       open311ServiceName(code),
+		ServiceCode:       code,
+		Description:       report.Description,
+		AgencyResponsible: agency,
+		RequestedDatetime: report.CreatedAt.Format(time.RFC3339),
+		UpdatedDatetime:   updated.Format(time.RFC3339),
+		Address:           report.RoadName,
+		Lat:               report.Latitude,
+		Long:              report.Longitude,
+		MediaURL:          report.BeforeImageURL,
+	}
+	if report.Deadline != nil {
+		request.ExpectedDatetime = report.Deadline.Format(time.RFC3339)
+	}
+	return request
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	entity "dinacom-11.0-backend/models/entity"
+)
+
+func TestOpen311Status(t *testing.T) {
+	forwardedAt := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		report     entity.Report
+		wantStatus string
+		wantNotes  string
+	}{
+		{"pending", entity.Report{Status: entity.STATUS_PENDING}, entity.OPEN311_STATUS_OPEN, "Waiting for review"},
+		{"classified", entity.Report{Status: entity.STATUS_COMPLETED}, entity.OPEN311_STATUS_OPEN, "Reviewed, waiting for a repair crew"},
+		{"assigned", entity.Report{Status: entity.STATUS_ASSIGNED}, entity.OPEN311_STATUS_OPEN, "Assigned to a repair crew"},
+		{"finished by the worker", entity.Report{Status: entity.STATUS_FINISH_BY_WORKER}, entity.OPEN311_STATUS_OPEN, "Repaired, waiting for verification"},
+		{"verified", entity.Report{Status: entity.STATUS_FINISHED}, entity.OPEN311_STATUS_CLOSED, "Repaired"},
+		{
+			"rejected with a reason",
+			entity.Report{Status: entity.STATUS_REJECTED, RejectReason: entity.REJECT_REASON_DUPLICATE},
+			entity.OPEN311_STATUS_CLOSED, "Duplicate of an existing report",
+		},
+		{
+			"rejected with a reason and note",
+			entity.Report{Status: entity.STATUS_REJECTED, RejectReason: entity.REJECT_REASON_DUPLICATE, RejectNote: "See the report filed yesterday"},
+			entity.OPEN311_STATUS_CLOSED, "Duplicate of an existing report: See the report filed yesterday",
+		},
+		{
+			"rejected with only a note",
+			entity.Report{Status: entity.STATUS_REJECTED, RejectNote: "Private road"},
+			entity.OPEN311_STATUS_CLOSED, "Private road",
+		},
+		{
+			"forwarded",
+			entity.Report{Status: entity.STATUS_COMPLETED, ForwardedAt: &forwardedAt, ForwardedTo: entity.ROAD_AUTHORITY_NATIONAL},
+			entity.OPEN311_STATUS_CLOSED, "Forwarded to the " + entity.ROAD_AUTHORITY_NATIONAL + " road authority",
+		},
+		{
+			"forwarded with a note",
+			entity.Report{Status: entity.STATUS_PENDING, ForwardedAt: &forwardedAt, ForwardedTo: entity.ROAD_AUTHORITY_PROVINCIAL, ForwardNote: "Provincial road"},
+			entity.OPEN311_STATUS_CLOSED, "Forwarded to the " + entity.ROAD_AUTHORITY_PROVINCIAL + " road authority: Provincial road",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, notes := open311Status(&tt.report)
+			if status != tt.wantStatus || notes != tt.wantNotes {
+				t.Errorf("open311Status() = %q, %q, want %q, %q", status, notes, tt.wantStatus, tt.wantNotes)
+			}
+		})
+	}
+}
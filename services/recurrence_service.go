@@ -0,0 +1,145 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	"dinacom-11.0-backend/models/entity"
+	"dinacom-11.0-backend/repositories"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/google/uuid"
+)
+
+type RecurrenceService interface {
+	LinkRecurrence(report *entity.Report) *entity.Report
+	NotifyRecurrence(report *entity.Report, repair *entity.Report)
+	GetWorkerRecurrences(from, to *time.Time, regionCodes []string) ([]dto.WorkerRecurrenceResponse, error)
+	GetContractorRecurrences(from, to *time.Time, regionCodes []string) ([]dto.ContractorRecurrenceResponse, error)
+}
+
+type recurrenceService struct {
+	reportRepo          repositories.ReportRepository
+	userRepo            repositories.UserRepository
+	workerRepo          repositories.WorkerRepository
+	notificationService NotificationService
+	window              time.Duration
+	radiusMeters        float64
+}
+
+func NewRecurrenceService(reportRepo repositories.ReportRepository, userRepo repositories.UserRepository, workerRepo repositories.WorkerRepository, notificationService NotificationService, window time.Duration, radiusMeters float64) RecurrenceService {
+	return &recurrenceService{
+		reportRepo:          reportRepo,
+		userRepo:            userRepo,
+		workerRepo:          workerRepo,
+		notificationService: notificationService,
+		window:              window,
+		radiusMeters:        radiusMeters,
+	}
+}
+
+// LinkRecurrence links a new report to the closest repair finished within the
+// recurrence window and radius, and to the worker who did it. It returns the
+// repair, or nil when the report is not a recurrence. Lookup errors are
+// logged so a report is never refused over them.
+func (s *recurrenceService) LinkRecurrence(report *entity.Report) *entity.Report {
+	if s.window <= 0 || s.radiusMeters <= 0 {
+		return nil
+	}
+
+	dLat := s.radiusMeters / 111320
+	dLng := dLat / math.Max(math.Cos(report.Latitude*math.Pi/180), 0.01)
+	repairs, err := s.reportRepo.GetRepairsInBox(report.Latitude-dLat, report.Longitude-dLng,
+		report.Latitude+dLat, report.Longitude+dLng, time.Now().Add(-s.window))
+	if err != nil {
+		utils.InternalErrorLog(err, "report_id", report.ID)
+		return nil
+	}
+
+	var nearest *entity.Report
+	best := s.radiusMeters
+	for i := range repairs {
+		distance := utils.HaversineKm(report.Latitude, report.Longitude, repairs[i].Latitude, repairs[i].Longitude) * 1000
+		if distance <= best {
+			nearest, best = &repairs[i], distance
+		}
+	}
+	if nearest == nil {
+		return nil
+	}
+
+	report.RecurrenceOf = &nearest.ID
+	report.RecurrenceWorkerID = nearest.WorkerID
+	return nearest
+}
+
+// NotifyRecurrence tells admins and the worker of the original repair that the
+// damage was reported again.
+func (s *recurrenceService) NotifyRecurrence(report *entity.Report, repair *entity.Report) {
+	finished := ""
+	if repair.FinishedAt != nil {
+		finished = " on " + repair.FinishedAt.Format("2006-01-02")
+	}
+	message := fmt.Sprintf("A new report on %s was filed close to the repair finished%s.", report.RoadName, finished)
+
+	s.notificationService.NotifyRole(entity.ROLE_ADMIN, entity.NOTIFICATION_REPORT_RECURRENCE, "Damage reported again", message, &report.ID)
+	if repair.WorkerID != nil {
+		s.notificationService.Notify(*repair.WorkerID, entity.NOTIFICATION_REPORT_RECURRENCE, "Damage reported again", message, &repair.ID)
+	}
+}
+
+func (s *recurrenceService) GetWorkerRecurrences(from, to *time.Time, regionCodes []string) ([]dto.WorkerRecurrenceResponse, error) {
+	stats, err := s.reportRepo.GetRecurrenceStatsByWorker(from, to, regionCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.WorkerRecurrenceResponse{}
+	for _, stat := range stats {
+		workerID, err := uuid.Parse(stat.GroupKey)
+		if err != nil {
+			continue
+		}
+		item := dto.WorkerRecurrenceResponse{
+			WorkerID:               workerID,
+			RecurrenceRateResponse: toRecurrenceRate(stat),
+		}
+		if worker, _ := s.userRepo.FindUserByID(workerID); worker != nil {
+			item.WorkerName = worker.Fullname
+		}
+		if profile, err := s.workerRepo.GetWorkerProfile(workerID); err == nil {
+			item.Contractor = profile.Contractor
+		}
+		response = append(response, item)
+	}
+	return response, nil
+}
+
+func (s *recurrenceService) GetContractorRecurrences(from, to *time.Time, regionCodes []string) ([]dto.ContractorRecurrenceResponse, error) {
+	stats, err := s.reportRepo.GetRecurrenceStatsByContractor(from, to, regionCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.ContractorRecurrenceResponse{}
+	for _, stat := range stats {
+		response = append(response, dto.ContractorRecurrenceResponse{
+			Contractor:             stat.GroupKey,
+			RecurrenceRateResponse: toRecurrenceRate(stat),
+		})
+	}
+	return response, nil
+}
+
+func toRecurrenceRate(stat repositories.RecurrenceStat) dto.RecurrenceRateResponse {
+	rate := dto.RecurrenceRateResponse{
+		Repairs:  stat.Repairs,
+		Recurred: stat.Recurred,
+	}
+	if stat.Repairs > 0 {
+		rate.RecurrenceRate = math.Round(float64(stat.Recurred)/float64(stat.Repairs)*1000) / 10
+	}
+	return rate
+}
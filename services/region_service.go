@@ -0,0 +1,526 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/repositories"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const regionBackfillBatch = 200
+
+type RegionService interface {
+	LoadBoundaries() error
+	ReloadBoundaries(actx dto.AuditContext) (*dto.RegionReloadResponse, error)
+	SyncBoundaries()
+	AssignRegions(report *entity.Report) bool
+	BackfillReportRegions()
+	GetRegions(level, parentCode string) ([]dto.RegionResponse, error)
+	GetAdminRegions(userID uuid.UUID) (*dto.AdminRegionsResponse, error)
+	SetAdminRegions(actx dto.AuditContext, userID uuid.UUID, req dto.AdminRegionsRequest) (*dto.AdminRegionsResponse, error)
+	GetScope(userID uuid.UUID) ([]string, error)
+	ResolveFilter(scope []string, regionCode string) ([]string, error)
+	ReportInScope(scope []string, reportID string) (bool, error)
+}
+
+// regionShape is a region boundary held in memory for point lookups.
+type regionShape struct {
+	code     string
+	name     string
+	minLat   float64
+	minLng   float64
+	maxLat   float64
+	maxLng   float64
+	polygons [][][][2]float64
+}
+
+type regionService struct {
+	regionRepo     repositories.RegionRepository
+	reportRepo     repositories.ReportRepository
+	userRepo       repositories.UserRepository
+	versionRepo    repositories.DatasetVersionRepository
+	auditService   AuditService
+	boundariesPath string
+	shapes         map[string][]regionShape
+	version        int64
+	mutex          sync.RWMutex
+}
+
+func NewRegionService(regionRepo repositories.RegionRepository, reportRepo repositories.ReportRepository, userRepo repositories.UserRepository, versionRepo repositories.DatasetVersionRepository, auditService AuditService, boundariesPath string) RegionService {
+	return &regionService{
+		regionRepo:     regionRepo,
+		reportRepo:     reportRepo,
+		userRepo:       userRepo,
+		versionRepo:    versionRepo,
+		auditService:   auditService,
+		boundariesPath: boundariesPath,
+		shapes:         make(map[string][]regionShape),
+	}
+}
+
+// LoadBoundaries imports the boundary files, when a path is configured, and
+// builds the lookup index from the stored regions. It runs at startup.
+func (s *regionService) LoadBoundaries() error {
+	if s.boundariesPath != "" {
+		if _, _, err := s.importBoundaries(); err != nil {
+			return err
+		}
+	}
+	version, err := s.versionRepo.GetVersion(entity.DATASET_REGIONS)
+	if err != nil {
+		return err
+	}
+	return s.buildIndex(version)
+}
+
+// ReloadBoundaries imports the boundary files again and raises the boundary
+// version. Every instance rebuilds its index once it sees the new version,
+// and the backfill job then places every report in its region anew.
+func (s *regionService) ReloadBoundaries(actx dto.AuditContext) (*dto.RegionReloadResponse, error) {
+	if s.boundariesPath == "" {
+		return nil, http_error.REGION_BOUNDARIES_NOT_SET
+	}
+
+	files, regions, err := s.importBoundaries()
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.versionRepo.BumpVersion(entity.DATASET_REGIONS)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.buildIndex(version); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_REGION_RELOAD, entity.AUDIT_TARGET_REGION, "", map[string]interface{}{
+		"files":   files,
+		"regions": regions,
+	})
+	return &dto.RegionReloadResponse{Files: files, Regions: regions}, nil
+}
+
+// importBoundaries reads the GeoJSON files at the boundaries path. Each
+// feature needs code, name and level properties and may name its parent_code.
+func (s *regionService) importBoundaries() (int, int, error) {
+	files, collections, err := utils.ReadFeatureCollections(s.boundariesPath)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	total := 0
+	for _, file := range files {
+		collection := collections[file]
+		regions := make([]entity.Region, 0, len(collection.Features))
+		for i, feature := range collection.Features {
+			region, err := toRegion(feature)
+			if err != nil {
+				return 0, 0, fmt.Errorf("%s feature %d: %w", file, i, err)
+			}
+			regions = append(regions, *region)
+		}
+		if err := s.regionRepo.UpsertRegions(regions); err != nil {
+			return 0, 0, err
+		}
+		total += len(regions)
+	}
+
+	utils.InfoLog("region boundaries imported", "files", len(files), "regions", total)
+	return len(files), total, nil
+}
+
+// SyncBoundaries rebuilds the index when the boundaries were reloaded on
+// another instance.
+func (s *regionService) SyncBoundaries() {
+	if _, err := s.syncBoundaries(); err != nil {
+		utils.InternalErrorLog(err, "job", "region_sync")
+	}
+}
+
+// syncBoundaries brings the index up to the stored boundary version and
+// returns that version.
+func (s *regionService) syncBoundaries() (int64, error) {
+	version, err := s.versionRepo.GetVersion(entity.DATASET_REGIONS)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mutex.RLock()
+	current := s.version
+	s.mutex.RUnlock()
+	if current == version {
+		return version, nil
+	}
+
+	if err := s.buildIndex(version); err != nil {
+		return 0, err
+	}
+	utils.InfoLog("region boundaries synced", "version", version)
+	return version, nil
+}
+
+func (s *regionService) buildIndex(version int64) error {
+	regions, err := s.regionRepo.GetRegionsWithGeometry()
+	if err != nil {
+		return err
+	}
+
+	shapes := make(map[string][]regionShape)
+	for _, region := range regions {
+		var geometry dto.GeoJSONGeometry
+		if err := json.Unmarshal([]byte(region.Geometry), &geometry); err != nil {
+			continue
+		}
+		polygons, err := parseRegionGeometry(&geometry)
+		if err != nil {
+			continue
+		}
+		shapes[region.Level] = append(shapes[region.Level], regionShape{
+			code:     region.Code,
+			name:     region.Name,
+			minLat:   region.MinLat,
+			minLng:   region.MinLng,
+			maxLat:   region.MaxLat,
+			maxLng:   region.MaxLng,
+			polygons: polygons,
+		})
+	}
+
+	s.mutex.Lock()
+	s.shapes = shapes
+	s.version = version
+	s.mutex.Unlock()
+	return nil
+}
+
+// AssignRegions fills in the report's region codes at every level and, when
+// the reporter left it empty, its district name. It returns false while no
+// boundaries are loaded, leaving the report to the backfill job.
+func (s *regionService) AssignRegions(report *entity.Report) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if len(s.shapes) == 0 {
+		return false
+	}
+
+	targets := map[string]*string{
+		entity.REGION_LEVEL_PROVINCE: &report.ProvinceCode,
+		entity.REGION_LEVEL_REGENCY:  &report.RegencyCode,
+		entity.REGION_LEVEL_DISTRICT: &report.DistrictCode,
+		entity.REGION_LEVEL_VILLAGE:  &report.VillageCode,
+	}
+	for level, target := range targets {
+		*target = ""
+		if shape := locate(s.shapes[level], report.Latitude, report.Longitude); shape != nil {
+			*target = shape.code
+			if level == entity.REGION_LEVEL_DISTRICT && report.District == "" {
+				report.District = shape.name
+			}
+		}
+	}
+
+	now := time.Now()
+	report.RegionsAssignedAt = &now
+	report.RegionsVersion = s.version
+	return true
+}
+
+func locate(shapes []regionShape, lat, lng float64) *regionShape {
+	for i := range shapes {
+		shape := &shapes[i]
+		if lat < shape.minLat || lat > shape.maxLat || lng < shape.minLng || lng > shape.maxLng {
+			continue
+		}
+		for _, polygon := range shape.polygons {
+			if utils.PointInPolygon(lat, lng, polygon) {
+				return shape
+			}
+		}
+	}
+	return nil
+}
+
+// BackfillReportRegions places reports created before the boundaries were
+// loaded, or placed with boundaries since reloaded, in their regions a batch
+// at a time. The index is synced first, so no report is placed with
+// boundaries older than the stored version.
+func (s *regionService) BackfillReportRegions() {
+	version, err := s.syncBoundaries()
+	if err != nil {
+		utils.InternalErrorLog(err, "job", "region_backfill")
+		return
+	}
+
+	reports, err := s.reportRepo.GetReportsWithoutRegions(version, regionBackfillBatch)
+	if err != nil {
+		utils.InternalErrorLog(err, "job", "region_backfill")
+		return
+	}
+
+	for i := range reports {
+		if !s.AssignRegions(&reports[i]) {
+			return
+		}
+		if err := s.reportRepo.SetRegions(&reports[i]); err != nil {
+			utils.InternalErrorLog(err, "job", "region_backfill", "report_id", reports[i].ID)
+		}
+	}
+}
+
+func (s *regionService) GetRegions(level, parentCode string) ([]dto.RegionResponse, error) {
+	if level != "" && entity.RegionLevelIndex(level) < 0 {
+		return nil, http_error.INVALID_REGION_LEVEL
+	}
+
+	regions, err := s.regionRepo.GetRegions(level, parentCode)
+	if err != nil {
+		return nil, err
+	}
+	return toRegionResponses(regions), nil
+}
+
+func (s *regionService) GetAdminRegions(userID uuid.UUID) (*dto.AdminRegionsResponse, error) {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil || user == nil {
+		return nil, http_error.ACCOUNT_NOT_FOUND
+	}
+
+	codes, err := s.regionRepo.GetAdminRegionCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+	regions, err := s.regionRepo.GetRegionsByCodes(codes)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.AdminRegionsResponse{UserID: userID, Regions: toRegionResponses(regions)}, nil
+}
+
+func (s *regionService) SetAdminRegions(actx dto.AuditContext, userID uuid.UUID, req dto.AdminRegionsRequest) (*dto.AdminRegionsResponse, error) {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil || user == nil {
+		return nil, http_error.ACCOUNT_NOT_FOUND
+	}
+	if user.Role != entity.ROLE_ADMIN {
+		return nil, http_error.REGIONS_ONLY_FOR_ADMINS
+	}
+
+	seen := make(map[string]bool, len(req.RegionCodes))
+	codes := make([]string, 0, len(req.RegionCodes))
+	for _, code := range req.RegionCodes {
+		code = strings.TrimSpace(code)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		codes = append(codes, code)
+	}
+
+	regions, err := s.regionRepo.GetRegionsByCodes(codes)
+	if err != nil {
+		return nil, err
+	}
+	if len(regions) != len(codes) {
+		return nil, http_error.REGION_NOT_FOUND
+	}
+
+	if err := s.regionRepo.SetAdminRegions(userID, codes); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_ADMIN_REGIONS_UPDATE, entity.AUDIT_TARGET_USER, userID.String(), map[string]interface{}{
+		"region_codes": codes,
+	})
+	return &dto.AdminRegionsResponse{UserID: userID, Regions: toRegionResponses(regions)}, nil
+}
+
+// GetScope returns the region codes the admin is limited to, empty when the
+// admin may see every report.
+func (s *regionService) GetScope(userID uuid.UUID) ([]string, error) {
+	return s.regionRepo.GetAdminRegionCodes(userID)
+}
+
+// ResolveFilter turns an optional region filter into the region codes a list
+// is limited to. Without a filter the admin's scope applies; a filter must lie
+// inside the scope.
+func (s *regionService) ResolveFilter(scope []string, regionCode string) ([]string, error) {
+	if regionCode == "" {
+		return scope, nil
+	}
+
+	region, err := s.regionRepo.GetRegionByCode(regionCode)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, http_error.REGION_NOT_FOUND
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(scope) > 0 {
+		inside, err := s.withinScope(region, scope)
+		if err != nil {
+			return nil, err
+		}
+		if !inside {
+			return nil, http_error.REGION_OUT_OF_SCOPE
+		}
+	}
+	return []string{regionCode}, nil
+}
+
+// withinScope walks up from the region to see whether it or a parent is one
+// of the scope's regions.
+func (s *regionService) withinScope(region *entity.Region, scope []string) (bool, error) {
+	for depth := 0; depth < len(entity.REGION_LEVELS); depth++ {
+		for _, code := range scope {
+			if code == region.Code {
+				return true, nil
+			}
+		}
+		if region.ParentCode == "" {
+			return false, nil
+		}
+
+		parent, err := s.regionRepo.GetRegionByCode(region.ParentCode)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		region = parent
+	}
+	return false, nil
+}
+
+// ReportInScope reports whether an admin limited to the scope may see and
+// manage the report. Missing reports are let through so the caller answers
+// with its usual not found error.
+func (s *regionService) ReportInScope(scope []string, reportID string) (bool, error) {
+	if len(scope) == 0 {
+		return true, nil
+	}
+
+	report, err := s.reportRepo.GetReportByID(reportID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, code := range scope {
+		if code == report.ProvinceCode || code == report.RegencyCode || code == report.DistrictCode || code == report.VillageCode {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func toRegion(feature dto.GeoJSONFeature) (*entity.Region, error) {
+	property := func(key string) string {
+		if value, ok := feature.Properties[key]; ok && value != nil {
+			return strings.TrimSpace(fmt.Sprint(value))
+		}
+		return ""
+	}
+
+	region := &entity.Region{
+		Code:       property("code"),
+		Name:       property("name"),
+		Level:      strings.ToLower(property("level")),
+		ParentCode: property("parent_code"),
+	}
+	if region.Code == "" || region.Name == "" || entity.RegionLevelIndex(region.Level) < 0 {
+		return nil, http_error.INVALID_REGION_BOUNDARY
+	}
+
+	polygons, err := parseRegionGeometry(&feature.Geometry)
+	if err != nil {
+		return nil, err
+	}
+
+	region.MinLat, region.MinLng, region.MaxLat, region.MaxLng = polygonBounds(polygons)
+
+	geometry, err := json.Marshal(feature.Geometry)
+	if err != nil {
+		return nil, err
+	}
+	region.Geometry = string(geometry)
+	return region, nil
+}
+
+// polygonBounds returns the bounding box of the outer rings.
+func polygonBounds(polygons [][][][2]float64) (minLat, minLng, maxLat, maxLng float64) {
+	minLat, minLng = math.Inf(1), math.Inf(1)
+	maxLat, maxLng = math.Inf(-1), math.Inf(-1)
+	for _, polygon := range polygons {
+		for _, position := range polygon[0] {
+			minLng = math.Min(minLng, position[0])
+			maxLng = math.Max(maxLng, position[0])
+			minLat = math.Min(minLat, position[1])
+			maxLat = math.Max(maxLat, position[1])
+		}
+	}
+	return minLat, minLng, maxLat, maxLng
+}
+
+// parseRegionGeometry accepts a Polygon or MultiPolygon and returns its
+// polygons as GeoJSON rings.
+func parseRegionGeometry(geometry *dto.GeoJSONGeometry) ([][][][2]float64, error) {
+	if geometry.Type == "Polygon" {
+		rings, err := parsePolygon(geometry)
+		if err != nil {
+			return nil, http_error.INVALID_REGION_BOUNDARY
+		}
+		return [][][][2]float64{rings}, nil
+	}
+	if geometry.Type != "MultiPolygon" {
+		return nil, http_error.INVALID_REGION_BOUNDARY
+	}
+
+	raw, err := json.Marshal(geometry.Coordinates)
+	if err != nil {
+		return nil, http_error.INVALID_REGION_BOUNDARY
+	}
+	var coordinates []interface{}
+	if err := json.Unmarshal(raw, &coordinates); err != nil || len(coordinates) == 0 {
+		return nil, http_error.INVALID_REGION_BOUNDARY
+	}
+
+	polygons := make([][][][2]float64, 0, len(coordinates))
+	for _, polygon := range coordinates {
+		rings, err := parsePolygon(&dto.GeoJSONGeometry{Type: "Polygon", Coordinates: polygon})
+		if err != nil {
+			return nil, http_error.INVALID_REGION_BOUNDARY
+		}
+		polygons = append(polygons, rings)
+	}
+	return polygons, nil
+}
+
+func toRegionResponses(regions []entity.Region) []dto.RegionResponse {
+	response := make([]dto.RegionResponse, 0, len(regions))
+	for _, region := range regions {
+		response = append(response, dto.RegionResponse{
+			Code:       region.Code,
+			Name:       region.Name,
+			Level:      region.Level,
+			ParentCode: region.ParentCode,
+		})
+	}
+	return response
+}
@@ -1,7 +1,9 @@
 package services
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"mime/multipart"
 	"path/filepath"
 	"strings"
@@ -16,7 +18,34 @@ import (
 	"github.com/google/uuid"
 )
 
-const maxFileSize = 32 << 20
+const (
+	maxFileSize = 32 << 20
+
+	// maxProgressPhotos caps the photos attached to one work order update.
+	maxProgressPhotos = 10
+)
+
+const (
+	defaultSearchLimit = 500
+	maxSearchLimit     = 2000
+	maxSearchRadiusKm  = 100.0
+
+	// maxGeohashCells bounds the prefixes in one query; larger areas use
+	// shorter prefixes and finally the coordinates alone.
+	maxGeohashCells = 32
+
+	// Reports are read in batches until enough match the exact shape. The
+	// scan stops at maxSearchScan rows and the result is marked truncated.
+	searchBatchSize = 1000
+	maxSearchScan   = 20000
+
+	// Below simplifyBelowZoom, reports closer than simplifyCellPixels on
+	// screen are merged into the most severe of them.
+	simplifyBelowZoom  = 16
+	simplifyCellPixels = 40.0
+
+	geohashBackfillBatch = 500
+)
 
 var allowedExtensions = map[string]bool{
 	".jpg":  true,
@@ -26,28 +55,73 @@ var allowedExtensions = map[string]bool{
 
 type ReportService interface {
 	CreateReport(userID uuid.UUID, file multipart.File, header *multipart.FileHeader, req dto.ReportRequest) (*dto.ReportResponse, error)
-	GetReports() ([]dto.ReportLocationResponse, error)
-	AssignWorker(req dto.AssignWorkerRequest) (string, error)
-	GetAssignedReports() ([]dto.AssignedWorkerResponse, error)
-	FinishReport(workerID uuid.UUID, file multipart.File, header *multipart.FileHeader, reportID string) error
+	CreateExternalReport(apiKeyID uuid.UUID, imageURL, requestedClass string, req dto.ReportRequest) (*dto.ReportResponse, error)
+	SearchReports(req dto.ReportSearchRequest) (*dto.ReportSearchResponse, error)
+	BackfillGeohashes()
+	AssignWorker(actx dto.AuditContext, req dto.AssignWorkerRequest) (string, error)
+	GetAssignedReports(regionCodes []string) ([]dto.AssignedWorkerResponse, error)
+	FinishReport(workerID uuid.UUID, file multipart.File, header *multipart.FileHeader, req dto.WorkerReportRequest) error
 	GetUserReports(userID uuid.UUID, page, limit int) (*dto.PaginatedReportsResponse, error)
 	GetWorkerAssignedReports(workerID uuid.UUID, page, limit int) (*dto.PaginatedReportsResponse, error)
 	GetWorkerHistory(workerID uuid.UUID, verifyAdmin bool, page, limit int) (*dto.PaginatedReportsResponse, error)
-	VerifyReport(reportID string) error
+	VerifyReport(actx dto.AuditContext, req dto.VerifyReportRequest) error
+	GetPendingReports(regionCodes []string, page, limit int) (*dto.PaginatedReportsResponse, error)
+	ClassifyReport(actx dto.AuditContext, req dto.ClassifyReportRequest) error
+	DeleteReport(actx dto.AuditContext, reportID string, expectedVersion *int) error
+	RejectReport(actx dto.AuditContext, req dto.RejectReportRequest) error
+	ReworkReport(actx dto.AuditContext, req dto.ReworkReportRequest) error
+	GetReportReworks(reportID string) ([]dto.ReportReworkResponse, error)
+	AcceptAssignment(actx dto.AuditContext, workerID uuid.UUID, req dto.AcceptAssignmentRequest) error
+	DeclineAssignment(actx dto.AuditContext, workerID uuid.UUID, req dto.DeclineAssignmentRequest) error
+	ReassignWorker(actx dto.AuditContext, req dto.ReassignWorkerRequest) (string, error)
+	UnassignWorker(actx dto.AuditContext, req dto.UnassignWorkerRequest) error
+	GetReportAssignments(reportID string) ([]dto.ReportAssignmentResponse, error)
+	GetReport(reportID string) (*dto.UserReportResponse, error)
+	AssignTeam(actx dto.AuditContext, req dto.AssignTeamRequest) (string, error)
+	SubmitProgress(workerID uuid.UUID, files []*multipart.FileHeader, req dto.ReportProgressRequest) (*dto.ReportProgressResponse, error)
+	GetWorkOrder(reportID string) (*dto.WorkOrderResponse, error)
+	CanViewProgress(reportID string, workerID uuid.UUID) (bool, error)
+	GetReportTimeline(userID uuid.UUID, reportID string) (*dto.ReportTimelineResponse, error)
+	ForwardReport(actx dto.AuditContext, req dto.ForwardReportRequest) error
+	GetAuthorityQueue(authority string, regionCodes []string, page, limit int) (*dto.PaginatedReportsResponse, error)
 }
 
 type reportService struct {
-	reportRepo       repositories.ReportRepository
-	userRepo         repositories.UserRepository
-	cloudinaryClient *utils.CloudinaryClient
+	reportRepo          repositories.ReportRepository
+	userRepo            repositories.UserRepository
+	teamRepo            repositories.TeamRepository
+	auditService        AuditService
+	notificationService NotificationService
+	autoAssignService   AutoAssignService
+	workerService       WorkerService
+	slaService          SLAService
+	materialService     MaterialService
+	regionService       RegionService
+	roadService         RoadService
+	geocodeService      GeocodeService
+	serviceAreaService  ServiceAreaService
+	recurrenceService   RecurrenceService
+	cloudinaryClient    *utils.CloudinaryClient
 }
 
-func NewReportService(reportRepo repositories.ReportRepository, userRepo repositories.UserRepository) ReportService {
+func NewReportService(reportRepo repositories.ReportRepository, userRepo repositories.UserRepository, teamRepo repositories.TeamRepository, auditService AuditService, notificationService NotificationService, autoAssignService AutoAssignService, workerService WorkerService, slaService SLAService, materialService MaterialService, regionService RegionService, roadService RoadService, geocodeService GeocodeService, serviceAreaService ServiceAreaService, recurrenceService RecurrenceService) ReportService {
 	client, _ := utils.NewCloudinaryClient()
 	return &reportService{
-		reportRepo:       reportRepo,
-		userRepo:         userRepo,
-		cloudinaryClient: client,
+		reportRepo:          reportRepo,
+		userRepo:            userRepo,
+		teamRepo:            teamRepo,
+		auditService:        auditService,
+		notificationService: notificationService,
+		autoAssignService:   autoAssignService,
+		workerService:       workerService,
+		slaService:          slaService,
+		materialService:     materialService,
+		regionService:       regionService,
+		roadService:         roadService,
+		geocodeService:      geocodeService,
+		serviceAreaService:  serviceAreaService,
+		recurrenceService:   recurrenceService,
+		cloudinaryClient:    client,
 	}
 }
 
@@ -61,92 +135,523 @@ func (s *reportService) CreateReport(userID uuid.UUID, file multipart.File, head
 		return nil, http_error.INVALID_FILE_FORMAT
 	}
 
-	reportID := fmt.Sprintf("%s_%s_%.6f_%.6f", uuid.New().String(), time.Now().Format("20060102150405"), req.Longitude, req.Latitude)
+	outsideServiceArea, err := s.serviceAreaService.CheckLocation(req.Latitude, req.Longitude)
+	if err != nil {
+		return nil, err
+	}
+
+	reportID := newReportID(req)
 
 	imageURL, err := s.cloudinaryClient.UploadImage(file, reportID, req.Longitude, req.Latitude, req.Description)
 	if err != nil {
 		return nil, http_error.CLOUDINARY_UPLOAD_FAILED
 	}
 
-	report := &entity.Report{
-		ID:             reportID,
-		UserID:         userID,
-		Longitude:      req.Longitude,
-		Latitude:       req.Latitude,
-		RoadName:       req.RoadName,
-		BeforeImageURL: imageURL,
-		Description:    req.Description,
-		Status:         entity.STATUS_PENDING,
+	return s.saveNewReport(&entity.Report{
+		ID:                 reportID,
+		UserID:             userID,
+		BeforeImageURL:     imageURL,
+		OutsideServiceArea: outsideServiceArea,
+	}, req)
+}
+
+// CreateExternalReport files a report sent by a machine client, such as an
+// Open311 app. It has no citizen owner and records the API key instead. The
+// image stays at the client's URL and requestedClass keeps the destruct class
+// the client picked until the report is classified.
+func (s *reportService) CreateExternalReport(apiKeyID uuid.UUID, imageURL, requestedClass string, req dto.ReportRequest) (*dto.ReportResponse, error) {
+	outsideServiceArea, err := s.serviceAreaService.CheckLocation(req.Latitude, req.Longitude)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.saveNewReport(&entity.Report{
+		ID:                 newReportID(req),
+		SourceAPIKeyID:     &apiKeyID,
+		BeforeImageURL:     imageURL,
+		RequestedClass:     requestedClass,
+		OutsideServiceArea: outsideServiceArea,
+	}, req)
+}
+
+func newReportID(req dto.ReportRequest) string {
+	return fmt.Sprintf("%s_%s_%.6f_%.6f", uuid.New().String(), time.Now().Format("20060102150405"), req.Longitude, req.Latitude)
+}
+
+// saveNewReport fills in the location derived fields of a new report from the
+// request and stores it as pending.
+func (s *reportService) saveNewReport(report *entity.Report, req dto.ReportRequest) (*dto.ReportResponse, error) {
+	// The typed name is stored next to the canonical one, which stands in
+	// when the citizen left it empty; failing both, snapping names the road.
+	roadName := strings.TrimSpace(req.RoadName)
+	canonicalRoadName := s.geocodeService.CanonicalRoadName(req.Latitude, req.Longitude, roadName)
+	if roadName == "" {
+		roadName = canonicalRoadName
 	}
 
+	report.Longitude = req.Longitude
+	report.Latitude = req.Latitude
+	report.Geohash = utils.GeohashEncode(req.Latitude, req.Longitude, utils.GeohashPrecision)
+	report.RoadName = roadName
+	report.CanonicalRoadName = canonicalRoadName
+	report.District = strings.TrimSpace(req.District)
+	report.Description = req.Description
+	report.Status = entity.STATUS_PENDING
+	s.regionService.AssignRegions(report)
+	s.roadService.SnapReport(report)
+	repair := s.recurrenceService.LinkRecurrence(report)
+
 	if err := s.reportRepo.CreateReport(report); err != nil {
 		return nil, http_error.REPORT_CREATION_FAILED
 	}
+	if repair != nil {
+		s.recurrenceService.NotifyRecurrence(report, repair)
+	}
 
 	return &dto.ReportResponse{
-		ID:             report.ID,
-		UserID:         report.UserID,
-		Longitude:      report.Longitude,
-		Latitude:       report.Latitude,
-		RoadName:       report.RoadName,
-		BeforeImageURL: report.BeforeImageURL,
-		AfterImageURL:  report.AfterImageURL,
-		Description:    report.Description,
-		DestructClass:  report.DestructClass,
-		LocationScore:  report.LocationScore,
-		TotalScore:     report.TotalScore,
-		Status:         report.Status,
+		ID:                 report.ID,
+		UserID:             report.UserID,
+		SourceAPIKeyID:     report.SourceAPIKeyID,
+		Longitude:          report.Longitude,
+		Latitude:           report.Latitude,
+		RoadName:           report.RoadName,
+		CanonicalRoadName:  report.CanonicalRoadName,
+		District:           report.District,
+		ProvinceCode:       report.ProvinceCode,
+		RegencyCode:        report.RegencyCode,
+		DistrictCode:       report.DistrictCode,
+		VillageCode:        report.VillageCode,
+		RoadSegmentID:      report.RoadSegmentID,
+		RoadAuthority:      report.RoadAuthority,
+		OutsideServiceArea: report.OutsideServiceArea,
+		RecurrenceOf:       report.RecurrenceOf,
+		RecurrenceWorkerID: report.RecurrenceWorkerID,
+		BeforeImageURL:     report.BeforeImageURL,
+		AfterImageURL:      report.AfterImageURL,
+		Description:        report.Description,
+		DestructClass:      report.DestructClass,
+		LocationScore:      report.LocationScore,
+		TotalScore:         report.TotalScore,
+		Status:             report.Status,
 	}, nil
 }
 
-func (s *reportService) GetReports() ([]dto.ReportLocationResponse, error) {
-	reports, err := s.reportRepo.GetCompletedNonGoodReports()
+func (s *reportService) AssignWorker(actx dto.AuditContext, req dto.AssignWorkerRequest) (string, error) {
+	report, err := s.reportRepo.GetReportByID(req.ReportID)
 	if err != nil {
-		return nil, err
+		return "", http_error.REPORT_NOT_FOUND
 	}
 
-	var response []dto.ReportLocationResponse
-	for _, report := range reports {
-		response = append(response, dto.ReportLocationResponse{
-			ID:            report.ID,
-			Longitude:     report.Longitude,
-			Latitude:      report.Latitude,
-			DestructClass: report.DestructClass,
-			TotalScore:    report.TotalScore,
-		})
+	if err := checkVersion(report, req.Version); err != nil {
+		return "", err
 	}
 
-	return response, nil
+	if report.Status == entity.STATUS_REJECTED {
+		return "", http_error.REPORT_REJECTED
+	}
+
+	if report.ForwardedAt != nil {
+		return "", http_error.REPORT_FORWARDED
+	}
+
+	if report.WorkerID != nil {
+		return "", http_error.REPORT_ALREADY_ASSIGNED
+	}
+
+	worker, err := s.findWorker(req.WorkerID)
+	if err != nil {
+		return "", err
+	}
+
+	warnings, err := s.checkWorkerAvailability(worker.ID, req.Force)
+	if err != nil {
+		return "", err
+	}
+
+	deadline, policyID, err := s.resolveDeadline(report, req.Deadline)
+	if err != nil {
+		return "", err
+	}
+
+	assignment := &entity.ReportAssignment{
+		ReportID:    req.ReportID,
+		WorkerID:    req.WorkerID,
+		AssignedBy:  actx.ActorID,
+		Status:      entity.ASSIGNMENT_PENDING,
+		AdminNotes:  req.AdminNotes,
+		Deadline:    deadline,
+		SLAPolicyID: policyID,
+	}
+
+	if err := s.reportRepo.AssignWorker(assignment, report.Version); err != nil {
+		return "", err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_REPORT_ASSIGN, entity.AUDIT_TARGET_REPORT, req.ReportID, map[string]interface{}{
+		"worker_id":             req.WorkerID,
+		"deadline":              deadline,
+		"sla_policy_id":         policyID,
+		"availability_warnings": warnings,
+	})
+	s.notifyAssigned(worker.ID, report)
+
+	return assignedMessage(worker.Fullname, warnings), nil
 }
 
-func (s *reportService) AssignWorker(req dto.AssignWorkerRequest) (string, error) {
+// AssignTeam gives the report to a team. The leader holds the assignment and
+// the current members are recorded as its crew.
+func (s *reportService) AssignTeam(actx dto.AuditContext, req dto.AssignTeamRequest) (string, error) {
 	report, err := s.reportRepo.GetReportByID(req.ReportID)
 	if err != nil {
 		return "", http_error.REPORT_NOT_FOUND
 	}
 
+	if err := checkVersion(report, req.Version); err != nil {
+		return "", err
+	}
+
+	if report.Status == entity.STATUS_REJECTED {
+		return "", http_error.REPORT_REJECTED
+	}
+
+	if report.ForwardedAt != nil {
+		return "", http_error.REPORT_FORWARDED
+	}
+
 	if report.WorkerID != nil {
 		return "", http_error.REPORT_ALREADY_ASSIGNED
 	}
 
-	worker, err := s.userRepo.FindUserByID(req.WorkerID)
+	team, err := s.teamRepo.GetTeamByID(req.TeamID)
+	if err != nil {
+		return "", http_error.TEAM_NOT_FOUND
+	}
+
+	warnings, err := s.checkWorkerAvailability(team.LeaderID, req.Force)
+	if err != nil {
+		return "", err
+	}
+
+	deadline, policyID, err := s.resolveDeadline(report, req.Deadline)
+	if err != nil {
+		return "", err
+	}
+
+	crew := make([]entity.ReportCrewMember, 0, len(team.Members))
+	for _, member := range team.Members {
+		crew = append(crew, entity.ReportCrewMember{
+			WorkerID: member.WorkerID,
+			ReportID: req.ReportID,
+			TeamID:   team.ID,
+			IsLeader: member.WorkerID == team.LeaderID,
+		})
+	}
+
+	assignment := &entity.ReportAssignment{
+		ReportID:    req.ReportID,
+		WorkerID:    team.LeaderID,
+		TeamID:      &team.ID,
+		AssignedBy:  actx.ActorID,
+		Status:      entity.ASSIGNMENT_PENDING,
+		AdminNotes:  req.AdminNotes,
+		Deadline:    deadline,
+		SLAPolicyID: policyID,
+		Crew:        crew,
+	}
+
+	if err := s.reportRepo.AssignWorker(assignment, report.Version); err != nil {
+		return "", err
+	}
+
+	crewIDs := make([]uuid.UUID, 0, len(crew))
+	for _, member := range crew {
+		crewIDs = append(crewIDs, member.WorkerID)
+	}
+	s.auditService.Record(actx, entity.AUDIT_REPORT_ASSIGN, entity.AUDIT_TARGET_REPORT, req.ReportID, map[string]interface{}{
+		"worker_id":             team.LeaderID,
+		"team_id":               team.ID,
+		"crew_ids":              crewIDs,
+		"deadline":              deadline,
+		"sla_policy_id":         policyID,
+		"availability_warnings": warnings,
+	})
+
+	s.notifyAssigned(team.LeaderID, report)
+	for _, member := range crew {
+		if member.IsLeader {
+			continue
+		}
+		s.notificationService.Notify(member.WorkerID, entity.NOTIFICATION_REPORT_ASSIGNED, "New crew assignment",
+			fmt.Sprintf("Your team %s has been assigned to the report on %s.", team.Name, report.RoadName), &report.ID)
+	}
+
+	return assignedMessage(team.Name, warnings), nil
+}
+
+func (s *reportService) AcceptAssignment(actx dto.AuditContext, workerID uuid.UUID, req dto.AcceptAssignmentRequest) error {
+	report, err := s.reportRepo.GetReportByID(req.ReportID)
+	if err != nil {
+		return http_error.REPORT_NOT_FOUND
+	}
+
+	if err := checkVersion(report, req.Version); err != nil {
+		return err
+	}
+
+	if report.WorkerID == nil || *report.WorkerID != workerID {
+		return http_error.NOT_ASSIGNED_TO_REPORT
+	}
+
+	if report.Status != entity.STATUS_ASSIGNED || report.AssignmentStatus != entity.ASSIGNMENT_PENDING {
+		return http_error.ASSIGNMENT_NOT_PENDING
+	}
+
+	if err := s.reportRepo.RespondToAssignment(req.ReportID, report.Version, workerID, entity.ASSIGNMENT_ACCEPTED, "", ""); err != nil {
+		return err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_REPORT_ACCEPT, entity.AUDIT_TARGET_REPORT, req.ReportID, nil)
+	return nil
+}
+
+func (s *reportService) DeclineAssignment(actx dto.AuditContext, workerID uuid.UUID, req dto.DeclineAssignmentRequest) error {
+	report, err := s.reportRepo.GetReportByID(req.ReportID)
+	if err != nil {
+		return http_error.REPORT_NOT_FOUND
+	}
+
+	if err := checkVersion(report, req.Version); err != nil {
+		return err
+	}
+
+	if report.WorkerID == nil || *report.WorkerID != workerID {
+		return http_error.NOT_ASSIGNED_TO_REPORT
+	}
+
+	if report.Status != entity.STATUS_ASSIGNED || report.AssignmentStatus != entity.ASSIGNMENT_PENDING {
+		return http_error.ASSIGNMENT_NOT_PENDING
+	}
+
+	if err := s.reportRepo.RespondToAssignment(req.ReportID, report.Version, workerID, entity.ASSIGNMENT_DECLINED, req.Reason, unassignedStatus(report)); err != nil {
+		return err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_REPORT_DECLINE, entity.AUDIT_TARGET_REPORT, req.ReportID, map[string]interface{}{
+		"reason": req.Reason,
+	})
+	s.notificationService.NotifyRole(entity.ROLE_ADMIN, entity.NOTIFICATION_REPORT_DECLINED, "Assignment declined",
+		fmt.Sprintf("The assignment on %s was declined: %s", report.RoadName, req.Reason), &report.ID)
+
+	return nil
+}
+
+func (s *reportService) ReassignWorker(actx dto.AuditContext, req dto.ReassignWorkerRequest) (string, error) {
+	report, err := s.reportRepo.GetReportByID(req.ReportID)
+	if err != nil {
+		return "", http_error.REPORT_NOT_FOUND
+	}
+
+	if err := checkVersion(report, req.Version); err != nil {
+		return "", err
+	}
+
+	if report.Status != entity.STATUS_ASSIGNED || report.WorkerID == nil {
+		return "", http_error.ASSIGNMENT_NOT_OPEN
+	}
+
+	if *report.WorkerID == req.WorkerID {
+		return "", http_error.SAME_WORKER_REASSIGN
+	}
+
+	worker, err := s.findWorker(req.WorkerID)
+	if err != nil {
+		return "", err
+	}
+
+	warnings, err := s.checkWorkerAvailability(worker.ID, req.Force)
+	if err != nil {
+		return "", err
+	}
+
+	deadline, policyID, err := s.resolveDeadline(report, req.Deadline)
+	if err != nil {
+		return "", err
+	}
+
+	previousWorkerID := *report.WorkerID
+	assignment := &entity.ReportAssignment{
+		ReportID:    req.ReportID,
+		WorkerID:    req.WorkerID,
+		AssignedBy:  actx.ActorID,
+		Status:      entity.ASSIGNMENT_PENDING,
+		AdminNotes:  req.AdminNotes,
+		Deadline:    deadline,
+		SLAPolicyID: policyID,
+	}
+
+	if err := s.reportRepo.ReassignWorker(assignment, report.Version, req.Reason); err != nil {
+		return "", err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_REPORT_REASSIGN, entity.AUDIT_TARGET_REPORT, req.ReportID, map[string]interface{}{
+		"from_worker_id":        previousWorkerID,
+		"to_worker_id":          req.WorkerID,
+		"reason":                req.Reason,
+		"deadline":              deadline,
+		"sla_policy_id":         policyID,
+		"availability_warnings": warnings,
+	})
+	s.notificationService.Notify(previousWorkerID, entity.NOTIFICATION_REPORT_REVOKED, "Assignment removed",
+		fmt.Sprintf("The report on %s was reassigned to another worker: %s", report.RoadName, req.Reason), &report.ID)
+	s.notifyAssigned(worker.ID, report)
+
+	return assignedMessage(worker.Fullname, warnings), nil
+}
+
+func (s *reportService) UnassignWorker(actx dto.AuditContext, req dto.UnassignWorkerRequest) error {
+	report, err := s.reportRepo.GetReportByID(req.ReportID)
+	if err != nil {
+		return http_error.REPORT_NOT_FOUND
+	}
+
+	if err := checkVersion(report, req.Version); err != nil {
+		return err
+	}
+
+	if report.Status != entity.STATUS_ASSIGNED || report.WorkerID == nil {
+		return http_error.ASSIGNMENT_NOT_OPEN
+	}
+
+	previousWorkerID := *report.WorkerID
+	if err := s.reportRepo.UnassignWorker(req.ReportID, report.Version, req.Reason, unassignedStatus(report)); err != nil {
+		return err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_REPORT_UNASSIGN, entity.AUDIT_TARGET_REPORT, req.ReportID, map[string]interface{}{
+		"worker_id": previousWorkerID,
+		"reason":    req.Reason,
+	})
+	s.notificationService.Notify(previousWorkerID, entity.NOTIFICATION_REPORT_REVOKED, "Assignment removed",
+		fmt.Sprintf("You were unassigned from the report on %s: %s", report.RoadName, req.Reason), &report.ID)
+
+	return nil
+}
+
+func (s *reportService) GetReportAssignments(reportID string) ([]dto.ReportAssignmentResponse, error) {
+	if _, err := s.reportRepo.GetReportByID(reportID); err != nil {
+		return nil, http_error.REPORT_NOT_FOUND
+	}
+
+	assignments, err := s.reportRepo.GetAssignmentsByReportID(reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []dto.ReportAssignmentResponse
+	for _, assignment := range assignments {
+		workerName := ""
+		if worker, _ := s.userRepo.FindUserByID(assignment.WorkerID); worker != nil {
+			workerName = worker.Fullname
+		}
+
+		var crewIDs []uuid.UUID
+		for _, member := range assignment.Crew {
+			crewIDs = append(crewIDs, member.WorkerID)
+		}
+		response = append(response, dto.ReportAssignmentResponse{
+			ID:          assignment.ID,
+			WorkerID:    assignment.WorkerID,
+			WorkerName:  workerName,
+			TeamID:      assignment.TeamID,
+			CrewIDs:     crewIDs,
+			AssignedBy:  assignment.AssignedBy,
+			Status:      assignment.Status,
+			AdminNotes:  assignment.AdminNotes,
+			Deadline:    assignment.Deadline,
+			Reason:      assignment.Reason,
+			Reasoning:   assignment.Reasoning,
+			RespondedAt: assignment.RespondedAt,
+			EndedAt:     assignment.EndedAt,
+			CreatedAt:   assignment.CreatedAt,
+		})
+	}
+	return response, nil
+}
+
+func (s *reportService) findWorker(workerID uuid.UUID) (*entity.User, error) {
+	worker, err := s.userRepo.FindUserByID(workerID)
 	if err != nil || worker == nil {
-		return "", http_error.WORKER_NOT_FOUND
+		return nil, http_error.WORKER_NOT_FOUND
 	}
 
 	if worker.Role != entity.ROLE_WORKER {
-		return "", http_error.ONLY_WORKER_CAN_ASSIGN
+		return nil, http_error.ONLY_WORKER_CAN_ASSIGN
 	}
 
-	if err := s.reportRepo.AssignWorker(req.ReportID, req.WorkerID, req.AdminNotes, req.Deadline); err != nil {
-		return "", err
+	return worker, nil
+}
+
+// checkWorkerAvailability refuses workers on leave or at their job limit unless
+// the admin forces the assignment. It returns the warnings to pass on, which
+// include the overridden reasons when forced.
+func (s *reportService) checkWorkerAvailability(workerID uuid.UUID, force bool) ([]string, error) {
+	availability, err := s.workerService.CheckAvailability(workerID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if !availability.Available && !force {
+		return nil, fmt.Errorf("%w: %s", http_error.WORKER_UNAVAILABLE, strings.Join(availability.Reasons, "; "))
+	}
+	return append(availability.Reasons, availability.Warnings...), nil
+}
+
+// resolveDeadline keeps a deadline given by the admin and otherwise takes it
+// from the SLA policy matching the report, if any.
+func (s *reportService) resolveDeadline(report *entity.Report, deadline *time.Time) (*time.Time, *uuid.UUID, error) {
+	if deadline != nil {
+		return deadline, nil, nil
+	}
+
+	deadline, policy, err := s.slaService.ResolveDeadline(report, time.Now())
+	if err != nil || policy == nil {
+		return nil, nil, err
+	}
+	return deadline, &policy.ID, nil
+}
+
+func assignedMessage(workerName string, warnings []string) string {
+	if len(warnings) == 0 {
+		return fmt.Sprintf("%s is successfully assigned", workerName)
+	}
+	return fmt.Sprintf("%s is successfully assigned (warning: %s)", workerName, strings.Join(warnings, "; "))
+}
+
+func (s *reportService) notifyAssigned(workerID uuid.UUID, report *entity.Report) {
+	s.notificationService.Notify(workerID, entity.NOTIFICATION_REPORT_ASSIGNED, "New assignment",
+		fmt.Sprintf("You have been assigned to the report on %s. Please accept or decline it.", report.RoadName), &report.ID)
+}
+
+// unassignedStatus is the status a report returns to when its worker is removed.
+func unassignedStatus(report *entity.Report) string {
+	if report.DestructClass != "" {
+		return entity.STATUS_COMPLETED
 	}
+	return entity.STATUS_PENDING
+}
 
-	return fmt.Sprintf("%s is successfully assigned", worker.Fullname), nil
+// checkVersion rejects a mutation made against an outdated copy of the report.
+// Without an expected version the version read by the service is still used
+// for the conditional update, so concurrent writers cannot overwrite each other.
+func checkVersion(report *entity.Report, expected *int) error {
+	if expected != nil && *expected != report.Version {
+		return http_error.REPORT_VERSION_CONFLICT
+	}
+	return nil
 }
 
-func (s *reportService) GetAssignedReports() ([]dto.AssignedWorkerResponse, error) {
-	reports, err := s.reportRepo.GetAssignedReports()
+func (s *reportService) GetAssignedReports(regionCodes []string) ([]dto.AssignedWorkerResponse, error) {
+	reports, err := s.reportRepo.GetAssignedReports(regionCodes)
 	if err != nil {
 		return nil, err
 	}
@@ -162,18 +667,27 @@ func (s *reportService) GetAssignedReports() ([]dto.AssignedWorkerResponse, erro
 		}
 
 		response = append(response, dto.AssignedWorkerResponse{
-			WorkerName: workerName,
-			RoadName:   report.RoadName,
-			Longitude:  report.Longitude,
-			Latitude:   report.Latitude,
-			Status:     report.Status,
+			ReportID:         report.ID,
+			WorkerID:         report.WorkerID,
+			WorkerName:       workerName,
+			TeamID:           report.TeamID,
+			RoadName:         report.RoadName,
+			Longitude:        report.Longitude,
+			Latitude:         report.Latitude,
+			Status:           report.Status,
+			AssignmentStatus: report.AssignmentStatus,
+			WorkStage:        report.WorkStage,
+			Deadline:         report.Deadline,
+			OverdueAt:        report.OverdueAt,
+			EscalatedAt:      report.EscalatedAt,
+			Version:          report.Version,
 		})
 	}
 
 	return response, nil
 }
 
-func (s *reportService) FinishReport(workerID uuid.UUID, file multipart.File, header *multipart.FileHeader, reportID string) error {
+func (s *reportService) FinishReport(workerID uuid.UUID, file multipart.File, header *multipart.FileHeader, req dto.WorkerReportRequest) error {
 	if header.Size > maxFileSize {
 		return http_error.FILE_TOO_LARGE
 	}
@@ -183,22 +697,49 @@ func (s *reportService) FinishReport(workerID uuid.UUID, file multipart.File, he
 		return http_error.INVALID_FILE_FORMAT
 	}
 
-	report, err := s.reportRepo.GetReportByID(reportID)
+	report, err := s.reportRepo.GetReportByID(req.ReportID)
 	if err != nil {
 		return http_error.REPORT_NOT_FOUND
 	}
 
+	if err := checkVersion(report, req.Version); err != nil {
+		return err
+	}
+
 	if report.WorkerID == nil || *report.WorkerID != workerID {
+		if isCrew, _ := s.reportRepo.IsCrewMember(req.ReportID, workerID); isCrew {
+			return http_error.ONLY_TEAM_LEADER_FINISH
+		}
 		return http_error.NOT_ASSIGNED_TO_REPORT
 	}
 
-	afterImageID := fmt.Sprintf("%s_after_%s", reportID, time.Now().Format("20060102150405"))
+	if report.Status != entity.STATUS_ASSIGNED {
+		return http_error.ASSIGNMENT_NOT_OPEN
+	}
+
+	materials, err := s.materialService.BuildUsage(req.ReportID, workerID, req.Materials)
+	if err != nil {
+		return err
+	}
+
+	afterImageID := fmt.Sprintf("%s_after_%s", req.ReportID, time.Now().Format("20060102150405"))
 	afterImageURL, err := s.cloudinaryClient.UploadImage(file, afterImageID, report.Longitude, report.Latitude, "After image")
 	if err != nil {
 		return http_error.CLOUDINARY_UPLOAD_FAILED
 	}
 
-	return s.reportRepo.UpdateAfterImage(reportID, afterImageURL, entity.STATUS_FINISH_BY_WORKER)
+	progress := &entity.ReportProgress{
+		ReportID: req.ReportID,
+		WorkerID: workerID,
+		Stage:    entity.WORK_STAGE_DONE,
+		Photos:   []entity.ReportProgressPhoto{{URL: afterImageURL}},
+	}
+	if err := s.reportRepo.FinishWorkOrder(progress, report.Version, afterImageURL, materials); err != nil {
+		return err
+	}
+
+	s.notifyStage(report, entity.WORK_STAGE_DONE)
+	return nil
 }
 
 func (s *reportService) GetUserReports(userID uuid.UUID, page, limit int) (*dto.PaginatedReportsResponse, error) {
@@ -236,50 +777,823 @@ func (s *reportService) GetWorkerHistory(workerID uuid.UUID, verifyAdmin bool, p
 	return s.buildPaginatedResponse(reports, total, page, limit), nil
 }
 
-func (s *reportService) VerifyReport(reportID string) error {
-	report, err := s.reportRepo.GetReportByID(reportID)
+func (s *reportService) VerifyReport(actx dto.AuditContext, req dto.VerifyReportRequest) error {
+	report, err := s.reportRepo.GetReportByID(req.ReportID)
 	if err != nil {
 		return http_error.REPORT_NOT_FOUND
 	}
 
+	if err := checkVersion(report, req.Version); err != nil {
+		return err
+	}
+
 	if report.Status != entity.STATUS_FINISH_BY_WORKER {
 		return http_error.ONLY_FINISH_BY_WORKER_VERIFY
 	}
 
-	return s.reportRepo.UpdateStatus(reportID, entity.STATUS_FINISHED)
+	if err := s.reportRepo.CompleteReport(req.ReportID, report.Version); err != nil {
+		return err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_REPORT_VERIFY, entity.AUDIT_TARGET_REPORT, req.ReportID, map[string]interface{}{
+		"worker_id": report.WorkerID,
+	})
+	return nil
 }
 
-func (s *reportService) buildPaginatedResponse(reports []entity.Report, total int64, page, limit int) *dto.PaginatedReportsResponse {
-	var reportDTOs []dto.UserReportResponse
-	for _, report := range reports {
-		reportDTOs = append(reportDTOs, dto.UserReportResponse{
-			ID:             report.ID,
-			Longitude:      report.Longitude,
-			Latitude:       report.Latitude,
-			RoadName:       report.RoadName,
-			BeforeImageURL: report.BeforeImageURL,
-			AfterImageURL:  report.AfterImageURL,
-			Description:    report.Description,
-			DestructClass:  report.DestructClass,
-			LocationScore:  report.LocationScore,
-			TotalScore:     report.TotalScore,
-			Status:         report.Status,
-			AdminNotes:     report.AdminNotes,
-			Deadline:       report.Deadline,
-			CreatedAt:      report.CreatedAt,
-		})
+func (s *reportService) GetPendingReports(regionCodes []string, page, limit int) (*dto.PaginatedReportsResponse, error) {
+	offset := (page - 1) * limit
+	reports, total, err := s.reportRepo.GetReportsByStatus(entity.STATUS_PENDING, regionCodes, limit, offset)
+	if err != nil {
+		return nil, err
 	}
 
-	totalPages := int(total) / limit
-	if int(total)%limit != 0 {
-		totalPages++
+	return s.buildPaginatedResponse(reports, total, page, limit), nil
+}
+
+func (s *reportService) ClassifyReport(actx dto.AuditContext, req dto.ClassifyReportRequest) error {
+	report, err := s.reportRepo.GetReportByID(req.ReportID)
+	if err != nil {
+		return http_error.REPORT_NOT_FOUND
 	}
 
-	return &dto.PaginatedReportsResponse{
-		Reports:    reportDTOs,
-		TotalCount: total,
-		Page:       page,
-		Limit:      limit,
-		TotalPages: totalPages,
+	if err := checkVersion(report, req.Version); err != nil {
+		return err
+	}
+
+	if report.Status != entity.STATUS_PENDING {
+		return http_error.REPORT_NOT_PENDING
+	}
+
+	if err := s.reportRepo.UpdateClassification(req.ReportID, report.Version, req.DestructClass, req.LocationScore, req.TotalScore, strings.TrimSpace(req.District)); err != nil {
+		return err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_REPORT_CLASSIFY, entity.AUDIT_TARGET_REPORT, req.ReportID, map[string]interface{}{
+		"destruct_class": req.DestructClass,
+		"total_score":    req.TotalScore,
+	})
+
+	if req.DestructClass != entity.DESTRUCT_CLASS_GOOD {
+		go s.autoAssignService.HandleClassified(req.ReportID)
+	}
+	return nil
+}
+
+func (s *reportService) DeleteReport(actx dto.AuditContext, reportID string, expectedVersion *int) error {
+	report, err := s.reportRepo.GetReportByID(reportID)
+	if err != nil {
+		return http_error.REPORT_NOT_FOUND
+	}
+
+	if err := checkVersion(report, expectedVersion); err != nil {
+		return err
+	}
+
+	if err := s.reportRepo.DeleteReport(reportID, report.Version); err != nil {
+		return err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_REPORT_DELETE, entity.AUDIT_TARGET_REPORT, reportID, map[string]interface{}{
+		"user_id":   report.UserID,
+		"status":    report.Status,
+		"road_name": report.RoadName,
+	})
+	return nil
+}
+
+func (s *reportService) RejectReport(actx dto.AuditContext, req dto.RejectReportRequest) error {
+	reasonLabel, ok := entity.REJECT_REASONS[req.ReasonCode]
+	if !ok {
+		return http_error.INVALID_REJECT_REASON
+	}
+
+	report, err := s.reportRepo.GetReportByID(req.ReportID)
+	if err != nil {
+		return http_error.REPORT_NOT_FOUND
+	}
+
+	if err := checkVersion(report, req.Version); err != nil {
+		return err
+	}
+
+	if report.Status != entity.STATUS_PENDING && report.Status != entity.STATUS_COMPLETED {
+		return http_error.ONLY_UNASSIGNED_REJECT
+	}
+
+	if err := s.reportRepo.RejectReport(req.ReportID, report.Version, req.ReasonCode, req.Note, actx.ActorID, time.Now()); err != nil {
+		return err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_REPORT_REJECT, entity.AUDIT_TARGET_REPORT, req.ReportID, map[string]interface{}{
+		"reason_code": req.ReasonCode,
+		"note":        req.Note,
+		"user_id":     report.UserID,
+	})
+
+	message := fmt.Sprintf("Your report on %s was rejected: %s.", report.RoadName, reasonLabel)
+	if req.Note != "" {
+		message += " Note from admin: " + req.Note
+	}
+	s.notifyCitizen(report, entity.NOTIFICATION_REPORT_REJECTED, "Report rejected", message)
+	s.autoAssignService.OutdateSuggestions(report.ID)
+
+	return nil
+}
+
+// ForwardReport flags a report on a road outside our authority so it can be
+// handed to the responsible agency. It leaves the agency queues and can no
+// longer be assigned.
+func (s *reportService) ForwardReport(actx dto.AuditContext, req dto.ForwardReportRequest) error {
+	report, err := s.reportRepo.GetReportByID(req.ReportID)
+	if err != nil {
+		return http_error.REPORT_NOT_FOUND
+	}
+
+	if err := checkVersion(report, req.Version); err != nil {
+		return err
+	}
+
+	if report.ForwardedAt != nil {
+		return http_error.REPORT_ALREADY_FORWARDED
+	}
+
+	if report.WorkerID != nil || (report.Status != entity.STATUS_PENDING && report.Status != entity.STATUS_COMPLETED) {
+		return http_error.ONLY_UNASSIGNED_FORWARD
+	}
+
+	authority := strings.ToLower(strings.TrimSpace(req.Authority))
+	if authority == "" {
+		authority = report.RoadAuthority
+	}
+	agency, ok := entity.ROAD_AUTHORITIES[authority]
+	if !ok {
+		return http_error.INVALID_ROAD_AUTHORITY
+	}
+	if s.roadService.IsOwnAuthority(authority) {
+		return http_error.REPORT_WITHIN_AUTHORITY
+	}
+
+	if err := s.reportRepo.ForwardReport(req.ReportID, report.Version, authority, req.Note, actx.ActorID, time.Now()); err != nil {
+		return err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_REPORT_FORWARD, entity.AUDIT_TARGET_REPORT, req.ReportID, map[string]interface{}{
+		"authority":       authority,
+		"road_authority":  report.RoadAuthority,
+		"road_segment_id": report.RoadSegmentID,
+		"note":            req.Note,
+	})
+
+	message := fmt.Sprintf("Your report on %s is on a road managed by another agency and was forwarded to: %s.", report.RoadName, agency)
+	if req.Note != "" {
+		message += " Note from admin: " + req.Note
+	}
+	s.notifyCitizen(report, entity.NOTIFICATION_REPORT_FORWARDED, "Report forwarded", message)
+	s.autoAssignService.OutdateSuggestions(report.ID)
+
+	return nil
+}
+
+// GetAuthorityQueue lists the open reports on roads of one authority, most
+// severe first. The unknown queue holds reports not snapped to any road.
+func (s *reportService) GetAuthorityQueue(authority string, regionCodes []string, page, limit int) (*dto.PaginatedReportsResponse, error) {
+	if _, ok := entity.ROAD_AUTHORITIES[authority]; !ok && authority != entity.ROAD_AUTHORITY_UNKNOWN {
+		return nil, http_error.INVALID_ROAD_AUTHORITY
+	}
+
+	offset := (page - 1) * limit
+	reports, total, err := s.reportRepo.GetOpenReportsByAuthority(authority, regionCodes, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildPaginatedResponse(reports, total, page, limit), nil
+}
+
+func (s *reportService) ReworkReport(actx dto.AuditContext, req dto.ReworkReportRequest) error {
+	report, err := s.reportRepo.GetReportByID(req.ReportID)
+	if err != nil {
+		return http_error.REPORT_NOT_FOUND
+	}
+
+	if err := checkVersion(report, req.Version); err != nil {
+		return err
+	}
+
+	if report.Status != entity.STATUS_FINISH_BY_WORKER || report.WorkerID == nil {
+		return http_error.ONLY_FINISH_BY_WORKER_REWORK
+	}
+
+	if req.Deadline != nil && !req.Deadline.After(time.Now()) {
+		return http_error.INVALID_DEADLINE
+	}
+
+	rework := &entity.ReportRework{
+		ReportID:         report.ID,
+		WorkerID:         *report.WorkerID,
+		AfterImageURL:    report.AfterImageURL,
+		FinishedAt:       report.FinishedAt,
+		Notes:            req.Notes,
+		PreviousDeadline: report.Deadline,
+		NewDeadline:      req.Deadline,
+		RequestedBy:      actx.ActorID,
+	}
+
+	if err := s.reportRepo.ReworkReport(rework, report.Version); err != nil {
+		return err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_REPORT_REWORK, entity.AUDIT_TARGET_REPORT, report.ID, map[string]interface{}{
+		"worker_id":    report.WorkerID,
+		"notes":        req.Notes,
+		"new_deadline": req.Deadline,
+		"rework_count": report.ReworkCount + 1,
+	})
+
+	message := fmt.Sprintf("The repair on %s was sent back for rework: %s", report.RoadName, req.Notes)
+	if req.Deadline != nil {
+		message += fmt.Sprintf(" New deadline: %s.", req.Deadline.Format("02 Jan 2006 15:04"))
+	}
+	s.notificationService.Notify(*report.WorkerID, entity.NOTIFICATION_REPORT_REWORK, "Report sent back for rework", message, &report.ID)
+
+	return nil
+}
+
+func (s *reportService) GetReportReworks(reportID string) ([]dto.ReportReworkResponse, error) {
+	if _, err := s.reportRepo.GetReportByID(reportID); err != nil {
+		return nil, http_error.REPORT_NOT_FOUND
+	}
+
+	reworks, err := s.reportRepo.GetReworksByReportID(reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []dto.ReportReworkResponse
+	for _, rework := range reworks {
+		response = append(response, dto.ReportReworkResponse{
+			ID:               rework.ID,
+			WorkerID:         rework.WorkerID,
+			AfterImageURL:    rework.AfterImageURL,
+			FinishedAt:       rework.FinishedAt,
+			Notes:            rework.Notes,
+			PreviousDeadline: rework.PreviousDeadline,
+			NewDeadline:      rework.NewDeadline,
+			RequestedBy:      rework.RequestedBy,
+			CreatedAt:        rework.CreatedAt,
+		})
+	}
+	return response, nil
+}
+
+func (s *reportService) buildPaginatedResponse(reports []entity.Report, total int64, page, limit int) *dto.PaginatedReportsResponse {
+	var reportDTOs []dto.UserReportResponse
+	for _, report := range reports {
+		reportDTOs = append(reportDTOs, toUserReportResponse(report))
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit != 0 {
+		totalPages++
+	}
+
+	return &dto.PaginatedReportsResponse{
+		Reports:    reportDTOs,
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages,
+	}
+}
+
+func toUserReportResponse(report entity.Report) dto.UserReportResponse {
+	return dto.UserReportResponse{
+		ID:                 report.ID,
+		Longitude:          report.Longitude,
+		Latitude:           report.Latitude,
+		RoadName:           report.RoadName,
+		District:           report.District,
+		BeforeImageURL:     report.BeforeImageURL,
+		AfterImageURL:      report.AfterImageURL,
+		Description:        report.Description,
+		DestructClass:      report.DestructClass,
+		LocationScore:      report.LocationScore,
+		TotalScore:         report.TotalScore,
+		Status:             report.Status,
+		AdminNotes:         report.AdminNotes,
+		Deadline:           report.Deadline,
+		SLAPolicy:          toReportSLAPolicyResponse(report.SLAPolicy),
+		RejectReason:       report.RejectReason,
+		RejectNote:         report.RejectNote,
+		ReworkCount:        report.ReworkCount,
+		RequestedClass:     report.RequestedClass,
+		RecurrenceOf:       report.RecurrenceOf,
+		RecurrenceWorkerID: report.RecurrenceWorkerID,
+		FinishedAt:         report.FinishedAt,
+		AssignmentStatus:   report.AssignmentStatus,
+		WorkStage:          report.WorkStage,
+		WorkStageAt:        report.WorkStageAt,
+		TeamID:             report.TeamID,
+		OverdueAt:          report.OverdueAt,
+		EscalatedAt:        report.EscalatedAt,
+		Version:            report.Version,
+		CreatedAt:          report.CreatedAt,
+	}
+}
+
+func toReportSLAPolicyResponse(policy *entity.SLAPolicy) *dto.ReportSLAPolicyResponse {
+	if policy == nil {
+		return nil
+	}
+	return &dto.ReportSLAPolicyResponse{
+		ID:              policy.ID,
+		Name:            policy.Name,
+		ResolutionHours: policy.ResolutionHours,
+	}
+}
+
+func (s *reportService) GetReport(reportID string) (*dto.UserReportResponse, error) {
+	report, err := s.reportRepo.GetReportByID(reportID)
+	if err != nil {
+		return nil, http_error.REPORT_NOT_FOUND
+	}
+
+	response := toUserReportResponse(*report)
+	return &response, nil
+}
+
+// SubmitProgress posts an update on a work order. Any crew member may post
+// notes and photos; moving the work order to a later stage is left to the
+// assigned worker or team leader, and the done stage finishes the report.
+func (s *reportService) SubmitProgress(workerID uuid.UUID, files []*multipart.FileHeader, req dto.ReportProgressRequest) (*dto.ReportProgressResponse, error) {
+	report, err := s.reportRepo.GetReportByID(req.ReportID)
+	if err != nil {
+		return nil, http_error.REPORT_NOT_FOUND
+	}
+
+	if err := checkVersion(report, req.Version); err != nil {
+		return nil, err
+	}
+
+	allowed, err := s.CanViewProgress(req.ReportID, workerID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, http_error.NOT_ASSIGNED_TO_REPORT
+	}
+
+	if report.Status != entity.STATUS_ASSIGNED {
+		return nil, http_error.ASSIGNMENT_NOT_OPEN
+	}
+
+	if req.Stage != "" {
+		if err := checkStageChange(report, workerID, req.Stage); err != nil {
+			return nil, err
+		}
+		if req.Stage == entity.WORK_STAGE_DONE && len(files) == 0 {
+			return nil, http_error.WORK_STAGE_PHOTO_REQUIRED
+		}
+	}
+
+	if len(req.Materials) > 0 && req.Stage != entity.WORK_STAGE_DONE {
+		return nil, http_error.MATERIALS_ONLY_ON_FINISH
+	}
+	materials, err := s.materialService.BuildUsage(req.ReportID, workerID, req.Materials)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) > maxProgressPhotos {
+		return nil, http_error.TOO_MANY_PHOTOS
+	}
+	for _, header := range files {
+		if header.Size > maxFileSize {
+			return nil, http_error.FILE_TOO_LARGE
+		}
+		if !allowedExtensions[strings.ToLower(filepath.Ext(header.Filename))] {
+			return nil, http_error.INVALID_FILE_FORMAT
+		}
+	}
+
+	progress := &entity.ReportProgress{
+		ReportID: req.ReportID,
+		WorkerID: workerID,
+		Stage:    req.Stage,
+		Note:     req.Note,
+	}
+
+	imageIDs := make([]string, 0, len(files))
+	for i, header := range files {
+		url, imageID, err := s.uploadProgressPhoto(report, header, i)
+		if err != nil {
+			s.discardProgressPhotos(imageIDs)
+			return nil, err
+		}
+		imageIDs = append(imageIDs, imageID)
+		progress.Photos = append(progress.Photos, entity.ReportProgressPhoto{URL: url})
+	}
+
+	switch req.Stage {
+	case "":
+		err = s.reportRepo.CreateProgress(progress)
+	case entity.WORK_STAGE_DONE:
+		err = s.reportRepo.FinishWorkOrder(progress, report.Version, progress.Photos[len(progress.Photos)-1].URL, materials)
+	default:
+		err = s.reportRepo.AdvanceWorkStage(progress, report.Version)
+	}
+	if err != nil {
+		s.discardProgressPhotos(imageIDs)
+		return nil, err
+	}
+
+	workerName := ""
+	if worker, _ := s.userRepo.FindUserByID(workerID); worker != nil {
+		workerName = worker.Fullname
+	}
+
+	if report.WorkerID != nil && *report.WorkerID != workerID {
+		s.notificationService.Notify(*report.WorkerID, entity.NOTIFICATION_REPORT_PROGRESS, "Progress update",
+			fmt.Sprintf("%s posted an update on the report on %s.", workerName, report.RoadName), &report.ID)
+	}
+	if req.Stage != "" {
+		s.notifyStage(report, req.Stage)
+	}
+
+	response := toReportProgressResponse(*progress, workerName)
+	return &response, nil
+}
+
+// checkStageChange allows the holder of the assignment to move the work order
+// to a later stage. Stages may be skipped but never revisited.
+func checkStageChange(report *entity.Report, workerID uuid.UUID, stage string) error {
+	next := entity.WorkStageIndex(stage)
+	if next < 0 {
+		return http_error.INVALID_WORK_STAGE
+	}
+
+	if report.WorkerID == nil || *report.WorkerID != workerID {
+		if stage == entity.WORK_STAGE_DONE {
+			return http_error.ONLY_TEAM_LEADER_FINISH
+		}
+		return http_error.ONLY_ASSIGNEE_CHANGE_STAGE
+	}
+
+	if next <= entity.WorkStageIndex(report.WorkStage) {
+		return http_error.WORK_STAGE_NOT_FORWARD
+	}
+	return nil
+}
+
+func (s *reportService) uploadProgressPhoto(report *entity.Report, header *multipart.FileHeader, index int) (string, string, error) {
+	file, err := header.Open()
+	if err != nil {
+		return "", "", err
+	}
+	defer file.Close()
+
+	imageID := fmt.Sprintf("%s_progress_%s_%d", report.ID, time.Now().Format("20060102150405"), index)
+	url, err := s.cloudinaryClient.UploadImage(file, imageID, report.Longitude, report.Latitude, "Progress image")
+	if err != nil {
+		return "", "", http_error.CLOUDINARY_UPLOAD_FAILED
+	}
+	return url, imageID, nil
+}
+
+// discardProgressPhotos deletes photos uploaded for a progress update that
+// was not saved, e.g. because the report changed in the meantime.
+func (s *reportService) discardProgressPhotos(imageIDs []string) {
+	for _, imageID := range imageIDs {
+		if err := s.cloudinaryClient.DeleteImage(imageID); err != nil {
+			utils.InternalErrorLog(err, "image_id", imageID)
+		}
+	}
+}
+
+// notifyStage tells the reporting citizen that the repair reached a stage.
+func (s *reportService) notifyStage(report *entity.Report, stage string) {
+	s.notifyCitizen(report, entity.NOTIFICATION_REPORT_STAGE, "Repair update",
+		fmt.Sprintf("%s: %s.", report.RoadName, entity.WORK_STAGE_LABELS[stage]))
+}
+
+// notifyCitizen notifies the user who filed the report. Reports sent through
+// an API key have no such user and are skipped.
+func (s *reportService) notifyCitizen(report *entity.Report, notificationType, title, message string) {
+	if report.SourceAPIKeyID != nil {
+		return
+	}
+	s.notificationService.Notify(report.UserID, notificationType, title, message, &report.ID)
+}
+
+func (s *reportService) GetWorkOrder(reportID string) (*dto.WorkOrderResponse, error) {
+	report, err := s.reportRepo.GetReportByID(reportID)
+	if err != nil {
+		return nil, http_error.REPORT_NOT_FOUND
+	}
+
+	progress, err := s.reportRepo.GetProgressByReportID(reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[uuid.UUID]string{}
+	response := &dto.WorkOrderResponse{
+		ReportID:    report.ID,
+		Status:      report.Status,
+		WorkStage:   report.WorkStage,
+		WorkStageAt: report.WorkStageAt,
+		Progress:    []dto.ReportProgressResponse{},
+	}
+	for _, p := range progress {
+		name, ok := names[p.WorkerID]
+		if !ok {
+			if worker, _ := s.userRepo.FindUserByID(p.WorkerID); worker != nil {
+				name = worker.Fullname
+			}
+			names[p.WorkerID] = name
+		}
+		response.Progress = append(response.Progress, toReportProgressResponse(p, name))
+	}
+	return response, nil
+}
+
+// CanViewProgress reports whether the worker holds the report's assignment or
+// is on its current crew.
+func (s *reportService) CanViewProgress(reportID string, workerID uuid.UUID) (bool, error) {
+	report, err := s.reportRepo.GetReportByID(reportID)
+	if err != nil {
+		return false, http_error.REPORT_NOT_FOUND
+	}
+
+	if report.WorkerID != nil && *report.WorkerID == workerID {
+		return true, nil
+	}
+	return s.reportRepo.IsCrewMember(reportID, workerID)
+}
+
+// GetReportTimeline summarises the work order for the citizen who reported
+// it: the stages reached and their photos, without notes or worker names.
+func (s *reportService) GetReportTimeline(userID uuid.UUID, reportID string) (*dto.ReportTimelineResponse, error) {
+	report, err := s.reportRepo.GetReportByID(reportID)
+	if err != nil || report.UserID != userID {
+		return nil, http_error.REPORT_NOT_FOUND
+	}
+
+	progress, err := s.reportRepo.GetProgressByReportID(reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ReportTimelineResponse{
+		ReportID:  report.ID,
+		Status:    report.Status,
+		WorkStage: report.WorkStage,
+		Stages:    []dto.WorkStageSummaryResponse{},
+	}
+	for _, p := range progress {
+		if p.Stage == "" {
+			continue
+		}
+		response.Stages = append(response.Stages, dto.WorkStageSummaryResponse{
+			Stage:     p.Stage,
+			Label:     entity.WORK_STAGE_LABELS[p.Stage],
+			ReachedAt: p.CreatedAt,
+			PhotoURLs: photoURLs(p.Photos),
+		})
+	}
+	return response, nil
+}
+
+func toReportProgressResponse(progress entity.ReportProgress, workerName string) dto.ReportProgressResponse {
+	return dto.ReportProgressResponse{
+		ID:         progress.ID,
+		WorkerID:   progress.WorkerID,
+		WorkerName: workerName,
+		Stage:      progress.Stage,
+		Note:       progress.Note,
+		PhotoURLs:  photoURLs(progress.Photos),
+		CreatedAt:  progress.CreatedAt,
+	}
+}
+
+func photoURLs(photos []entity.ReportProgressPhoto) []string {
+	urls := make([]string, 0, len(photos))
+	for _, photo := range photos {
+		urls = append(urls, photo.URL)
+	}
+	return urls
+}
+
+// reportShape is the exact area a search must match after the geohash and
+// bounding box prefilter.
+type reportShape struct {
+	hasCircle bool
+	lat, lng  float64
+	radiusKm  float64
+	polygon   [][][2]float64
+}
+
+func (sh reportShape) contains(report *entity.Report) bool {
+	if sh.hasCircle && utils.HaversineKm(sh.lat, sh.lng, report.Latitude, report.Longitude) > sh.radiusKm {
+		return false
+	}
+	if sh.polygon != nil && !utils.PointInPolygon(report.Latitude, report.Longitude, sh.polygon) {
+		return false
+	}
+	return true
+}
+
+// SearchReports returns the completed, non-good reports inside the requested
+// bounding box, radius and polygon, most severe first. Results stop at the
+// limit and, when a zoom level is given, nearby reports are merged.
+func (s *reportService) SearchReports(req dto.ReportSearchRequest) (*dto.ReportSearchResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	filter, shape, err := buildSpatialFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	cellSize := 0.0
+	if req.Zoom != nil {
+		if *req.Zoom < 0 || *req.Zoom > 22 {
+			return nil, http_error.INVALID_ZOOM
+		}
+		if *req.Zoom < simplifyBelowZoom {
+			cellSize = utils.ZoomCellDegrees(*req.Zoom, simplifyCellPixels)
+		}
+	}
+
+	type gridCell struct{ row, col int64 }
+	cellIndex := map[gridCell]int{}
+	response := &dto.ReportSearchResponse{Reports: []dto.ReportLocationResponse{}, Simplified: cellSize > 0}
+
+	for offset := 0; offset < maxSearchScan; offset += searchBatchSize {
+		reports, err := s.reportRepo.SearchReports(filter, searchBatchSize, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range reports {
+			report := &reports[i]
+			if !shape.contains(report) {
+				continue
+			}
+
+			if cellSize > 0 {
+				cell := gridCell{int64(math.Floor(report.Latitude / cellSize)), int64(math.Floor(report.Longitude / cellSize))}
+				if index, ok := cellIndex[cell]; ok {
+					response.Reports[index].Count++
+					continue
+				}
+				cellIndex[cell] = len(response.Reports)
+			}
+
+			if len(response.Reports) == limit {
+				response.Truncated = true
+				response.Returned = limit
+				return response, nil
+			}
+			location := dto.ReportLocationResponse{
+				ID:            report.ID,
+				Longitude:     report.Longitude,
+				Latitude:      report.Latitude,
+				DestructClass: report.DestructClass,
+				TotalScore:    report.TotalScore,
+				RoadName:      report.RoadName,
+				Status:        report.Status,
+			}
+			if cellSize > 0 {
+				location.Count = 1
+			}
+			response.Reports = append(response.Reports, location)
+		}
+
+		if len(reports) < searchBatchSize {
+			response.Returned = len(response.Reports)
+			return response, nil
+		}
+	}
+
+	response.Truncated = true
+	response.Returned = len(response.Reports)
+	return response, nil
+}
+
+// buildSpatialFilter intersects the requested areas into one bounding box for
+// the database and keeps the circle and polygon for the exact check.
+func buildSpatialFilter(req dto.ReportSearchRequest) (repositories.ReportSpatialFilter, reportShape, error) {
+	var filter repositories.ReportSpatialFilter
+	var shape reportShape
+
+	intersect := func(minLat, minLng, maxLat, maxLng float64) {
+		if !filter.HasBBox {
+			filter.MinLat, filter.MinLng, filter.MaxLat, filter.MaxLng = minLat, minLng, maxLat, maxLng
+			filter.HasBBox = true
+			return
+		}
+		filter.MinLat = math.Max(filter.MinLat, minLat)
+		filter.MinLng = math.Max(filter.MinLng, minLng)
+		filter.MaxLat = math.Min(filter.MaxLat, maxLat)
+		filter.MaxLng = math.Min(filter.MaxLng, maxLng)
+	}
+
+	if req.BBox != nil {
+		if len(req.BBox) != 4 || req.BBox[0] > req.BBox[2] || req.BBox[1] > req.BBox[3] ||
+			!validCoordinate(req.BBox[1], req.BBox[0]) || !validCoordinate(req.BBox[3], req.BBox[2]) {
+			return filter, shape, http_error.INVALID_BBOX
+		}
+		intersect(req.BBox[1], req.BBox[0], req.BBox[3], req.BBox[2])
+	}
+
+	if req.Latitude != nil || req.Longitude != nil || req.RadiusKm != 0 {
+		if req.Latitude == nil || req.Longitude == nil || !validCoordinate(*req.Latitude, *req.Longitude) ||
+			req.RadiusKm <= 0 || req.RadiusKm > maxSearchRadiusKm {
+			return filter, shape, http_error.INVALID_RADIUS
+		}
+		shape.hasCircle = true
+		shape.lat, shape.lng, shape.radiusKm = *req.Latitude, *req.Longitude, req.RadiusKm
+
+		dLat := req.RadiusKm / 111.32
+		dLng := req.RadiusKm / (111.32 * math.Max(math.Cos(shape.lat*math.Pi/180), 0.01))
+		intersect(shape.lat-dLat, shape.lng-dLng, shape.lat+dLat, shape.lng+dLng)
+	}
+
+	if req.Polygon != nil {
+		rings, err := parsePolygon(req.Polygon)
+		if err != nil {
+			return filter, shape, err
+		}
+		shape.polygon = rings
+
+		minLat, minLng, maxLat, maxLng := 90.0, 180.0, -90.0, -180.0
+		for _, position := range rings[0] {
+			minLng, maxLng = math.Min(minLng, position[0]), math.Max(maxLng, position[0])
+			minLat, maxLat = math.Min(minLat, position[1]), math.Max(maxLat, position[1])
+		}
+		intersect(minLat, minLng, maxLat, maxLng)
+	}
+
+	if filter.HasBBox {
+		filter.Cells = utils.GeohashCover(filter.MinLat, filter.MinLng, filter.MaxLat, filter.MaxLng, maxGeohashCells)
+	}
+	return filter, shape, nil
+}
+
+// parsePolygon reads the rings of a GeoJSON Polygon, longitude first.
+func parsePolygon(geometry *dto.GeoJSONGeometry) ([][][2]float64, error) {
+	if geometry.Type != "Polygon" {
+		return nil, http_error.INVALID_POLYGON
+	}
+
+	raw, err := json.Marshal(geometry.Coordinates)
+	if err != nil {
+		return nil, http_error.INVALID_POLYGON
+	}
+	var rings [][][2]float64
+	if err := json.Unmarshal(raw, &rings); err != nil || len(rings) == 0 {
+		return nil, http_error.INVALID_POLYGON
+	}
+
+	for _, ring := range rings {
+		if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+			return nil, http_error.INVALID_POLYGON
+		}
+		for _, position := range ring {
+			if !validCoordinate(position[1], position[0]) {
+				return nil, http_error.INVALID_POLYGON
+			}
+		}
+	}
+	return rings, nil
+}
+
+func validCoordinate(lat, lng float64) bool {
+	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
+
+// BackfillGeohashes gives reports created before geohashes were stored their
+// geohash, a batch at a time. Until then searches match them on coordinates.
+func (s *reportService) BackfillGeohashes() {
+	reports, err := s.reportRepo.GetReportsWithoutGeohash(geohashBackfillBatch)
+	if err != nil {
+		utils.InternalErrorLog(err, "job", "geohash_backfill")
+		return
+	}
+
+	for _, report := range reports {
+		if err := s.reportRepo.SetGeohash(report.ID, utils.GeohashEncode(report.Latitude, report.Longitude, utils.GeohashPrecision)); err != nil {
+			utils.InternalErrorLog(err, "job", "geohash_backfill", "report_id", report.ID)
+		}
+	}
+	if len(reports) > 0 {
+		utils.InfoLog("geohash backfill", "reports", len(reports))
 	}
 }
@@ -0,0 +1,573 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/repositories"
+	"dinacom-11.0-backend/utils"
+)
+
+const (
+	// Reports further than roadSnapMaxMeters from every segment are left
+	// in the unknown authority queue.
+	roadSnapMaxMeters = 50.0
+
+	// Segments are indexed on a grid of roadCellDegrees cells, about a
+	// kilometre wide.
+	roadCellDegrees = 0.01
+
+	roadBackfillBatch = 200
+
+	// Each open damage report multiplies the condition index by
+	// conditionOpenFactor and each recurrence of the last
+	// conditionRecurrenceMemory by conditionRecurrenceFactor.
+	conditionOpenFactor       = 0.8
+	conditionRecurrenceFactor = 0.9
+	conditionRecurrenceMemory = 180 * 24 * time.Hour
+
+	defaultConditionMonths = 12
+	maxConditionMonths     = 36
+)
+
+// roadAuthorityOrder lists the queues from the largest roads down.
+var roadAuthorityOrder = []string{
+	entity.ROAD_AUTHORITY_NATIONAL,
+	entity.ROAD_AUTHORITY_PROVINCIAL,
+	entity.ROAD_AUTHORITY_REGENCY,
+	entity.ROAD_AUTHORITY_CITY,
+	entity.ROAD_AUTHORITY_VILLAGE,
+	entity.ROAD_AUTHORITY_UNKNOWN,
+}
+
+type RoadService interface {
+	LoadNetwork() error
+	ReloadNetwork(actx dto.AuditContext) (*dto.RoadReloadResponse, error)
+	SyncNetwork()
+	SnapReport(report *entity.Report) bool
+	BackfillReportRoads()
+	IsOwnAuthority(authority string) bool
+	GetQueues(regionCodes []string) ([]dto.RoadQueueResponse, error)
+	GetConditions(regionCodes []string, page, limit int) (*dto.PaginatedRoadConditionsResponse, error)
+	GetCondition(id string, regionCodes []string, months int) (*dto.RoadConditionDetailResponse, error)
+}
+
+// roadShape is a road segment held in memory for snapping.
+type roadShape struct {
+	segmentID string
+	name      string
+	authority string
+}
+
+type roadService struct {
+	roadRepo       repositories.RoadRepository
+	reportRepo     repositories.ReportRepository
+	versionRepo    repositories.DatasetVersionRepository
+	auditService   AuditService
+	networkPath    string
+	ownAuthorities map[string]bool
+	shapes         []roadShape
+	index          *utils.LineIndex
+	version        int64
+	mutex          sync.RWMutex
+}
+
+func NewRoadService(roadRepo repositories.RoadRepository, reportRepo repositories.ReportRepository, versionRepo repositories.DatasetVersionRepository, auditService AuditService, networkPath string, ownAuthorities []string) RoadService {
+	own := make(map[string]bool, len(ownAuthorities))
+	for _, authority := range ownAuthorities {
+		own[authority] = true
+	}
+	return &roadService{
+		roadRepo:       roadRepo,
+		reportRepo:     reportRepo,
+		versionRepo:    versionRepo,
+		auditService:   auditService,
+		networkPath:    networkPath,
+		ownAuthorities: own,
+		index:          utils.NewLineIndex(roadCellDegrees),
+	}
+}
+
+// LoadNetwork imports the road files, when a path is configured, and builds
+// the snapping index from the stored segments. It runs at startup.
+func (s *roadService) LoadNetwork() error {
+	if s.networkPath != "" {
+		if _, _, err := s.importNetwork(); err != nil {
+			return err
+		}
+	}
+	version, err := s.versionRepo.GetVersion(entity.DATASET_ROADS)
+	if err != nil {
+		return err
+	}
+	return s.buildIndex(version)
+}
+
+// ReloadNetwork imports the road files again and raises the road network
+// version. Every instance rebuilds its index once it sees the new version,
+// and the backfill job then snaps every report anew.
+func (s *roadService) ReloadNetwork(actx dto.AuditContext) (*dto.RoadReloadResponse, error) {
+	if s.networkPath == "" {
+		return nil, http_error.ROAD_NETWORK_NOT_SET
+	}
+
+	files, segments, err := s.importNetwork()
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.versionRepo.BumpVersion(entity.DATASET_ROADS)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.buildIndex(version); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_ROAD_RELOAD, entity.AUDIT_TARGET_ROAD, "", map[string]interface{}{
+		"files":    files,
+		"segments": segments,
+	})
+	return &dto.RoadReloadResponse{Files: files, Segments: segments}, nil
+}
+
+// importNetwork reads the GeoJSON files at the network path. Each feature
+// needs segment_id and authority properties and may have a name.
+func (s *roadService) importNetwork() (int, int, error) {
+	files, collections, err := utils.ReadFeatureCollections(s.networkPath)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	total := 0
+	for _, file := range files {
+		collection := collections[file]
+		segments := make([]entity.RoadSegment, 0, len(collection.Features))
+		for i, feature := range collection.Features {
+			segment, err := toRoadSegment(feature)
+			if err != nil {
+				return 0, 0, fmt.Errorf("%s feature %d: %w", file, i, err)
+			}
+			segments = append(segments, *segment)
+		}
+		if err := s.roadRepo.UpsertSegments(segments); err != nil {
+			return 0, 0, err
+		}
+		total += len(segments)
+	}
+
+	utils.InfoLog("road network imported", "files", len(files), "segments", total)
+	return len(files), total, nil
+}
+
+// SyncNetwork rebuilds the index when the road network was reloaded on
+// another instance.
+func (s *roadService) SyncNetwork() {
+	if _, err := s.syncNetwork(); err != nil {
+		utils.InternalErrorLog(err, "job", "road_sync")
+	}
+}
+
+// syncNetwork brings the index up to the stored road network version and
+// returns that version.
+func (s *roadService) syncNetwork() (int64, error) {
+	version, err := s.versionRepo.GetVersion(entity.DATASET_ROADS)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mutex.RLock()
+	current := s.version
+	s.mutex.RUnlock()
+	if current == version {
+		return version, nil
+	}
+
+	if err := s.buildIndex(version); err != nil {
+		return 0, err
+	}
+	utils.InfoLog("road network synced", "version", version)
+	return version, nil
+}
+
+func (s *roadService) buildIndex(version int64) error {
+	segments, err := s.roadRepo.GetSegmentsWithGeometry()
+	if err != nil {
+		return err
+	}
+
+	shapes := make([]roadShape, 0, len(segments))
+	index := utils.NewLineIndex(roadCellDegrees)
+	for _, segment := range segments {
+		var geometry dto.GeoJSONGeometry
+		if err := json.Unmarshal([]byte(segment.Geometry), &geometry); err != nil {
+			continue
+		}
+		lines, err := parseRoadGeometry(&geometry)
+		if err != nil {
+			continue
+		}
+
+		index.Add(lines)
+		shapes = append(shapes, roadShape{segmentID: segment.SegmentID, name: segment.Name, authority: segment.Authority})
+	}
+
+	s.mutex.Lock()
+	s.shapes, s.index, s.version = shapes, index, version
+	s.mutex.Unlock()
+	return nil
+}
+
+// SnapReport puts the report on the nearest road segment within 50 metres,
+// storing its ID and authority and, when the reporter left it empty, its
+// name. It returns false while no road network is loaded, leaving the report
+// to the backfill job. Either way the report gets the road key the condition
+// index groups it under.
+func (s *roadService) SnapReport(report *entity.Report) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if len(s.shapes) == 0 {
+		setRoadKey(report)
+		return false
+	}
+
+	var nearest *roadShape
+	if match, ok := s.index.Nearest(report.Latitude, report.Longitude, roadSnapMaxMeters); ok {
+		nearest = &s.shapes[match.Index]
+	}
+
+	report.RoadSegmentID, report.RoadAuthority = "", ""
+	if nearest != nil {
+		report.RoadSegmentID = nearest.segmentID
+		report.RoadAuthority = nearest.authority
+		if strings.TrimSpace(report.RoadName) == "" {
+			report.RoadName = nearest.name
+		}
+	}
+
+	now := time.Now()
+	report.RoadSnappedAt = &now
+	report.RoadsVersion = s.version
+	setRoadKey(report)
+	return true
+}
+
+// BackfillReportRoads snaps reports created before the road network was
+// loaded, or snapped to a network since reloaded, a batch at a time. The
+// index is synced first, so no report is snapped to a network older than the
+// stored version. Reports filed before road keys were stored get theirs
+// first.
+func (s *roadService) BackfillReportRoads() {
+	keyless, err := s.reportRepo.GetReportsWithoutRoadKey(roadBackfillBatch)
+	if err != nil {
+		utils.InternalErrorLog(err, "job", "road_backfill")
+		return
+	}
+	for i := range keyless {
+		setRoadKey(&keyless[i])
+		if err := s.reportRepo.SetRoadKey(keyless[i].ID, *keyless[i].RoadKey); err != nil {
+			utils.InternalErrorLog(err, "job", "road_backfill", "report_id", keyless[i].ID)
+		}
+	}
+
+	version, err := s.syncNetwork()
+	if err != nil {
+		utils.InternalErrorLog(err, "job", "road_backfill")
+		return
+	}
+
+	reports, err := s.reportRepo.GetReportsWithoutRoad(version, roadBackfillBatch)
+	if err != nil {
+		utils.InternalErrorLog(err, "job", "road_backfill")
+		return
+	}
+
+	for i := range reports {
+		if !s.SnapReport(&reports[i]) {
+			return
+		}
+		if err := s.reportRepo.SetRoad(&reports[i]); err != nil {
+			utils.InternalErrorLog(err, "job", "road_backfill", "report_id", reports[i].ID)
+		}
+	}
+}
+
+func (s *roadService) IsOwnAuthority(authority string) bool {
+	return s.ownAuthorities[authority]
+}
+
+// GetQueues counts the open reports waiting for each road authority's agency.
+func (s *roadService) GetQueues(regionCodes []string) ([]dto.RoadQueueResponse, error) {
+	counts, err := s.reportRepo.CountOpenReportsByAuthority(regionCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.RoadQueueResponse, 0, len(roadAuthorityOrder))
+	for _, authority := range roadAuthorityOrder {
+		response = append(response, dto.RoadQueueResponse{
+			Authority: authority,
+			Agency:    entity.ROAD_AUTHORITIES[authority],
+			Own:       s.ownAuthorities[authority],
+			Open:      counts[authority],
+		})
+	}
+	return response, nil
+}
+
+// GetConditions lists the roads with reports, worst condition first,
+// aggregating the reports per road in the database.
+func (s *roadService) GetConditions(regionCodes []string, page, limit int) (*dto.PaginatedRoadConditionsResponse, error) {
+	stats, total, err := s.reportRepo.GetRoadConditionStats(repositories.RoadConditionQuery{
+		RegionCodes:      regionCodes,
+		RecurrenceSince:  time.Now().Add(-conditionRecurrenceMemory),
+		OpenFactor:       conditionOpenFactor,
+		RecurrenceFactor: conditionRecurrenceFactor,
+		Limit:            limit,
+		Offset:           (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	classCounts, err := s.classCounts(stats, regionCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	roads := make([]dto.RoadConditionResponse, 0, len(stats))
+	for _, stat := range stats {
+		roads = append(roads, toRoadCondition(stat, classCounts[stat.RoadKey]))
+	}
+
+	return &dto.PaginatedRoadConditionsResponse{
+		Roads:      roads,
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}, nil
+}
+
+// GetCondition sums up one road with the condition index at the end of each
+// of the last months, the current month ending now. Both come from the same
+// aggregation as the list of roads.
+func (s *roadService) GetCondition(id string, regionCodes []string, months int) (*dto.RoadConditionDetailResponse, error) {
+	if months < 1 || months > maxConditionMonths {
+		months = defaultConditionMonths
+	}
+
+	now := time.Now()
+	stats, _, err := s.reportRepo.GetRoadConditionStats(repositories.RoadConditionQuery{
+		RoadKey:          id,
+		RegionCodes:      regionCodes,
+		RecurrenceSince:  now.Add(-conditionRecurrenceMemory),
+		OpenFactor:       conditionOpenFactor,
+		RecurrenceFactor: conditionRecurrenceFactor,
+		Limit:            1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, http_error.ROAD_NOT_FOUND
+	}
+	classCounts, err := s.classCounts(stats, regionCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	points := make([]time.Time, 0, months)
+	for i := months - 1; i >= 0; i-- {
+		at := monthStart.AddDate(0, 1-i, 0).Add(-time.Microsecond)
+		if at.After(now) {
+			at = now
+		}
+		points = append(points, at)
+	}
+	history, err := s.reportRepo.GetRoadConditionHistory(repositories.RoadConditionHistoryQuery{
+		RoadKey:          id,
+		RegionCodes:      regionCodes,
+		Points:           points,
+		RecurrenceMemory: conditionRecurrenceMemory,
+		OpenFactor:       conditionOpenFactor,
+		RecurrenceFactor: conditionRecurrenceFactor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.RoadConditionDetailResponse{
+		RoadConditionResponse: toRoadCondition(stats[0], classCounts[id]),
+		History:               make([]dto.RoadConditionPoint, 0, len(history)),
+	}
+	for _, point := range history {
+		response.History = append(response.History, dto.RoadConditionPoint{
+			Month:          point.At.In(now.Location()).Format("2006-01"),
+			ConditionIndex: point.ConditionIndex,
+			Open:           point.OpenReports,
+			Recurrences:    point.RecentRecurrences,
+		})
+	}
+	return response, nil
+}
+
+// classCounts counts the reports per destruct class on each of the roads.
+func (s *roadService) classCounts(stats []repositories.RoadConditionStat, regionCodes []string) (map[string]map[string]int, error) {
+	roadKeys := make([]string, 0, len(stats))
+	for _, stat := range stats {
+		roadKeys = append(roadKeys, stat.RoadKey)
+	}
+	counts, err := s.reportRepo.GetRoadClassCounts(roadKeys, regionCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	classCounts := make(map[string]map[string]int, len(stats))
+	for _, count := range counts {
+		if classCounts[count.RoadKey] == nil {
+			classCounts[count.RoadKey] = map[string]int{}
+		}
+		classCounts[count.RoadKey][conditionClass(count.Class)] += count.Total
+	}
+	return classCounts, nil
+}
+
+func toRoadCondition(stat repositories.RoadConditionStat, classCounts map[string]int) dto.RoadConditionResponse {
+	if classCounts == nil {
+		classCounts = map[string]int{}
+	}
+	lastReportAt := stat.LastReportAt
+	response := dto.RoadConditionResponse{
+		ID:             stat.RoadKey,
+		SegmentID:      stat.SegmentID,
+		Name:           stat.Name,
+		Authority:      stat.Authority,
+		ConditionIndex: stat.ConditionIndex,
+		Reports:        stat.Reports,
+		ClassCounts:    classCounts,
+		Open:           stat.OpenReports,
+		Closed:         stat.ClosedReports,
+		Recurrences:    stat.Recurrences,
+		LastReportAt:   &lastReportAt,
+	}
+	if stat.OpenReports+stat.ClosedReports > 0 {
+		response.OpenRatio = float64(stat.OpenReports) / float64(stat.OpenReports+stat.ClosedReports)
+	}
+	if stat.ClosedReports > 0 {
+		response.AvgRepairHours = stat.RepairHours / float64(stat.ClosedReports)
+		response.RecurrenceRate = float64(stat.Recurrences) / float64(stat.ClosedReports)
+	}
+	return response
+}
+
+// setRoadKey files the report under the road it lies on for the condition
+// index.
+func setRoadKey(report *entity.Report) {
+	key := roadConditionID(report)
+	report.RoadKey = &key
+}
+
+// conditionClass names the class an unclassified report is counted under.
+func conditionClass(class string) string {
+	if class == "" {
+		return "unclassified"
+	}
+	return class
+}
+
+// roadConditionID is the snapped segment or, without one, the road name
+// reduced to its words, so "Jl. Sudirman" and "jalan sudirman" meet.
+func roadConditionID(report *entity.Report) string {
+	if report.RoadSegmentID != "" {
+		return report.RoadSegmentID
+	}
+	words := roadNameWords(reportRoadName(report))
+	if len(words) == 0 {
+		return ""
+	}
+	return "name:" + strings.Join(words, "-")
+}
+
+func reportRoadName(report *entity.Report) string {
+	if report.CanonicalRoadName != "" {
+		return report.CanonicalRoadName
+	}
+	return strings.TrimSpace(report.RoadName)
+}
+
+func toRoadSegment(feature dto.GeoJSONFeature) (*entity.RoadSegment, error) {
+	property := func(key string) string {
+		if value, ok := feature.Properties[key]; ok && value != nil {
+			return strings.TrimSpace(fmt.Sprint(value))
+		}
+		return ""
+	}
+
+	segment := &entity.RoadSegment{
+		SegmentID: property("segment_id"),
+		Name:      property("name"),
+		Authority: strings.ToLower(property("authority")),
+	}
+	if segment.SegmentID == "" || entity.ROAD_AUTHORITIES[segment.Authority] == "" {
+		return nil, http_error.INVALID_ROAD_SEGMENT
+	}
+	if _, err := parseRoadGeometry(&feature.Geometry); err != nil {
+		return nil, err
+	}
+
+	geometry, err := json.Marshal(feature.Geometry)
+	if err != nil {
+		return nil, err
+	}
+	segment.Geometry = string(geometry)
+	return segment, nil
+}
+
+// parseRoadGeometry accepts a LineString or MultiLineString and returns its
+// lines as GeoJSON positions.
+func parseRoadGeometry(geometry *dto.GeoJSONGeometry) ([][][2]float64, error) {
+	raw, err := json.Marshal(geometry.Coordinates)
+	if err != nil {
+		return nil, http_error.INVALID_ROAD_SEGMENT
+	}
+
+	var lines [][][2]float64
+	switch geometry.Type {
+	case "LineString":
+		var line [][2]float64
+		if err := json.Unmarshal(raw, &line); err != nil {
+			return nil, http_error.INVALID_ROAD_SEGMENT
+		}
+		lines = [][][2]float64{line}
+	case "MultiLineString":
+		if err := json.Unmarshal(raw, &lines); err != nil {
+			return nil, http_error.INVALID_ROAD_SEGMENT
+		}
+	default:
+		return nil, http_error.INVALID_ROAD_SEGMENT
+	}
+
+	if len(lines) == 0 {
+		return nil, http_error.INVALID_ROAD_SEGMENT
+	}
+	for _, line := range lines {
+		if len(line) < 2 {
+			return nil, http_error.INVALID_ROAD_SEGMENT
+		}
+		for _, position := range line {
+			if !validCoordinate(position[1], position[0]) {
+				return nil, http_error.INVALID_ROAD_SEGMENT
+			}
+		}
+	}
+	return lines, nil
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/repositories"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/google/uuid"
+)
+
+// Travel estimates for ordering stops. A stop that would be reached after its
+// deadline costs as much as a thousand kilometre detour, so the optimiser only
+// trades punctuality for distance when no order meets every deadline.
+const (
+	routeAverageSpeedKmh = 25.0
+	routeServiceTime     = 45 * time.Minute
+	routeLatePenaltyKm   = 1000.0
+	maxRouteStops        = 100
+)
+
+type RouteService interface {
+	GetWorkerRoute(workerID uuid.UUID, latitude, longitude *float64) (*dto.WorkerRouteResponse, error)
+}
+
+type routeService struct {
+	reportRepo   repositories.ReportRepository
+	workerRepo   repositories.WorkerRepository
+	locationRepo repositories.LocationRepository
+}
+
+func NewRouteService(reportRepo repositories.ReportRepository, workerRepo repositories.WorkerRepository, locationRepo repositories.LocationRepository) RouteService {
+	return &routeService{reportRepo: reportRepo, workerRepo: workerRepo, locationRepo: locationRepo}
+}
+
+type routePlan struct {
+	startLat  float64
+	startLng  float64
+	startTime time.Time
+	reports   []entity.Report
+}
+
+// GetWorkerRoute orders the worker's open assignments. The route starts at the
+// given position, else at the last tracked position if it is still fresh,
+// else at the worker's base. The order is seeded with the better of nearest
+// neighbour and earliest deadline first, then improved with 2-opt.
+func (s *routeService) GetWorkerRoute(workerID uuid.UUID, latitude, longitude *float64) (*dto.WorkerRouteResponse, error) {
+	startedFrom := "current_position"
+	if latitude == nil || longitude == nil {
+		if ping, err := s.locationRepo.GetLatestPing(workerID, time.Now().Add(-locationStaleAfter)); err == nil {
+			latitude, longitude = &ping.Latitude, &ping.Longitude
+			startedFrom = "last_known_position"
+		}
+	}
+	if latitude == nil || longitude == nil {
+		profile, err := s.workerRepo.GetWorkerProfile(workerID)
+		if err != nil {
+			return nil, http_error.ROUTE_START_REQUIRED
+		}
+		latitude, longitude = &profile.BaseLatitude, &profile.BaseLongitude
+		startedFrom = "base"
+	}
+
+	reports, _, err := s.reportRepo.GetAssignedReportsByWorkerID(workerID, maxRouteStops, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := routePlan{startLat: *latitude, startLng: *longitude, startTime: time.Now(), reports: reports}
+	order := plan.nearestNeighbour()
+	if byDeadline := plan.earliestDeadlineFirst(); plan.cost(byDeadline) < plan.cost(order) {
+		order = byDeadline
+	}
+	order = plan.twoOpt(order)
+
+	return plan.response(workerID, startedFrom, order), nil
+}
+
+// visit walks the stops in order and calls fn with each leg's distance and the
+// arrival time. It returns the total distance and the time the last repair ends.
+func (p *routePlan) visit(order []int, fn func(index int, legKm float64, arrival time.Time)) (float64, time.Time) {
+	lat, lng, now := p.startLat, p.startLng, p.startTime
+	total := 0.0
+	for _, index := range order {
+		report := p.reports[index]
+		legKm := utils.HaversineKm(lat, lng, report.Latitude, report.Longitude)
+		total += legKm
+		now = now.Add(time.Duration(legKm / routeAverageSpeedKmh * float64(time.Hour)))
+		if fn != nil {
+			fn(index, legKm, now)
+		}
+		now = now.Add(routeServiceTime)
+		lat, lng = report.Latitude, report.Longitude
+	}
+	return total, now
+}
+
+func (p *routePlan) cost(order []int) float64 {
+	late := 0
+	total, _ := p.visit(order, func(index int, _ float64, arrival time.Time) {
+		if p.isLate(index, arrival) {
+			late++
+		}
+	})
+	return total + float64(late)*routeLatePenaltyKm
+}
+
+func (p *routePlan) isLate(index int, arrival time.Time) bool {
+	deadline := p.reports[index].Deadline
+	return deadline != nil && arrival.After(*deadline)
+}
+
+func (p *routePlan) nearestNeighbour() []int {
+	visited := make([]bool, len(p.reports))
+	order := make([]int, 0, len(p.reports))
+	lat, lng := p.startLat, p.startLng
+	for len(order) < len(p.reports) {
+		next, nextKm := -1, math.MaxFloat64
+		for i, report := range p.reports {
+			if visited[i] {
+				continue
+			}
+			if km := utils.HaversineKm(lat, lng, report.Latitude, report.Longitude); km < nextKm {
+				next, nextKm = i, km
+			}
+		}
+		visited[next] = true
+		order = append(order, next)
+		lat, lng = p.reports[next].Latitude, p.reports[next].Longitude
+	}
+	return order
+}
+
+func (p *routePlan) earliestDeadlineFirst() []int {
+	order := make([]int, len(p.reports))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		da, db := p.reports[order[a]].Deadline, p.reports[order[b]].Deadline
+		if da == nil || db == nil {
+			return da != nil
+		}
+		return da.Before(*db)
+	})
+	return order
+}
+
+// twoOpt reverses segments of the order while that lowers the cost.
+func (p *routePlan) twoOpt(order []int) []int {
+	best := p.cost(order)
+	candidate := make([]int, len(order))
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < len(order)-1; i++ {
+			for j := i + 1; j < len(order); j++ {
+				copy(candidate, order)
+				for a, b := i, j; a < b; a, b = a+1, b-1 {
+					candidate[a], candidate[b] = candidate[b], candidate[a]
+				}
+				if cost := p.cost(candidate); cost < best-1e-9 {
+					best = cost
+					copy(order, candidate)
+					improved = true
+				}
+			}
+		}
+	}
+	return order
+}
+
+func (p *routePlan) response(workerID uuid.UUID, startedFrom string, order []int) *dto.WorkerRouteResponse {
+	stops := make([]dto.RouteStopResponse, 0, len(order))
+	cumulative := 0.0
+	late := 0
+	total, end := p.visit(order, func(index int, legKm float64, arrival time.Time) {
+		report := p.reports[index]
+		cumulative += legKm
+		isLate := p.isLate(index, arrival)
+		if isLate {
+			late++
+		}
+		stops = append(stops, dto.RouteStopResponse{
+			Sequence:         len(stops) + 1,
+			ReportID:         report.ID,
+			RoadName:         report.RoadName,
+			Latitude:         report.Latitude,
+			Longitude:        report.Longitude,
+			DestructClass:    report.DestructClass,
+			TotalScore:       report.TotalScore,
+			Deadline:         report.Deadline,
+			LegDistanceKm:    math.Round(legKm*100) / 100,
+			CumulativeKm:     math.Round(cumulative*100) / 100,
+			EstimatedArrival: arrival,
+			Late:             isLate,
+		})
+	})
+
+	return &dto.WorkerRouteResponse{
+		WorkerID:                 workerID,
+		StartLatitude:            p.startLat,
+		StartLongitude:           p.startLng,
+		StartedFrom:              startedFrom,
+		TotalDistanceKm:          math.Round(total*100) / 100,
+		EstimatedDurationMinutes: int(end.Sub(p.startTime).Minutes()),
+		LateStops:                late,
+		Stops:                    stops,
+		GeneratedAt:              p.startTime,
+	}
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	entity "dinacom-11.0-backend/models/entity"
+	"dinacom-11.0-backend/utils"
+)
+
+var routeTestStart = time.Date(2025, 3, 3, 8, 0, 0, 0, time.UTC)
+
+// routeStops places the reports on the equator at the given longitudes, with
+// deadlines counted from the start of the plan where not nil.
+func routeStops(longitudes []float64, deadlines map[int]time.Duration) []entity.Report {
+	reports := make([]entity.Report, len(longitudes))
+	for i, lng := range longitudes {
+		reports[i] = entity.Report{Longitude: lng}
+		if after, ok := deadlines[i]; ok {
+			deadline := routeTestStart.Add(after)
+			reports[i].Deadline = &deadline
+		}
+	}
+	return reports
+}
+
+func TestRoutePlanCost(t *testing.T) {
+	kmPerDegree := utils.HaversineKm(0, 0, 0, 1)
+	tests := []struct {
+		name      string
+		longitude []float64
+		deadlines map[int]time.Duration
+		order     []int
+		want      float64
+	}{
+		{"no stops", nil, nil, []int{}, 0},
+		{"straight line", []float64{0.01, 0.02, 0.03}, nil, []int{0, 1, 2}, 0.03 * kmPerDegree},
+		{"doubling back", []float64{0.01, 0.02, 0.03}, nil, []int{2, 0, 1}, 0.06 * kmPerDegree},
+		{"deadline met", []float64{0.01}, map[int]time.Duration{0: time.Hour}, []int{0}, 0.01 * kmPerDegree},
+		{"deadline missed", []float64{0.01}, map[int]time.Duration{0: time.Minute}, []int{0}, 0.01*kmPerDegree + routeLatePenaltyKm},
+		{
+			"repair time counts towards later deadlines",
+			[]float64{0.01, 0.02},
+			map[int]time.Duration{1: 30 * time.Minute},
+			[]int{0, 1},
+			0.02*kmPerDegree + routeLatePenaltyKm,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := routePlan{startTime: routeTestStart, reports: routeStops(tt.longitude, tt.deadlines)}
+			if got := plan.cost(tt.order); math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("cost(%v) = %v, want %v", tt.order, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoutePlanTwoOpt(t *testing.T) {
+	tests := []struct {
+		name      string
+		longitude []float64
+		deadlines map[int]time.Duration
+		order     []int
+		want      []int
+	}{
+		{"single stop", []float64{0.01}, nil, []int{0}, []int{0}},
+		{"already shortest", []float64{0.01, 0.02, 0.03}, nil, []int{0, 1, 2}, []int{0, 1, 2}},
+		{"uncrosses the route", []float64{0.01, 0.02, 0.03, 0.04}, nil, []int{0, 2, 1, 3}, []int{0, 1, 2, 3}},
+		{"reverses a backwards route", []float64{0.01, 0.02, 0.03}, nil, []int{2, 1, 0}, []int{0, 1, 2}},
+		{
+			"detours for a deadline",
+			[]float64{0.01, 0.05},
+			map[int]time.Duration{1: 30 * time.Minute},
+			[]int{0, 1},
+			[]int{1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := routePlan{startTime: routeTestStart, reports: routeStops(tt.longitude, tt.deadlines)}
+			before := plan.cost(tt.order)
+			got := plan.twoOpt(append([]int(nil), tt.order...))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("twoOpt(%v) = %v, want %v", tt.order, got, tt.want)
+			}
+			if after := plan.cost(got); after > before {
+				t.Errorf("twoOpt raised the cost from %v to %v", before, after)
+			}
+		})
+	}
+}
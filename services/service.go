@@ -1 +1 @@
-package services
\ No newline at end of file
+package services
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/repositories"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/google/uuid"
+)
+
+type ServiceAreaService interface {
+	LoadAreas() error
+	SyncAreas()
+	GetServiceAreas() ([]dto.ServiceAreaResponse, error)
+	CreateServiceArea(actx dto.AuditContext, req dto.ServiceAreaRequest) (*dto.ServiceAreaResponse, error)
+	UpdateServiceArea(actx dto.AuditContext, id uuid.UUID, req dto.ServiceAreaRequest) (*dto.ServiceAreaResponse, error)
+	DeleteServiceArea(actx dto.AuditContext, id uuid.UUID) error
+	CheckLocation(lat, lng float64) (bool, error)
+}
+
+type serviceAreaService struct {
+	serviceAreaRepo repositories.ServiceAreaRepository
+	versionRepo     repositories.DatasetVersionRepository
+	auditService    AuditService
+	mode            string
+	shapes          []regionShape
+	version         int64
+	mutex           sync.RWMutex
+}
+
+func NewServiceAreaService(serviceAreaRepo repositories.ServiceAreaRepository, versionRepo repositories.DatasetVersionRepository, auditService AuditService, mode string) ServiceAreaService {
+	return &serviceAreaService{
+		serviceAreaRepo: serviceAreaRepo,
+		versionRepo:     versionRepo,
+		auditService:    auditService,
+		mode:            mode,
+	}
+}
+
+// LoadAreas reads the active service areas into memory. It runs at startup,
+// after every change and whenever another instance changed them.
+func (s *serviceAreaService) LoadAreas() error {
+	version, err := s.versionRepo.GetVersion(entity.DATASET_SERVICE_AREAS)
+	if err != nil {
+		return err
+	}
+	areas, err := s.serviceAreaRepo.GetServiceAreas(true)
+	if err != nil {
+		return err
+	}
+
+	shapes := make([]regionShape, 0, len(areas))
+	for _, area := range areas {
+		var geometry dto.GeoJSONGeometry
+		if err := json.Unmarshal([]byte(area.Geometry), &geometry); err != nil {
+			continue
+		}
+		polygons, err := parseRegionGeometry(&geometry)
+		if err != nil {
+			continue
+		}
+		shapes = append(shapes, regionShape{
+			code:     area.ID.String(),
+			name:     area.Name,
+			minLat:   area.MinLat,
+			minLng:   area.MinLng,
+			maxLat:   area.MaxLat,
+			maxLng:   area.MaxLng,
+			polygons: polygons,
+		})
+	}
+
+	s.mutex.Lock()
+	s.shapes = shapes
+	s.version = version
+	s.mutex.Unlock()
+	return nil
+}
+
+// SyncAreas reloads the service areas when they were changed on another
+// instance.
+func (s *serviceAreaService) SyncAreas() {
+	version, err := s.versionRepo.GetVersion(entity.DATASET_SERVICE_AREAS)
+	if err != nil {
+		utils.InternalErrorLog(err, "job", "service_area_sync")
+		return
+	}
+
+	s.mutex.RLock()
+	current := s.version
+	s.mutex.RUnlock()
+	if current == version {
+		return
+	}
+
+	if err := s.LoadAreas(); err != nil {
+		utils.InternalErrorLog(err, "job", "service_area_sync")
+	}
+}
+
+// CheckLocation validates the coordinates of a new report. Coordinates out of
+// range or at 0,0 are invalid, and those that only make sense or only fall in
+// a service area the other way round are reported as swapped. Outside every
+// active area the report is refused in reject mode; in flag mode true is
+// returned so it can be marked. Without service areas any valid location is
+// accepted.
+func (s *serviceAreaService) CheckLocation(lat, lng float64) (bool, error) {
+	if !validCoordinate(lat, lng) {
+		if validCoordinate(lng, lat) {
+			return false, http_error.COORDINATES_SWAPPED
+		}
+		return false, http_error.INVALID_COORDINATES
+	}
+	if lat == 0 && lng == 0 {
+		return false, http_error.INVALID_COORDINATES
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if len(s.shapes) == 0 || locate(s.shapes, lat, lng) != nil {
+		return false, nil
+	}
+	if validCoordinate(lng, lat) && locate(s.shapes, lng, lat) != nil {
+		return false, http_error.COORDINATES_SWAPPED
+	}
+	if s.mode == entity.SERVICE_AREA_MODE_REJECT {
+		return false, http_error.OUTSIDE_SERVICE_AREA
+	}
+	return true, nil
+}
+
+func (s *serviceAreaService) GetServiceAreas() ([]dto.ServiceAreaResponse, error) {
+	areas, err := s.serviceAreaRepo.GetServiceAreas(false)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.ServiceAreaResponse{}
+	for _, area := range areas {
+		response = append(response, toServiceAreaResponse(area))
+	}
+	return response, nil
+}
+
+func (s *serviceAreaService) CreateServiceArea(actx dto.AuditContext, req dto.ServiceAreaRequest) (*dto.ServiceAreaResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if _, err := s.serviceAreaRepo.GetServiceAreaByName(name); err == nil {
+		return nil, http_error.SERVICE_AREA_NAME_TAKEN
+	}
+
+	area := &entity.ServiceArea{Name: name, Active: req.Active == nil || *req.Active}
+	if err := setServiceAreaGeometry(area, &req.Geometry); err != nil {
+		return nil, err
+	}
+	if err := s.serviceAreaRepo.CreateServiceArea(area); err != nil {
+		return nil, err
+	}
+	s.reload()
+
+	s.auditService.Record(actx, entity.AUDIT_SERVICE_AREA_CREATE, entity.AUDIT_TARGET_SERVICE_AREA, area.ID.String(), map[string]interface{}{
+		"name":   area.Name,
+		"active": area.Active,
+	})
+
+	response := toServiceAreaResponse(*area)
+	return &response, nil
+}
+
+func (s *serviceAreaService) UpdateServiceArea(actx dto.AuditContext, id uuid.UUID, req dto.ServiceAreaRequest) (*dto.ServiceAreaResponse, error) {
+	area, err := s.serviceAreaRepo.GetServiceAreaByID(id)
+	if err != nil {
+		return nil, http_error.SERVICE_AREA_NOT_FOUND
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if existing, err := s.serviceAreaRepo.GetServiceAreaByName(name); err == nil && existing.ID != id {
+		return nil, http_error.SERVICE_AREA_NAME_TAKEN
+	}
+
+	area.Name = name
+	if req.Active != nil {
+		area.Active = *req.Active
+	}
+	if err := setServiceAreaGeometry(area, &req.Geometry); err != nil {
+		return nil, err
+	}
+	if err := s.serviceAreaRepo.UpdateServiceArea(area); err != nil {
+		return nil, err
+	}
+	s.reload()
+
+	s.auditService.Record(actx, entity.AUDIT_SERVICE_AREA_UPDATE, entity.AUDIT_TARGET_SERVICE_AREA, area.ID.String(), map[string]interface{}{
+		"name":   area.Name,
+		"active": area.Active,
+	})
+
+	response := toServiceAreaResponse(*area)
+	return &response, nil
+}
+
+func (s *serviceAreaService) DeleteServiceArea(actx dto.AuditContext, id uuid.UUID) error {
+	deleted, err := s.serviceAreaRepo.DeleteServiceArea(id)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return http_error.SERVICE_AREA_NOT_FOUND
+	}
+	s.reload()
+
+	s.auditService.Record(actx, entity.AUDIT_SERVICE_AREA_DELETE, entity.AUDIT_TARGET_SERVICE_AREA, id.String(), nil)
+	return nil
+}
+
+// reload raises the service area version, so the other instances pick up
+// the change, and reads the areas again.
+func (s *serviceAreaService) reload() {
+	if _, err := s.versionRepo.BumpVersion(entity.DATASET_SERVICE_AREAS); err != nil {
+		utils.InternalErrorLog(err, "step", "service_areas")
+	}
+	if err := s.LoadAreas(); err != nil {
+		utils.InternalErrorLog(err, "step", "service_areas")
+	}
+}
+
+func setServiceAreaGeometry(area *entity.ServiceArea, geometry *dto.GeoJSONGeometry) error {
+	polygons, err := parseRegionGeometry(geometry)
+	if err != nil {
+		return http_error.INVALID_SERVICE_AREA
+	}
+	raw, err := json.Marshal(geometry)
+	if err != nil {
+		return http_error.INVALID_SERVICE_AREA
+	}
+
+	area.MinLat, area.MinLng, area.MaxLat, area.MaxLng = polygonBounds(polygons)
+	area.Geometry = string(raw)
+	return nil
+}
+
+func toServiceAreaResponse(area entity.ServiceArea) dto.ServiceAreaResponse {
+	var geometry dto.GeoJSONGeometry
+	_ = json.Unmarshal([]byte(area.Geometry), &geometry)
+	return dto.ServiceAreaResponse{
+		ID:        area.ID,
+		Name:      area.Name,
+		Geometry:  geometry,
+		MinLat:    area.MinLat,
+		MinLng:    area.MinLng,
+		MaxLat:    area.MaxLat,
+		MaxLng:    area.MaxLng,
+		Active:    area.Active,
+		CreatedAt: area.CreatedAt,
+		UpdatedAt: area.UpdatedAt,
+	}
+}
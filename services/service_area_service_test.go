@@ -0,0 +1,60 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+)
+
+// serviceAreaTestShape is a rectangle from minLat, minLng to maxLat, maxLng.
+func serviceAreaTestShape(minLat, minLng, maxLat, maxLng float64) regionShape {
+	ring := [][2]float64{{minLng, minLat}, {maxLng, minLat}, {maxLng, maxLat}, {minLng, maxLat}, {minLng, minLat}}
+	return regionShape{
+		minLat:   minLat,
+		minLng:   minLng,
+		maxLat:   maxLat,
+		maxLng:   maxLng,
+		polygons: [][][][2]float64{{ring}},
+	}
+}
+
+func TestServiceAreaCheckLocation(t *testing.T) {
+	jakarta := serviceAreaTestShape(-6.4, 106.6, -6.0, 107.0)
+	// An area whose swapped coordinates are valid ones elsewhere.
+	square := serviceAreaTestShape(10, 30, 20, 40)
+
+	tests := []struct {
+		name        string
+		mode        string
+		shapes      []regionShape
+		lat, lng    float64
+		wantOutside bool
+		wantErr     error
+	}{
+		{"inside an area", entity.SERVICE_AREA_MODE_REJECT, []regionShape{jakarta}, -6.2, 106.8, false, nil},
+		{"latitude out of range is swapped", entity.SERVICE_AREA_MODE_REJECT, []regionShape{jakarta}, 106.8, -6.2, false, http_error.COORDINATES_SWAPPED},
+		{"swapped without areas", entity.SERVICE_AREA_MODE_REJECT, nil, 106.8, -6.2, false, http_error.COORDINATES_SWAPPED},
+		{"out of range either way", entity.SERVICE_AREA_MODE_REJECT, nil, 100, 200, false, http_error.INVALID_COORDINATES},
+		{"null island", entity.SERVICE_AREA_MODE_FLAG, nil, 0, 0, false, http_error.INVALID_COORDINATES},
+		{"swapped into an area", entity.SERVICE_AREA_MODE_REJECT, []regionShape{jakarta, square}, 35, 15, false, http_error.COORDINATES_SWAPPED},
+		{"swapped into an area in flag mode", entity.SERVICE_AREA_MODE_FLAG, []regionShape{square}, 35, 15, false, http_error.COORDINATES_SWAPPED},
+		{"outside every area in reject mode", entity.SERVICE_AREA_MODE_REJECT, []regionShape{jakarta, square}, -7.8, 110.4, false, http_error.OUTSIDE_SERVICE_AREA},
+		{"outside every area in flag mode", entity.SERVICE_AREA_MODE_FLAG, []regionShape{jakarta, square}, -7.8, 110.4, true, nil},
+		{"any valid location without areas", entity.SERVICE_AREA_MODE_REJECT, nil, -7.8, 110.4, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &serviceAreaService{mode: tt.mode, shapes: tt.shapes}
+			outside, err := service.CheckLocation(tt.lat, tt.lng)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("CheckLocation(%v, %v) error = %v, want %v", tt.lat, tt.lng, err, tt.wantErr)
+			}
+			if outside != tt.wantOutside {
+				t.Errorf("CheckLocation(%v, %v) outside = %v, want %v", tt.lat, tt.lng, outside, tt.wantOutside)
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/repositories"
+	"dinacom-11.0-backend/utils"
+
+	"github.com/google/uuid"
+)
+
+type SLAService interface {
+	CheckDeadlines()
+	GetOverdueReports(regionCodes []string) ([]dto.OverdueReportResponse, error)
+	GetWorkerCompliance(from, to *time.Time, regionCodes []string) ([]dto.WorkerSLAResponse, error)
+	GetRoadCompliance(from, to *time.Time, regionCodes []string) ([]dto.RoadSLAResponse, error)
+	GetPolicies() ([]dto.SLAPolicyResponse, error)
+	CreatePolicy(actx dto.AuditContext, req dto.SLAPolicyRequest) (*dto.SLAPolicyResponse, error)
+	UpdatePolicy(actx dto.AuditContext, id uuid.UUID, req dto.SLAPolicyRequest) (*dto.SLAPolicyResponse, error)
+	DeletePolicy(actx dto.AuditContext, id uuid.UUID) error
+	ResolveDeadline(report *entity.Report, from time.Time) (*time.Time, *entity.SLAPolicy, error)
+}
+
+type slaService struct {
+	reportRepo          repositories.ReportRepository
+	userRepo            repositories.UserRepository
+	slaPolicyRepo       repositories.SLAPolicyRepository
+	auditService        AuditService
+	notificationService NotificationService
+	dueSoonWindow       time.Duration
+	escalationGrace     time.Duration
+}
+
+func NewSLAService(reportRepo repositories.ReportRepository, userRepo repositories.UserRepository, slaPolicyRepo repositories.SLAPolicyRepository, auditService AuditService, notificationService NotificationService, dueSoonWindow, escalationGrace time.Duration) SLAService {
+	return &slaService{
+		reportRepo:          reportRepo,
+		userRepo:            userRepo,
+		slaPolicyRepo:       slaPolicyRepo,
+		auditService:        auditService,
+		notificationService: notificationService,
+		dueSoonWindow:       dueSoonWindow,
+		escalationGrace:     escalationGrace,
+	}
+}
+
+// CheckDeadlines is run by the scheduler. Each assigned report moves through
+// due soon, overdue and escalated at most once per deadline; reassigning or
+// sending a report back for rework resets the marks.
+func (s *slaService) CheckDeadlines() {
+	now := time.Now()
+	reports, err := s.reportRepo.GetOpenReportsWithDeadlineBefore(now.Add(s.dueSoonWindow))
+	if err != nil {
+		utils.InternalErrorLog(err, "job", "sla_check")
+		return
+	}
+
+	for _, report := range reports {
+		if report.WorkerID == nil {
+			continue
+		}
+
+		switch {
+		case report.Deadline.After(now):
+			if report.DeadlineWarnedAt == nil {
+				s.warnDueSoon(report, now)
+			}
+		case report.OverdueAt == nil:
+			s.markOverdue(report, now)
+		case report.EscalatedAt == nil && now.Sub(*report.Deadline) >= s.escalationGrace:
+			s.escalate(report, now)
+		}
+	}
+}
+
+func (s *slaService) warnDueSoon(report entity.Report, now time.Time) {
+	marked, err := s.reportRepo.MarkDeadlineWarned(report.ID, now)
+	if err != nil {
+		utils.InternalErrorLog(err, "job", "sla_check", "report_id", report.ID)
+		return
+	}
+	if !marked {
+		return
+	}
+
+	message := fmt.Sprintf("The repair on %s is due %s.", report.RoadName, report.Deadline.Format("02 Jan 2006 15:04"))
+	s.notificationService.Notify(*report.WorkerID, entity.NOTIFICATION_DEADLINE_SOON, "Deadline approaching", message, &report.ID)
+}
+
+func (s *slaService) markOverdue(report entity.Report, now time.Time) {
+	marked, err := s.reportRepo.MarkOverdue(report.ID, now)
+	if err != nil {
+		utils.InternalErrorLog(err, "job", "sla_check", "report_id", report.ID)
+		return
+	}
+	if !marked {
+		return
+	}
+
+	s.auditService.Record(dto.AuditContext{ActorRole: entity.ROLE_SYSTEM}, entity.AUDIT_REPORT_OVERDUE, entity.AUDIT_TARGET_REPORT, report.ID, map[string]interface{}{
+		"worker_id": report.WorkerID,
+		"deadline":  report.Deadline,
+	})
+
+	deadline := report.Deadline.Format("02 Jan 2006 15:04")
+	s.notificationService.Notify(*report.WorkerID, entity.NOTIFICATION_REPORT_OVERDUE, "Report overdue",
+		fmt.Sprintf("The repair on %s passed its deadline of %s.", report.RoadName, deadline), &report.ID)
+	s.notificationService.NotifyRole(entity.ROLE_ADMIN, entity.NOTIFICATION_REPORT_OVERDUE, "Report overdue",
+		fmt.Sprintf("The repair on %s by %s passed its deadline of %s.", report.RoadName, s.workerName(*report.WorkerID), deadline), &report.ID)
+}
+
+func (s *slaService) escalate(report entity.Report, now time.Time) {
+	marked, err := s.reportRepo.MarkEscalated(report.ID, now)
+	if err != nil {
+		utils.InternalErrorLog(err, "job", "sla_check", "report_id", report.ID)
+		return
+	}
+	if !marked {
+		return
+	}
+
+	s.auditService.Record(dto.AuditContext{ActorRole: entity.ROLE_SYSTEM}, entity.AUDIT_REPORT_ESCALATE, entity.AUDIT_TARGET_REPORT, report.ID, map[string]interface{}{
+		"worker_id":     report.WorkerID,
+		"deadline":      report.Deadline,
+		"overdue_hours": roundHours(now.Sub(*report.Deadline)),
+	})
+
+	message := fmt.Sprintf("The repair on %s by %s is %.0f hours past its deadline.", report.RoadName, s.workerName(*report.WorkerID), roundHours(now.Sub(*report.Deadline)))
+	s.notificationService.NotifyRole(entity.ROLE_SUPERVISOR, entity.NOTIFICATION_REPORT_ESCALATED, "Overdue report escalated", message, &report.ID)
+}
+
+func (s *slaService) GetOverdueReports(regionCodes []string) ([]dto.OverdueReportResponse, error) {
+	now := time.Now()
+	reports, err := s.reportRepo.GetOverdueReports(now, regionCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.OverdueReportResponse{}
+	for _, report := range reports {
+		item := dto.OverdueReportResponse{
+			ReportID:     report.ID,
+			WorkerID:     report.WorkerID,
+			RoadName:     report.RoadName,
+			Deadline:     report.Deadline,
+			OverdueHours: roundHours(now.Sub(*report.Deadline)),
+			OverdueAt:    report.OverdueAt,
+			EscalatedAt:  report.EscalatedAt,
+		}
+		if report.WorkerID != nil {
+			item.WorkerName = s.workerName(*report.WorkerID)
+		}
+		response = append(response, item)
+	}
+	return response, nil
+}
+
+func (s *slaService) GetWorkerCompliance(from, to *time.Time, regionCodes []string) ([]dto.WorkerSLAResponse, error) {
+	stats, err := s.reportRepo.GetSLAStatsByWorker(from, to, regionCodes)
+	if err != nil {
+		return nil, err
+	}
+	overdue, err := s.reportRepo.CountOverdueByWorker(time.Now(), regionCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.WorkerSLAResponse{}
+	for _, stat := range stats {
+		workerID, err := uuid.Parse(stat.GroupKey)
+		if err != nil {
+			continue
+		}
+		response = append(response, dto.WorkerSLAResponse{
+			WorkerID:              workerID,
+			WorkerName:            s.workerName(workerID),
+			SLAComplianceResponse: toSLACompliance(stat, overdue[stat.GroupKey]),
+		})
+		delete(overdue, stat.GroupKey)
+	}
+	// Workers with overdue jobs but nothing finished in the period.
+	for key, count := range overdue {
+		workerID, err := uuid.Parse(key)
+		if err != nil {
+			continue
+		}
+		response = append(response, dto.WorkerSLAResponse{
+			WorkerID:              workerID,
+			WorkerName:            s.workerName(workerID),
+			SLAComplianceResponse: toSLACompliance(repositories.SLAStat{GroupKey: key}, count),
+		})
+	}
+	return response, nil
+}
+
+func (s *slaService) GetRoadCompliance(from, to *time.Time, regionCodes []string) ([]dto.RoadSLAResponse, error) {
+	stats, err := s.reportRepo.GetSLAStatsByRoad(from, to, regionCodes)
+	if err != nil {
+		return nil, err
+	}
+	overdue, err := s.reportRepo.CountOverdueByRoad(time.Now(), regionCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.RoadSLAResponse{}
+	for _, stat := range stats {
+		response = append(response, dto.RoadSLAResponse{
+			RoadName:              stat.GroupKey,
+			SLAComplianceResponse: toSLACompliance(stat, overdue[stat.GroupKey]),
+		})
+		delete(overdue, stat.GroupKey)
+	}
+	for road, count := range overdue {
+		response = append(response, dto.RoadSLAResponse{
+			RoadName:              road,
+			SLAComplianceResponse: toSLACompliance(repositories.SLAStat{GroupKey: road}, count),
+		})
+	}
+	return response, nil
+}
+
+func (s *slaService) GetPolicies() ([]dto.SLAPolicyResponse, error) {
+	policies, err := s.slaPolicyRepo.GetPolicies(false)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.SLAPolicyResponse{}
+	for _, policy := range policies {
+		response = append(response, toSLAPolicyResponse(policy))
+	}
+	return response, nil
+}
+
+func (s *slaService) CreatePolicy(actx dto.AuditContext, req dto.SLAPolicyRequest) (*dto.SLAPolicyResponse, error) {
+	policy := &entity.SLAPolicy{
+		Name:            req.Name,
+		DestructClass:   req.DestructClass,
+		MinTotalScore:   req.MinTotalScore,
+		ResolutionHours: req.ResolutionHours,
+		Active:          req.Active == nil || *req.Active,
+		CreatedBy:       actx.ActorID,
+	}
+	if err := s.slaPolicyRepo.CreatePolicy(policy); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_SLA_POLICY_CREATE, entity.AUDIT_TARGET_SLA_POLICY, policy.ID.String(), map[string]interface{}{
+		"destruct_class":   policy.DestructClass,
+		"min_total_score":  policy.MinTotalScore,
+		"resolution_hours": policy.ResolutionHours,
+	})
+
+	response := toSLAPolicyResponse(*policy)
+	return &response, nil
+}
+
+func (s *slaService) UpdatePolicy(actx dto.AuditContext, id uuid.UUID, req dto.SLAPolicyRequest) (*dto.SLAPolicyResponse, error) {
+	policy, err := s.slaPolicyRepo.GetPolicyByID(id)
+	if err != nil {
+		return nil, http_error.SLA_POLICY_NOT_FOUND
+	}
+
+	previousHours := policy.ResolutionHours
+	policy.Name = req.Name
+	policy.DestructClass = req.DestructClass
+	policy.MinTotalScore = req.MinTotalScore
+	policy.ResolutionHours = req.ResolutionHours
+	if req.Active != nil {
+		policy.Active = *req.Active
+	}
+
+	if err := s.slaPolicyRepo.UpdatePolicy(policy); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_SLA_POLICY_UPDATE, entity.AUDIT_TARGET_SLA_POLICY, policy.ID.String(), map[string]interface{}{
+		"destruct_class":            policy.DestructClass,
+		"min_total_score":           policy.MinTotalScore,
+		"previous_resolution_hours": previousHours,
+		"resolution_hours":          policy.ResolutionHours,
+		"active":                    policy.Active,
+	})
+
+	response := toSLAPolicyResponse(*policy)
+	return &response, nil
+}
+
+// DeletePolicy removes a policy. Deadlines it already set are kept.
+func (s *slaService) DeletePolicy(actx dto.AuditContext, id uuid.UUID) error {
+	deleted, err := s.slaPolicyRepo.DeletePolicy(id)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return http_error.SLA_POLICY_NOT_FOUND
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_SLA_POLICY_DELETE, entity.AUDIT_TARGET_SLA_POLICY, id.String(), nil)
+	return nil
+}
+
+// ResolveDeadline picks the most specific active policy matching the report and
+// returns the deadline it gives work starting at from. Both are nil when no
+// policy matches.
+func (s *slaService) ResolveDeadline(report *entity.Report, from time.Time) (*time.Time, *entity.SLAPolicy, error) {
+	policies, err := s.slaPolicyRepo.GetPolicies(true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var selected *entity.SLAPolicy
+	for i := range policies {
+		policy := &policies[i]
+		if !policy.Matches(report) {
+			continue
+		}
+		if selected == nil || policy.MoreSpecificThan(selected) {
+			selected = policy
+		}
+	}
+	if selected == nil {
+		return nil, nil, nil
+	}
+
+	deadline := from.Add(time.Duration(selected.ResolutionHours) * time.Hour)
+	return &deadline, selected, nil
+}
+
+func (s *slaService) workerName(workerID uuid.UUID) string {
+	worker, _ := s.userRepo.FindUserByID(workerID)
+	if worker == nil {
+		return ""
+	}
+	return worker.Fullname
+}
+
+func toSLACompliance(stat repositories.SLAStat, openOverdue int64) dto.SLAComplianceResponse {
+	compliance := dto.SLAComplianceResponse{
+		Completed:     stat.Completed,
+		OnTime:        stat.OnTime,
+		Late:          stat.Completed - stat.OnTime,
+		AvgDelayHours: math.Round(stat.AvgDelayHours*10) / 10,
+		OpenOverdue:   openOverdue,
+	}
+	if stat.Completed > 0 {
+		compliance.ComplianceRate = math.Round(float64(stat.OnTime)/float64(stat.Completed)*1000) / 10
+	}
+	return compliance
+}
+
+func toSLAPolicyResponse(policy entity.SLAPolicy) dto.SLAPolicyResponse {
+	return dto.SLAPolicyResponse{
+		ID:              policy.ID,
+		Name:            policy.Name,
+		DestructClass:   policy.DestructClass,
+		MinTotalScore:   policy.MinTotalScore,
+		ResolutionHours: policy.ResolutionHours,
+		Active:          policy.Active,
+		CreatedAt:       policy.CreatedAt,
+		UpdatedAt:       policy.UpdatedAt,
+	}
+}
+
+func roundHours(d time.Duration) float64 {
+	return math.Round(d.Hours()*10) / 10
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"errors"
+
+	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TeamService interface {
+	GetTeams() ([]dto.TeamResponse, error)
+	GetTeam(id uuid.UUID) (*dto.TeamResponse, error)
+	GetWorkerTeam(workerID uuid.UUID) (*dto.TeamResponse, error)
+	CreateTeam(actx dto.AuditContext, req dto.TeamRequest) (*dto.TeamResponse, error)
+	UpdateTeam(actx dto.AuditContext, id uuid.UUID, req dto.TeamRequest) (*dto.TeamResponse, error)
+	DeleteTeam(actx dto.AuditContext, id uuid.UUID) error
+}
+
+type teamService struct {
+	teamRepo     repositories.TeamRepository
+	userRepo     repositories.UserRepository
+	reportRepo   repositories.ReportRepository
+	auditService AuditService
+}
+
+func NewTeamService(teamRepo repositories.TeamRepository, userRepo repositories.UserRepository, reportRepo repositories.ReportRepository, auditService AuditService) TeamService {
+	return &teamService{
+		teamRepo:     teamRepo,
+		userRepo:     userRepo,
+		reportRepo:   reportRepo,
+		auditService: auditService,
+	}
+}
+
+func (s *teamService) GetTeams() ([]dto.TeamResponse, error) {
+	teams, err := s.teamRepo.GetTeams()
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.TeamResponse{}
+	for _, team := range teams {
+		response = append(response, s.toTeamResponse(team))
+	}
+	return response, nil
+}
+
+func (s *teamService) GetTeam(id uuid.UUID) (*dto.TeamResponse, error) {
+	team, err := s.teamRepo.GetTeamByID(id)
+	if err != nil {
+		return nil, http_error.TEAM_NOT_FOUND
+	}
+
+	response := s.toTeamResponse(*team)
+	return &response, nil
+}
+
+func (s *teamService) GetWorkerTeam(workerID uuid.UUID) (*dto.TeamResponse, error) {
+	team, err := s.teamRepo.GetTeamByWorkerID(workerID)
+	if err != nil {
+		return nil, http_error.TEAM_NOT_FOUND
+	}
+
+	response := s.toTeamResponse(*team)
+	return &response, nil
+}
+
+func (s *teamService) CreateTeam(actx dto.AuditContext, req dto.TeamRequest) (*dto.TeamResponse, error) {
+	if _, err := s.teamRepo.GetTeamByName(req.Name); err == nil {
+		return nil, http_error.TEAM_NAME_TAKEN
+	}
+
+	members, err := s.validateMembers(uuid.Nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	team := &entity.Team{Name: req.Name, LeaderID: req.LeaderID, Members: members}
+	if err := s.teamRepo.CreateTeam(team); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_TEAM_CREATE, entity.AUDIT_TARGET_TEAM, team.ID.String(), map[string]interface{}{
+		"name":       team.Name,
+		"leader_id":  team.LeaderID,
+		"member_ids": memberIDs(members),
+	})
+
+	response := s.toTeamResponse(*team)
+	return &response, nil
+}
+
+// UpdateTeam changes the team for future assignments. Open assignments keep
+// the crew they were given.
+func (s *teamService) UpdateTeam(actx dto.AuditContext, id uuid.UUID, req dto.TeamRequest) (*dto.TeamResponse, error) {
+	team, err := s.teamRepo.GetTeamByID(id)
+	if err != nil {
+		return nil, http_error.TEAM_NOT_FOUND
+	}
+
+	if existing, err := s.teamRepo.GetTeamByName(req.Name); err == nil && existing.ID != id {
+		return nil, http_error.TEAM_NAME_TAKEN
+	}
+
+	members, err := s.validateMembers(id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	previousLeader := team.LeaderID
+	team.Name = req.Name
+	team.LeaderID = req.LeaderID
+	team.Members = members
+	if err := s.teamRepo.UpdateTeam(team); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_TEAM_UPDATE, entity.AUDIT_TARGET_TEAM, team.ID.String(), map[string]interface{}{
+		"name":               team.Name,
+		"previous_leader_id": previousLeader,
+		"leader_id":          team.LeaderID,
+		"member_ids":         memberIDs(members),
+	})
+
+	response := s.toTeamResponse(*team)
+	return &response, nil
+}
+
+func (s *teamService) DeleteTeam(actx dto.AuditContext, id uuid.UUID) error {
+	team, err := s.teamRepo.GetTeamByID(id)
+	if err != nil {
+		return http_error.TEAM_NOT_FOUND
+	}
+
+	open, err := s.reportRepo.CountOpenReportsByTeam(id)
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return http_error.TEAM_HAS_OPEN_REPORTS
+	}
+
+	if err := s.teamRepo.DeleteTeam(id); err != nil {
+		return err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_TEAM_DELETE, entity.AUDIT_TARGET_TEAM, id.String(), map[string]interface{}{
+		"name": team.Name,
+	})
+	return nil
+}
+
+// validateMembers checks that the leader and every member are workers who are
+// not on another team, and returns the member rows with the leader included.
+func (s *teamService) validateMembers(teamID uuid.UUID, req dto.TeamRequest) ([]entity.TeamMember, error) {
+	ids := append([]uuid.UUID{req.LeaderID}, req.MemberIDs...)
+	seen := make(map[uuid.UUID]bool, len(ids))
+
+	var members []entity.TeamMember
+	for _, workerID := range ids {
+		if seen[workerID] {
+			continue
+		}
+		seen[workerID] = true
+
+		worker, err := s.userRepo.FindUserByID(workerID)
+		if err != nil || worker == nil {
+			return nil, http_error.WORKER_NOT_FOUND
+		}
+		if worker.Role != entity.ROLE_WORKER {
+			return nil, http_error.ONLY_WORKER_CAN_ASSIGN
+		}
+
+		current, err := s.teamRepo.GetTeamByWorkerID(workerID)
+		if err == nil && current.ID != teamID {
+			return nil, http_error.WORKER_IN_OTHER_TEAM
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		members = append(members, entity.TeamMember{TeamID: teamID, WorkerID: workerID})
+	}
+	return members, nil
+}
+
+func (s *teamService) toTeamResponse(team entity.Team) dto.TeamResponse {
+	response := dto.TeamResponse{
+		ID:        team.ID,
+		Name:      team.Name,
+		LeaderID:  team.LeaderID,
+		Members:   []dto.TeamMemberResponse{},
+		CreatedAt: team.CreatedAt,
+		UpdatedAt: team.UpdatedAt,
+	}
+
+	for _, member := range team.Members {
+		name := ""
+		if worker, _ := s.userRepo.FindUserByID(member.WorkerID); worker != nil {
+			name = worker.Fullname
+		}
+		if member.WorkerID == team.LeaderID {
+			response.LeaderName = name
+		}
+		response.Members = append(response.Members, dto.TeamMemberResponse{
+			WorkerID:   member.WorkerID,
+			WorkerName: name,
+			IsLeader:   member.WorkerID == team.LeaderID,
+		})
+	}
+	return response
+}
+
+func memberIDs(members []entity.TeamMember) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.WorkerID)
+	}
+	return ids
+}
@@ -0,0 +1,500 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"dinacom-11.0-backend/models/dto"
+	entity "dinacom-11.0-backend/models/entity"
+	http_error "dinacom-11.0-backend/models/error"
+	"dinacom-11.0-backend/repositories"
+
+	"github.com/google/uuid"
+)
+
+const maxAvailabilityDays = 31
+
+type WorkerService interface {
+	GetWorkerProfile(workerID uuid.UUID) (*dto.WorkerProfileResponse, error)
+	UpdateWorkerProfile(actx dto.AuditContext, workerID uuid.UUID, req dto.WorkerProfileRequest) (*dto.WorkerProfileResponse, error)
+	GetWorkerSchedule(workerID uuid.UUID) (*dto.WorkerScheduleResponse, error)
+	UpdateWorkerShifts(actx dto.AuditContext, workerID uuid.UUID, req dto.UpdateWorkerShiftsRequest) (*dto.WorkerScheduleResponse, error)
+	CreateLeave(actx dto.AuditContext, workerID uuid.UUID, req dto.WorkerLeaveRequest) (*dto.WorkerLeaveResponse, error)
+	DeleteLeave(actx dto.AuditContext, workerID uuid.UUID, leaveID uuid.UUID) error
+	CheckAvailability(workerID uuid.UUID, at time.Time) (*dto.WorkerAvailabilityResponse, error)
+	CheckAvailabilities(workerIDs []uuid.UUID, at time.Time) (map[uuid.UUID]*dto.WorkerAvailabilityResponse, error)
+	GetAvailability(from, to time.Time) ([]dto.WorkerAvailabilityRangeResponse, error)
+}
+
+type workerService struct {
+	workerRepo          repositories.WorkerRepository
+	userRepo            repositories.UserRepository
+	reportRepo          repositories.ReportRepository
+	auditService        AuditService
+	notificationService NotificationService
+}
+
+func NewWorkerService(workerRepo repositories.WorkerRepository, userRepo repositories.UserRepository, reportRepo repositories.ReportRepository, auditService AuditService, notificationService NotificationService) WorkerService {
+	return &workerService{
+		workerRepo:          workerRepo,
+		userRepo:            userRepo,
+		reportRepo:          reportRepo,
+		auditService:        auditService,
+		notificationService: notificationService,
+	}
+}
+
+func (s *workerService) GetWorkerProfile(workerID uuid.UUID) (*dto.WorkerProfileResponse, error) {
+	worker, err := s.findWorker(workerID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.workerRepo.GetWorkerProfile(workerID)
+	if err != nil {
+		return nil, http_error.WORKER_PROFILE_NOT_FOUND
+	}
+
+	response := toWorkerProfileResponse(*profile, worker.Fullname)
+	return &response, nil
+}
+
+func (s *workerService) UpdateWorkerProfile(actx dto.AuditContext, workerID uuid.UUID, req dto.WorkerProfileRequest) (*dto.WorkerProfileResponse, error) {
+	worker, err := s.findWorker(workerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var skills []string
+	seen := map[string]bool{}
+	for _, skill := range req.Skills {
+		skill = strings.ToLower(strings.TrimSpace(skill))
+		if skill != "" && !seen[skill] {
+			seen[skill] = true
+			skills = append(skills, skill)
+		}
+	}
+
+	profile, err := s.workerRepo.GetWorkerProfile(workerID)
+	if err != nil {
+		profile = &entity.WorkerProfile{WorkerID: workerID, AutoAssign: true}
+	}
+	profile.BaseLatitude = *req.BaseLatitude
+	profile.BaseLongitude = *req.BaseLongitude
+	profile.BaseRadiusKm = req.BaseRadiusKm
+	profile.Skills = strings.Join(skills, ",")
+	profile.MaxConcurrentJobs = req.MaxConcurrentJobs
+	if req.AutoAssign != nil {
+		profile.AutoAssign = *req.AutoAssign
+	}
+	profile.Contractor = strings.TrimSpace(req.Contractor)
+
+	if err := s.workerRepo.SaveWorkerProfile(profile); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_WORKER_PROFILE_UPDATE, entity.AUDIT_TARGET_USER, workerID.String(), map[string]interface{}{
+		"base_latitude":       profile.BaseLatitude,
+		"base_longitude":      profile.BaseLongitude,
+		"base_radius_km":      profile.BaseRadiusKm,
+		"skills":              skills,
+		"max_concurrent_jobs": profile.MaxConcurrentJobs,
+		"auto_assign":         profile.AutoAssign,
+		"contractor":          profile.Contractor,
+	})
+
+	response := toWorkerProfileResponse(*profile, worker.Fullname)
+	return &response, nil
+}
+
+func (s *workerService) GetWorkerSchedule(workerID uuid.UUID) (*dto.WorkerScheduleResponse, error) {
+	worker, err := s.findWorker(workerID)
+	if err != nil {
+		return nil, err
+	}
+
+	shifts, err := s.workerRepo.GetShiftsByWorkerID(workerID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := startOfDay(time.Now())
+	leaves, err := s.workerRepo.GetLeaves(&workerID, today, today.AddDate(1, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	_, openJobs, err := s.reportRepo.GetAssignedReportsByWorkerID(workerID, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.WorkerScheduleResponse{
+		WorkerID:   worker.ID,
+		WorkerName: worker.Fullname,
+		OpenJobs:   openJobs,
+		Shifts:     toWorkerShiftResponses(shifts),
+		Leaves:     []dto.WorkerLeaveResponse{},
+	}
+	if profile, _ := s.workerRepo.GetWorkerProfile(workerID); profile != nil {
+		response.MaxConcurrentJobs = profile.MaxConcurrentJobs
+	}
+	for _, leave := range leaves {
+		response.Leaves = append(response.Leaves, toWorkerLeaveResponse(leave))
+	}
+	return response, nil
+}
+
+func (s *workerService) UpdateWorkerShifts(actx dto.AuditContext, workerID uuid.UUID, req dto.UpdateWorkerShiftsRequest) (*dto.WorkerScheduleResponse, error) {
+	if _, err := s.findWorker(workerID); err != nil {
+		return nil, err
+	}
+
+	shifts := make([]entity.WorkerShift, 0, len(req.Shifts))
+	for _, shift := range req.Shifts {
+		if !validClock(shift.StartTime) || !validClock(shift.EndTime) || shift.StartTime == shift.EndTime {
+			return nil, http_error.INVALID_SHIFT
+		}
+		shifts = append(shifts, entity.WorkerShift{
+			WorkerID:  workerID,
+			Weekday:   *shift.Weekday,
+			StartTime: shift.StartTime,
+			EndTime:   shift.EndTime,
+		})
+	}
+
+	if err := s.workerRepo.ReplaceShifts(workerID, shifts); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_WORKER_SHIFTS_UPDATE, entity.AUDIT_TARGET_USER, workerID.String(), map[string]interface{}{
+		"shifts": toWorkerShiftResponses(shifts),
+	})
+
+	return s.GetWorkerSchedule(workerID)
+}
+
+// CreateLeave records leave or sick days. When workers report it themselves the
+// admins are told, since open assignments may need to be moved.
+func (s *workerService) CreateLeave(actx dto.AuditContext, workerID uuid.UUID, req dto.WorkerLeaveRequest) (*dto.WorkerLeaveResponse, error) {
+	worker, err := s.findWorker(workerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Type != entity.LEAVE_TYPE_LEAVE && req.Type != entity.LEAVE_TYPE_SICK {
+		return nil, http_error.INVALID_LEAVE
+	}
+
+	startDate, err := time.ParseInLocation("2006-01-02", req.StartDate, time.Local)
+	if err != nil {
+		return nil, http_error.INVALID_LEAVE
+	}
+	endDate, err := time.ParseInLocation("2006-01-02", req.EndDate, time.Local)
+	if err != nil || endDate.Before(startDate) {
+		return nil, http_error.INVALID_LEAVE
+	}
+
+	leave := &entity.WorkerLeave{
+		WorkerID:  workerID,
+		Type:      req.Type,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Reason:    req.Reason,
+		CreatedBy: actx.ActorID,
+	}
+
+	if err := s.workerRepo.CreateLeave(leave); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_WORKER_LEAVE_CREATE, entity.AUDIT_TARGET_USER, workerID.String(), map[string]interface{}{
+		"leave_id":   leave.ID,
+		"type":       leave.Type,
+		"start_date": req.StartDate,
+		"end_date":   req.EndDate,
+	})
+
+	if actx.ActorID != nil && *actx.ActorID == workerID {
+		s.notificationService.NotifyRole(entity.ROLE_ADMIN, entity.NOTIFICATION_WORKER_LEAVE, "Worker unavailable",
+			fmt.Sprintf("%s reported %s from %s to %s: %s", worker.Fullname, leave.Type, req.StartDate, req.EndDate, req.Reason), nil)
+	}
+
+	response := toWorkerLeaveResponse(*leave)
+	return &response, nil
+}
+
+func (s *workerService) DeleteLeave(actx dto.AuditContext, workerID uuid.UUID, leaveID uuid.UUID) error {
+	leave, err := s.workerRepo.GetLeaveByID(leaveID)
+	if err != nil || leave.WorkerID != workerID {
+		return http_error.LEAVE_NOT_FOUND
+	}
+
+	if err := s.workerRepo.DeleteLeave(leaveID); err != nil {
+		return err
+	}
+
+	s.auditService.Record(actx, entity.AUDIT_WORKER_LEAVE_DELETE, entity.AUDIT_TARGET_USER, workerID.String(), map[string]interface{}{
+		"leave_id":   leaveID,
+		"type":       leave.Type,
+		"start_date": leave.StartDate.Format("2006-01-02"),
+		"end_date":   leave.EndDate.Format("2006-01-02"),
+	})
+	return nil
+}
+
+// CheckAvailability decides whether a worker can take another job at the given
+// moment. Leave and a full job limit make the worker unavailable; being off
+// shift is only a warning, since work can be scheduled for the next shift.
+// Workers without shifts are treated as always on shift.
+func (s *workerService) CheckAvailability(workerID uuid.UUID, at time.Time) (*dto.WorkerAvailabilityResponse, error) {
+	shifts, err := s.workerRepo.GetShiftsByWorkerID(workerID)
+	if err != nil {
+		return nil, err
+	}
+
+	day := startOfDay(at)
+	leaves, err := s.workerRepo.GetLeaves(&workerID, day, day)
+	if err != nil {
+		return nil, err
+	}
+
+	_, openJobs, err := s.reportRepo.GetAssignedReportsByWorkerID(workerID, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	maxJobs := 0
+	if profile, _ := s.workerRepo.GetWorkerProfile(workerID); profile != nil {
+		maxJobs = profile.MaxConcurrentJobs
+	}
+	return availabilityAt(workerID, at, shifts, leaves, openJobs, maxJobs), nil
+}
+
+// CheckAvailabilities checks the workers all at once, loading the shifts,
+// leaves, open jobs and profiles with one query each.
+func (s *workerService) CheckAvailabilities(workerIDs []uuid.UUID, at time.Time) (map[uuid.UUID]*dto.WorkerAvailabilityResponse, error) {
+	allShifts, err := s.workerRepo.GetAllShifts()
+	if err != nil {
+		return nil, err
+	}
+	shiftsByWorker := map[uuid.UUID][]entity.WorkerShift{}
+	for _, shift := range allShifts {
+		shiftsByWorker[shift.WorkerID] = append(shiftsByWorker[shift.WorkerID], shift)
+	}
+
+	day := startOfDay(at)
+	allLeaves, err := s.workerRepo.GetLeaves(nil, day, day)
+	if err != nil {
+		return nil, err
+	}
+	leavesByWorker := map[uuid.UUID][]entity.WorkerLeave{}
+	for _, leave := range allLeaves {
+		leavesByWorker[leave.WorkerID] = append(leavesByWorker[leave.WorkerID], leave)
+	}
+
+	openJobs, err := s.reportRepo.CountOpenJobsByWorker(workerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles, err := s.workerRepo.GetWorkerProfiles()
+	if err != nil {
+		return nil, err
+	}
+	maxJobs := map[uuid.UUID]int{}
+	for _, profile := range profiles {
+		maxJobs[profile.WorkerID] = profile.MaxConcurrentJobs
+	}
+
+	availabilities := make(map[uuid.UUID]*dto.WorkerAvailabilityResponse, len(workerIDs))
+	for _, workerID := range workerIDs {
+		availabilities[workerID] = availabilityAt(workerID, at, shiftsByWorker[workerID], leavesByWorker[workerID], openJobs[workerID], maxJobs[workerID])
+	}
+	return availabilities, nil
+}
+
+// availabilityAt decides whether the worker can take a job at the given
+// time. Leave and a full job limit make the worker unavailable; being off
+// shift is only a warning.
+func availabilityAt(workerID uuid.UUID, at time.Time, shifts []entity.WorkerShift, leaves []entity.WorkerLeave, openJobs int64, maxJobs int) *dto.WorkerAvailabilityResponse {
+	availability := &dto.WorkerAvailabilityResponse{
+		WorkerID:          workerID,
+		OnShift:           len(shifts) == 0,
+		OpenJobs:          openJobs,
+		MaxConcurrentJobs: maxJobs,
+		Reasons:           []string{},
+		Warnings:          []string{},
+	}
+
+	for _, leave := range leaves {
+		if leave.Covers(at) {
+			availability.LeaveType = leave.Type
+			availability.Reasons = append(availability.Reasons, fmt.Sprintf("on %s until %s", leave.Type, leave.EndDate.Format("2006-01-02")))
+			break
+		}
+	}
+
+	if availability.MaxConcurrentJobs > 0 && openJobs >= int64(availability.MaxConcurrentJobs) {
+		availability.Reasons = append(availability.Reasons, fmt.Sprintf("at job limit (%d/%d open jobs)", openJobs, availability.MaxConcurrentJobs))
+	}
+
+	for _, shift := range shifts {
+		if shift.Covers(at) {
+			availability.OnShift = true
+			break
+		}
+	}
+	if !availability.OnShift {
+		availability.Warnings = append(availability.Warnings, "off shift")
+	}
+
+	availability.Available = len(availability.Reasons) == 0
+	return availability
+}
+
+func (s *workerService) GetAvailability(from, to time.Time) ([]dto.WorkerAvailabilityRangeResponse, error) {
+	from, to = startOfDay(from), startOfDay(to)
+	if to.Before(from) || to.Sub(from) >= maxAvailabilityDays*24*time.Hour {
+		return nil, http_error.INVALID_DATE_RANGE
+	}
+
+	workers, err := s.userRepo.GetUsersByRole(entity.ROLE_WORKER)
+	if err != nil {
+		return nil, err
+	}
+
+	allShifts, err := s.workerRepo.GetAllShifts()
+	if err != nil {
+		return nil, err
+	}
+	shiftsByWorker := map[uuid.UUID][]entity.WorkerShift{}
+	for _, shift := range allShifts {
+		shiftsByWorker[shift.WorkerID] = append(shiftsByWorker[shift.WorkerID], shift)
+	}
+
+	allLeaves, err := s.workerRepo.GetLeaves(nil, from, to)
+	if err != nil {
+		return nil, err
+	}
+	leavesByWorker := map[uuid.UUID][]entity.WorkerLeave{}
+	for _, leave := range allLeaves {
+		leavesByWorker[leave.WorkerID] = append(leavesByWorker[leave.WorkerID], leave)
+	}
+
+	profiles, err := s.workerRepo.GetWorkerProfiles()
+	if err != nil {
+		return nil, err
+	}
+	maxJobs := map[uuid.UUID]int{}
+	for _, profile := range profiles {
+		maxJobs[profile.WorkerID] = profile.MaxConcurrentJobs
+	}
+
+	workerIDs := make([]uuid.UUID, 0, len(workers))
+	for _, worker := range workers {
+		workerIDs = append(workerIDs, worker.ID)
+	}
+	openJobsByWorker, err := s.reportRepo.CountOpenJobsByWorker(workerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []dto.WorkerAvailabilityRangeResponse
+	for _, worker := range workers {
+		openJobs := openJobsByWorker[worker.ID]
+		item := dto.WorkerAvailabilityRangeResponse{
+			WorkerID:          worker.ID,
+			WorkerName:        worker.Fullname,
+			OpenJobs:          openJobs,
+			MaxConcurrentJobs: maxJobs[worker.ID],
+			AtCapacity:        maxJobs[worker.ID] > 0 && openJobs >= int64(maxJobs[worker.ID]),
+		}
+
+		shifts := shiftsByWorker[worker.ID]
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			entry := dto.WorkerAvailabilityDay{
+				Date:   day.Format("2006-01-02"),
+				Shifts: []dto.WorkerShiftResponse{},
+			}
+			for _, shift := range shifts {
+				if shift.Weekday == int(day.Weekday()) {
+					entry.Shifts = append(entry.Shifts, toWorkerShiftResponse(shift))
+				}
+			}
+			for _, leave := range leavesByWorker[worker.ID] {
+				if leave.Covers(day) {
+					entry.LeaveType = leave.Type
+					break
+				}
+			}
+			entry.Available = entry.LeaveType == "" && (len(shifts) == 0 || len(entry.Shifts) > 0)
+			item.Days = append(item.Days, entry)
+		}
+
+		response = append(response, item)
+	}
+	return response, nil
+}
+
+func (s *workerService) findWorker(workerID uuid.UUID) (*entity.User, error) {
+	worker, err := s.userRepo.FindUserByID(workerID)
+	if err != nil || worker == nil || worker.Role != entity.ROLE_WORKER {
+		return nil, http_error.WORKER_NOT_FOUND
+	}
+	return worker, nil
+}
+
+func validClock(value string) bool {
+	_, err := time.Parse("15:04", value)
+	return err == nil && len(value) == 5
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func toWorkerProfileResponse(profile entity.WorkerProfile, workerName string) dto.WorkerProfileResponse {
+	return dto.WorkerProfileResponse{
+		WorkerID:          profile.WorkerID,
+		WorkerName:        workerName,
+		BaseLatitude:      profile.BaseLatitude,
+		BaseLongitude:     profile.BaseLongitude,
+		BaseRadiusKm:      profile.BaseRadiusKm,
+		Skills:            profile.SkillList(),
+		MaxConcurrentJobs: profile.MaxConcurrentJobs,
+		AutoAssign:        profile.AutoAssign,
+		Contractor:        profile.Contractor,
+		UpdatedAt:         profile.UpdatedAt,
+	}
+}
+
+func toWorkerShiftResponse(shift entity.WorkerShift) dto.WorkerShiftResponse {
+	return dto.WorkerShiftResponse{
+		Weekday:   shift.Weekday,
+		StartTime: shift.StartTime,
+		EndTime:   shift.EndTime,
+	}
+}
+
+func toWorkerShiftResponses(shifts []entity.WorkerShift) []dto.WorkerShiftResponse {
+	response := []dto.WorkerShiftResponse{}
+	for _, shift := range shifts {
+		response = append(response, toWorkerShiftResponse(shift))
+	}
+	return response
+}
+
+func toWorkerLeaveResponse(leave entity.WorkerLeave) dto.WorkerLeaveResponse {
+	return dto.WorkerLeaveResponse{
+		ID:        leave.ID,
+		WorkerID:  leave.WorkerID,
+		Type:      leave.Type,
+		StartDate: leave.StartDate.Format("2006-01-02"),
+		EndDate:   leave.EndDate.Format("2006-01-02"),
+		Reason:    leave.Reason,
+		CreatedBy: leave.CreatedBy,
+		CreatedAt: leave.CreatedAt,
+	}
+}
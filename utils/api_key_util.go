@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const apiKeyPrefix = "slj"
+
+// GenerateAPIKey returns the plaintext key shown once to the admin, the public
+// prefix used to identify it in listings and the hash stored in the database.
+func GenerateAPIKey() (rawKey string, prefix string, hash string, err error) {
+	idBytes := make([]byte, 4)
+	if _, err = rand.Read(idBytes); err != nil {
+		return "", "", "", err
+	}
+	secretBytes := make([]byte, 32)
+	if _, err = rand.Read(secretBytes); err != nil {
+		return "", "", "", err
+	}
+
+	prefix = apiKeyPrefix + "_" + hex.EncodeToString(idBytes)
+	rawKey = prefix + "_" + hex.EncodeToString(secretBytes)
+	return rawKey, prefix, HashAPIKey(rawKey), nil
+}
+
+func HashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(rawKey)))
+	return hex.EncodeToString(sum[:])
+}
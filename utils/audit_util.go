@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"dinacom-11.0-backend/models/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetAuditContext collects the actor, IP and request ID of the current request
+// as set by AuthMiddleware and RequestIDMiddleware.
+func GetAuditContext(c *gin.Context) dto.AuditContext {
+	actx := dto.AuditContext{
+		IP:        c.ClientIP(),
+		RequestID: c.GetString("request_id"),
+		ActorRole: c.GetString("role"),
+	}
+
+	if userIDVal, exists := c.Get("user_id"); exists {
+		if userID, ok := userIDVal.(uuid.UUID); ok {
+			actx.ActorID = &userID
+		}
+	}
+	if apiKeyIDVal, exists := c.Get("api_key_id"); exists {
+		if apiKeyID, ok := apiKeyIDVal.(uuid.UUID); ok {
+			actx.APIKeyID = &apiKeyID
+		}
+	}
+
+	return actx
+}
+
+// GetRegionScope returns the regions the admin making the request is limited
+// to, as set by AuthMiddleware. Empty means the request is not limited.
+func GetRegionScope(c *gin.Context) []string {
+	regionCodes, _ := c.Get("region_codes")
+	scope, _ := regionCodes.([]string)
+	return scope
+}
@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SendCachedData writes a public, cacheable body with an ETag derived from its
+// content, answering 304 when the client already holds it.
+func SendCachedData(c *gin.Context, contentType string, body []byte, maxAgeSeconds int) {
+	sum := sha1.Sum(body)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAgeSeconds))
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, contentType, body)
+}
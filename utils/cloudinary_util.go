@@ -51,3 +51,11 @@ func (c *CloudinaryClient) UploadImage(file multipart.File, reportID string, lon
 
 	return result.SecureURL, nil
 }
+
+// DeleteImage removes an image stored by UploadImage under the same ID.
+func (c *CloudinaryClient) DeleteImage(reportID string) error {
+	_, err := c.cld.Upload.Destroy(context.Background(), uploader.DestroyParams{
+		PublicID: "reports/" + reportID,
+	})
+	return err
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SendCSVResponse writes the rows as a CSV attachment. Cells a spreadsheet
+// would read as a formula are escaped.
+func SendCSVResponse(c *gin.Context, filename string, header []string, rows [][]string) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(200)
+
+	writer := csv.NewWriter(c.Writer)
+	writer.Write(EscapeCSVRow(header))
+	escaped := make([][]string, len(rows))
+	for i, row := range rows {
+		escaped[i] = EscapeCSVRow(row)
+	}
+	writer.WriteAll(escaped)
+	if err := writer.Error(); err != nil {
+		InternalErrorLog(err, "filename", filename)
+	}
+}
+
+// EscapeCSVRow prefixes cells starting with =, +, -, @, a tab or a carriage
+// return with a quote, so they are not run as formulas. Numbers such as
+// negative coordinates are left as they are.
+func EscapeCSVRow(row []string) []string {
+	escaped := make([]string, len(row))
+	for i, cell := range row {
+		escaped[i] = cell
+		if cell == "" || !strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+			continue
+		}
+		if _, err := strconv.ParseFloat(cell, 64); err == nil {
+			continue
+		}
+		escaped[i] = "'" + cell
+	}
+	return escaped
+}
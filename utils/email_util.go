@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"fmt"
+	"net/smtp"
+	"os"
+)
+
+// SendEmail sends a plain text email through the configured SMTP server. When
+// SMTP is not configured the email is skipped and only its recipient and
+// subject are logged, since the body may hold codes or links.
+func SendEmail(to, subject, body string) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	smtpEmail := os.Getenv("SMTP_EMAIL")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+
+	if smtpHost == "" || smtpPort == "" || smtpEmail == "" || smtpPassword == "" {
+		InfoLog("smtp not configured, email not sent", "to", to, "subject", subject)
+		return nil
+	}
+
+	auth := smtp.PlainAuth("", smtpEmail, smtpPassword, smtpHost)
+
+	msg := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		smtpEmail, to, subject, body))
+
+	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+	return smtp.SendMail(addr, auth, smtpEmail, []string{to}, msg)
+}
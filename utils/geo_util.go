@@ -0,0 +1,43 @@
+package utils
+
+import "math"
+
+const earthRadiusKm = 6371.0
+
+// HaversineKm returns the great-circle distance between two coordinates.
+func HaversineKm(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLng := (lng2 - lng1) * math.Pi / 180
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*math.Pi/180)*math.Cos(lat2*math.Pi/180)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// DistanceToLineMeters returns the distance from the point to the nearest part
+// of the line, given as GeoJSON positions, longitude first. Coordinates are
+// projected flat around the point, which is accurate over a few kilometres.
+func DistanceToLineMeters(lat, lng float64, line [][2]float64) float64 {
+	metersPerLat := earthRadiusKm * 1000 * math.Pi / 180
+	metersPerLng := metersPerLat * math.Cos(lat*math.Pi/180)
+	project := func(position [2]float64) (float64, float64) {
+		return (position[0] - lng) * metersPerLng, (position[1] - lat) * metersPerLat
+	}
+
+	best := math.Inf(1)
+	if len(line) == 1 {
+		x, y := project(line[0])
+		return math.Hypot(x, y)
+	}
+	for i := 1; i < len(line); i++ {
+		ax, ay := project(line[i-1])
+		bx, by := project(line[i])
+		dx, dy := bx-ax, by-ay
+
+		t := 0.0
+		if length := dx*dx + dy*dy; length > 0 {
+			t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/length))
+		}
+		best = math.Min(best, math.Hypot(ax+t*dx, ay+t*dy))
+	}
+	return best
+}
@@ -0,0 +1,122 @@
+package utils
+
+import "math"
+
+const geohashBase32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// GeohashPrecision is the length of the geohash stored with each report,
+// about 1.2 km by 0.6 km per cell.
+const GeohashPrecision = 6
+
+// GeohashEncode returns the geohash of a coordinate with the given number of
+// characters.
+func GeohashEncode(lat, lng float64, precision int) string {
+	minLat, maxLat := -90.0, 90.0
+	minLng, maxLng := -180.0, 180.0
+
+	hash := make([]byte, 0, precision)
+	bit, ch, even := 0, 0, true
+	for len(hash) < precision {
+		if even {
+			mid := (minLng + maxLng) / 2
+			if lng >= mid {
+				ch |= 1 << (4 - bit)
+				minLng = mid
+			} else {
+				maxLng = mid
+			}
+		} else {
+			mid := (minLat + maxLat) / 2
+			if lat >= mid {
+				ch |= 1 << (4 - bit)
+				minLat = mid
+			} else {
+				maxLat = mid
+			}
+		}
+		even = !even
+
+		if bit < 4 {
+			bit++
+		} else {
+			hash = append(hash, geohashBase32[ch])
+			bit, ch = 0, 0
+		}
+	}
+	return string(hash)
+}
+
+// geohashCellSize returns the height and width in degrees of a geohash cell
+// with the given number of characters.
+func geohashCellSize(precision int) (float64, float64) {
+	bits := 5 * precision
+	lngBits := (bits + 1) / 2
+	latBits := bits / 2
+	return 180 / math.Pow(2, float64(latBits)), 360 / math.Pow(2, float64(lngBits))
+}
+
+// GeohashCover returns the geohash prefixes of the longest length whose cells
+// cover the bounding box using at most maxCells cells. It returns nil when even
+// single character cells need more than maxCells, in which case the caller
+// should filter on coordinates alone.
+func GeohashCover(minLat, minLng, maxLat, maxLng float64, maxCells int) []string {
+	for precision := GeohashPrecision; precision >= 1; precision-- {
+		height, width := geohashCellSize(precision)
+		rows := int(math.Floor((maxLat+90)/height) - math.Floor((minLat+90)/height) + 1)
+		cols := int(math.Floor((maxLng+180)/width) - math.Floor((minLng+180)/width) + 1)
+		if rows*cols > maxCells {
+			continue
+		}
+
+		seen := make(map[string]bool, rows*cols)
+		cells := make([]string, 0, rows*cols)
+		startLat := math.Floor((minLat+90)/height)*height - 90
+		startLng := math.Floor((minLng+180)/width)*width - 180
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				lat := math.Min(startLat+(float64(r)+0.5)*height, 90)
+				lng := math.Min(startLng+(float64(c)+0.5)*width, 180)
+				cell := GeohashEncode(lat, lng, precision)
+				if !seen[cell] {
+					seen[cell] = true
+					cells = append(cells, cell)
+				}
+			}
+		}
+		return cells
+	}
+	return nil
+}
+
+// PointInPolygon reports whether the point lies inside the polygon given as
+// GeoJSON rings, longitude first. The first ring is the outer boundary and
+// the others are holes.
+func PointInPolygon(lat, lng float64, rings [][][2]float64) bool {
+	if len(rings) == 0 || !pointInRing(lat, lng, rings[0]) {
+		return false
+	}
+	for _, hole := range rings[1:] {
+		if pointInRing(lat, lng, hole) {
+			return false
+		}
+	}
+	return true
+}
+
+func pointInRing(lat, lng float64, ring [][2]float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > lat) != (yj > lat) && lng < (xj-xi)*(lat-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// ZoomCellDegrees is the size in degrees of a square grid cell of about
+// cellPixels screen pixels on a web map at the zoom level.
+func ZoomCellDegrees(zoom int, cellPixels float64) float64 {
+	return 360 / math.Pow(2, float64(zoom)) / 256 * cellPixels
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeohashEncode(t *testing.T) {
+	tests := []struct {
+		name      string
+		lat, lng  float64
+		precision int
+		want      string
+	}{
+		{"reference point", 57.64911, 10.40744, 11, "u4pruydqqvj"},
+		{"five characters", 42.6, -5.6, 5, "ezs42"},
+		{"origin", 0, 0, 1, "s"},
+		{"south west corner", -90, -180, 4, "0000"},
+		{"north east corner", 89.9999, 179.9999, 4, "zzzz"},
+		{"report precision", -6.2, 106.816666, GeohashPrecision, "qqguwx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GeohashEncode(tt.lat, tt.lng, tt.precision)
+			if len(got) != tt.precision || !strings.HasPrefix(got, tt.want) {
+				t.Errorf("GeohashEncode(%v, %v, %d) = %q, want %q", tt.lat, tt.lng, tt.precision, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeohashCover(t *testing.T) {
+	tests := []struct {
+		name                           string
+		minLat, minLng, maxLat, maxLng float64
+		maxCells                       int
+		wantNil                        bool
+	}{
+		{"inside one cell", -6.2001, 106.8001, -6.2000, 106.8002, 9, false},
+		{"across cell edges", -6.25, 106.75, -6.15, 106.9, 32, false},
+		{"across the equator", -0.01, 100.0, 0.01, 100.02, 16, false},
+		{"single cell allowed", -6.3, 106.7, -6.1, 106.9, 1, false},
+		{"too large for any precision", -89, -179, 89, 179, 4, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cells := GeohashCover(tt.minLat, tt.minLng, tt.maxLat, tt.maxLng, tt.maxCells)
+			if tt.wantNil {
+				if cells != nil {
+					t.Fatalf("GeohashCover() = %v, want nil", cells)
+				}
+				return
+			}
+			if len(cells) == 0 || len(cells) > tt.maxCells {
+				t.Fatalf("GeohashCover() returned %d cells, want 1 to %d", len(cells), tt.maxCells)
+			}
+
+			for _, point := range [][2]float64{
+				{tt.minLat, tt.minLng}, {tt.minLat, tt.maxLng}, {tt.maxLat, tt.minLng}, {tt.maxLat, tt.maxLng},
+				{(tt.minLat + tt.maxLat) / 2, (tt.minLng + tt.maxLng) / 2},
+			} {
+				hash := GeohashEncode(point[0], point[1], GeohashPrecision)
+				covered := false
+				for _, cell := range cells {
+					covered = covered || strings.HasPrefix(hash, cell)
+				}
+				if !covered {
+					t.Errorf("point %v (%s) is not covered by %v", point, hash, cells)
+				}
+			}
+		})
+	}
+}
+
+func TestPointInPolygon(t *testing.T) {
+	square := [][2]float64{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}
+	hole := [][2]float64{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}}
+	triangle := [][2]float64{{100, -5}, {110, -5}, {105, 5}, {100, -5}}
+
+	tests := []struct {
+		name     string
+		lat, lng float64
+		rings    [][][2]float64
+		want     bool
+	}{
+		{"inside", 2, 2, [][][2]float64{square}, true},
+		{"outside", 12, 2, [][][2]float64{square}, false},
+		{"swapped axes outside", 2, 12, [][][2]float64{square}, false},
+		{"inside the hole", 5, 5, [][][2]float64{square, hole}, false},
+		{"beside the hole", 5, 8, [][][2]float64{square, hole}, true},
+		{"inside a triangle", 0, 105, [][][2]float64{triangle}, true},
+		{"beside a triangle", 4, 101, [][][2]float64{triangle}, false},
+		{"no rings", 2, 2, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PointInPolygon(tt.lat, tt.lng, tt.rings); got != tt.want {
+				t.Errorf("PointInPolygon(%v, %v) = %v, want %v", tt.lat, tt.lng, got, tt.want)
+			}
+		})
+	}
+}
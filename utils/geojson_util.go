@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"dinacom-11.0-backend/models/dto"
+)
+
+// ReadFeatureCollections reads the GeoJSON FeatureCollections at path, either
+// a single file or a directory of .geojson and .json files, keyed by file
+// name.
+func ReadFeatureCollections(path string) ([]string, map[string]dto.GeoJSONFeatureCollection, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files = nil
+		for _, pattern := range []string{"*.geojson", "*.json"} {
+			matches, err := filepath.Glob(filepath.Join(path, pattern))
+			if err != nil {
+				return nil, nil, err
+			}
+			files = append(files, matches...)
+		}
+		sort.Strings(files)
+	}
+
+	names := make([]string, 0, len(files))
+	collections := make(map[string]dto.GeoJSONFeatureCollection, len(files))
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		var collection dto.GeoJSONFeatureCollection
+		if err := json.Unmarshal(raw, &collection); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", filepath.Base(file), err)
+		}
+		names = append(names, filepath.Base(file))
+		collections[filepath.Base(file)] = collection
+	}
+	return names, collections, nil
+}
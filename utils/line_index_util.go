@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"math"
+	"sort"
+)
+
+// LineIndex finds the lines, given as GeoJSON positions, nearest to a point.
+// Lines are bucketed on a grid of square cells so a lookup only measures the
+// lines in the cells around the point; searches reach at most one cell away.
+type LineIndex struct {
+	cellDegrees float64
+	shapes      [][][][2]float64
+	cells       map[[2]int64][]int
+}
+
+// LineMatch is an indexed shape and its distance from the searched point.
+type LineMatch struct {
+	Index          int
+	DistanceMeters float64
+}
+
+func NewLineIndex(cellDegrees float64) *LineIndex {
+	return &LineIndex{cellDegrees: cellDegrees, cells: make(map[[2]int64][]int)}
+}
+
+// Add indexes a shape made of one or more lines and returns its position,
+// which matches report back.
+func (i *LineIndex) Add(lines [][][2]float64) int {
+	index := len(i.shapes)
+	i.shapes = append(i.shapes, lines)
+
+	seen := map[[2]int64]bool{}
+	for _, line := range lines {
+		for j := range line {
+			from, to := line[j], line[j]
+			if j > 0 {
+				from = line[j-1]
+			}
+			for row := i.cell(math.Min(from[1], to[1])); row <= i.cell(math.Max(from[1], to[1])); row++ {
+				for col := i.cell(math.Min(from[0], to[0])); col <= i.cell(math.Max(from[0], to[0])); col++ {
+					key := [2]int64{row, col}
+					if !seen[key] {
+						seen[key] = true
+						i.cells[key] = append(i.cells[key], index)
+					}
+				}
+			}
+		}
+	}
+	return index
+}
+
+func (i *LineIndex) Len() int {
+	return len(i.shapes)
+}
+
+// Within returns the shapes no further than maxMeters from the point,
+// nearest first.
+func (i *LineIndex) Within(lat, lng, maxMeters float64) []LineMatch {
+	var matches []LineMatch
+	row, col := i.cell(lat), i.cell(lng)
+	checked := map[int]bool{}
+	for dRow := int64(-1); dRow <= 1; dRow++ {
+		for dCol := int64(-1); dCol <= 1; dCol++ {
+			for _, index := range i.cells[[2]int64{row + dRow, col + dCol}] {
+				if checked[index] {
+					continue
+				}
+				checked[index] = true
+
+				best := math.Inf(1)
+				for _, line := range i.shapes[index] {
+					best = math.Min(best, DistanceToLineMeters(lat, lng, line))
+				}
+				if best <= maxMeters {
+					matches = append(matches, LineMatch{Index: index, DistanceMeters: best})
+				}
+			}
+		}
+	}
+
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].DistanceMeters != matches[b].DistanceMeters {
+			return matches[a].DistanceMeters < matches[b].DistanceMeters
+		}
+		return matches[a].Index < matches[b].Index
+	})
+	return matches
+}
+
+// Nearest returns the shape closest to the point within maxMeters.
+func (i *LineIndex) Nearest(lat, lng, maxMeters float64) (LineMatch, bool) {
+	matches := i.Within(lat, lng, maxMeters)
+	if len(matches) == 0 {
+		return LineMatch{}, false
+	}
+	return matches[0], true
+}
+
+func (i *LineIndex) cell(degrees float64) int64 {
+	return int64(math.Floor(degrees / i.cellDegrees))
+}
@@ -1,31 +1,19 @@
 package utils
 
 import (
-	"fmt"
-	"log"
+	"log/slog"
 	"os"
 )
 
-func InternalErrorLog(err_log error) {
-	fmt.Println("There is an error!")
+// logger writes structured JSON lines to stdout. It is its own instance so the
+// standard library logger used by gin and gorm is left untouched. Security
+// relevant events belong in the audit log (see services.AuditService).
+var logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	file, err := os.OpenFile("logs/error_log.txt", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
-
-	if err != nil {
-		log.Fatal(err)
-	}
-	log.Println("Error Log :", err_log)
-	log.SetOutput(file)
+func InternalErrorLog(err error, attrs ...any) {
+	logger.Error(err.Error(), attrs...)
 }
 
-func SecurityLog(security_log string) {
-	fmt.Println("There is an error!")
-
-	file, err := os.OpenFile("logs/security_log.txt", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
-
-	if err != nil {
-		log.Fatal(err)
-	}
-	log.Println("Security Log :", security_log)
-	log.SetOutput(file)
+func InfoLog(message string, attrs ...any) {
+	logger.Info(message, attrs...)
 }
@@ -0,0 +1,169 @@
+package utils
+
+import (
+	"math"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// MVTExtent is the tile coordinate range of the encoded layers.
+const MVTExtent = 4096
+
+// maxMercatorLatitude is where web mercator tiles end.
+const maxMercatorLatitude = 85.05112878
+
+// MVTPoint is a point feature for a Mapbox Vector Tile layer. Property values
+// may be strings, float64, int, int64 or bool.
+type MVTPoint struct {
+	Latitude   float64
+	Longitude  float64
+	Properties map[string]interface{}
+}
+
+// TileBounds returns the coordinates covered by a web mercator tile.
+func TileBounds(z, x, y int) (minLat, minLng, maxLat, maxLng float64) {
+	n := math.Pow(2, float64(z))
+	minLng = float64(x)/n*360 - 180
+	maxLng = float64(x+1)/n*360 - 180
+	maxLat = tileLatitude(float64(y), n)
+	minLat = tileLatitude(float64(y+1), n)
+	return minLat, minLng, maxLat, maxLng
+}
+
+// TileRange returns the columns and rows of the web mercator tiles covering
+// the bounding box at the zoom level.
+func TileRange(z int, minLat, minLng, maxLat, maxLng float64) (minX, minY, maxX, maxY int) {
+	n := math.Pow(2, float64(z))
+	clamp := func(v float64) int {
+		return int(math.Max(0, math.Min(n-1, math.Floor(v))))
+	}
+	minX = clamp((minLng + 180) / 360 * n)
+	maxX = clamp((maxLng + 180) / 360 * n)
+	minY = clamp(mercatorY(maxLat) * n)
+	maxY = clamp(mercatorY(minLat) * n)
+	return minX, minY, maxX, maxY
+}
+
+// mercatorY is the web mercator row of a latitude, from 0 at the top of the
+// world to 1 at the bottom.
+func mercatorY(lat float64) float64 {
+	lat = math.Max(-maxMercatorLatitude, math.Min(maxMercatorLatitude, lat))
+	latRad := lat * math.Pi / 180
+	return (1 - math.Log(math.Tan(latRad)+1/math.Cos(latRad))/math.Pi) / 2
+}
+
+func tileLatitude(y, n float64) float64 {
+	return math.Atan(math.Sinh(math.Pi*(1-2*y/n))) * 180 / math.Pi
+}
+
+// tilePixel projects a coordinate into the tile's extent.
+func tilePixel(lat, lng float64, z, x, y int) (int64, int64) {
+	n := math.Pow(2, float64(z))
+	tileX := (lng + 180) / 360 * n
+	tileY := mercatorY(lat) * n
+	return int64(math.Round((tileX - float64(x)) * MVTExtent)), int64(math.Round((tileY - float64(y)) * MVTExtent))
+}
+
+// EncodeMVTPoints encodes the points as a single layer of a Mapbox Vector Tile
+// (specification 2.1).
+func EncodeMVTPoints(layerName string, z, x, y int, points []MVTPoint) []byte {
+	var keys []string
+	keyIndex := map[string]uint64{}
+	var values [][]byte
+	valueIndex := map[interface{}]uint64{}
+
+	var layer []byte
+	layer = protowire.AppendTag(layer, 15, protowire.VarintType)
+	layer = protowire.AppendVarint(layer, 2)
+	layer = protowire.AppendTag(layer, 1, protowire.BytesType)
+	layer = protowire.AppendString(layer, layerName)
+
+	for i, point := range points {
+		names := make([]string, 0, len(point.Properties))
+		for name := range point.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var tags []byte
+		for _, name := range names {
+			value, ok := encodeMVTValue(point.Properties[name])
+			if !ok {
+				continue
+			}
+			k, seen := keyIndex[name]
+			if !seen {
+				k = uint64(len(keys))
+				keyIndex[name] = k
+				keys = append(keys, name)
+			}
+			v, seen := valueIndex[point.Properties[name]]
+			if !seen {
+				v = uint64(len(values))
+				valueIndex[point.Properties[name]] = v
+				values = append(values, value)
+			}
+			tags = protowire.AppendVarint(tags, k)
+			tags = protowire.AppendVarint(tags, v)
+		}
+
+		px, py := tilePixel(point.Latitude, point.Longitude, z, x, y)
+		var geometry []byte
+		geometry = protowire.AppendVarint(geometry, 1|1<<3) // MoveTo, one point
+		geometry = protowire.AppendVarint(geometry, protowire.EncodeZigZag(px))
+		geometry = protowire.AppendVarint(geometry, protowire.EncodeZigZag(py))
+
+		var feature []byte
+		feature = protowire.AppendTag(feature, 1, protowire.VarintType)
+		feature = protowire.AppendVarint(feature, uint64(i+1))
+		feature = protowire.AppendTag(feature, 2, protowire.BytesType)
+		feature = protowire.AppendBytes(feature, tags)
+		feature = protowire.AppendTag(feature, 3, protowire.VarintType)
+		feature = protowire.AppendVarint(feature, 1) // POINT
+		feature = protowire.AppendTag(feature, 4, protowire.BytesType)
+		feature = protowire.AppendBytes(feature, geometry)
+
+		layer = protowire.AppendTag(layer, 2, protowire.BytesType)
+		layer = protowire.AppendBytes(layer, feature)
+	}
+
+	for _, key := range keys {
+		layer = protowire.AppendTag(layer, 3, protowire.BytesType)
+		layer = protowire.AppendString(layer, key)
+	}
+	for _, value := range values {
+		layer = protowire.AppendTag(layer, 4, protowire.BytesType)
+		layer = protowire.AppendBytes(layer, value)
+	}
+	layer = protowire.AppendTag(layer, 5, protowire.VarintType)
+	layer = protowire.AppendVarint(layer, MVTExtent)
+
+	var tile []byte
+	tile = protowire.AppendTag(tile, 3, protowire.BytesType)
+	return protowire.AppendBytes(tile, layer)
+}
+
+func encodeMVTValue(value interface{}) ([]byte, bool) {
+	var encoded []byte
+	switch v := value.(type) {
+	case string:
+		encoded = protowire.AppendTag(encoded, 1, protowire.BytesType)
+		encoded = protowire.AppendString(encoded, v)
+	case float64:
+		encoded = protowire.AppendTag(encoded, 3, protowire.Fixed64Type)
+		encoded = protowire.AppendFixed64(encoded, math.Float64bits(v))
+	case int:
+		encoded = protowire.AppendTag(encoded, 6, protowire.VarintType)
+		encoded = protowire.AppendVarint(encoded, protowire.EncodeZigZag(int64(v)))
+	case int64:
+		encoded = protowire.AppendTag(encoded, 6, protowire.VarintType)
+		encoded = protowire.AppendVarint(encoded, protowire.EncodeZigZag(v))
+	case bool:
+		encoded = protowire.AppendTag(encoded, 7, protowire.VarintType)
+		encoded = protowire.AppendVarint(encoded, protowire.EncodeBool(v))
+	default:
+		return nil, false
+	}
+	return encoded, true
+}
@@ -0,0 +1,248 @@
+package utils
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestTileBounds(t *testing.T) {
+	tests := []struct {
+		name                           string
+		z, x, y                        int
+		minLat, minLng, maxLat, maxLng float64
+	}{
+		{"world", 0, 0, 0, -maxMercatorLatitude, -180, maxMercatorLatitude, 180},
+		{"south east quarter", 1, 1, 1, -maxMercatorLatitude, 0, 0, 180},
+		{"north west inner tile", 2, 1, 1, 0, -90, 66.51326044, 0},
+		{"jakarta at zoom 12", 12, 3263, 2118, -6.22793393, 106.78710938, -6.14055478, 106.875},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minLat, minLng, maxLat, maxLng := TileBounds(tt.z, tt.x, tt.y)
+			got := []float64{minLat, minLng, maxLat, maxLng}
+			want := []float64{tt.minLat, tt.minLng, tt.maxLat, tt.maxLng}
+			for i := range got {
+				if math.Abs(got[i]-want[i]) > 1e-6 {
+					t.Fatalf("TileBounds(%d, %d, %d) = %v, want %v", tt.z, tt.x, tt.y, got, want)
+				}
+			}
+		})
+	}
+}
+
+// mvtFeature is a decoded point feature with its properties resolved.
+type mvtFeature struct {
+	id         uint64
+	geomType   uint64
+	geometry   []uint64
+	properties map[string]interface{}
+}
+
+type mvtLayer struct {
+	name     string
+	version  uint64
+	extent   uint64
+	keys     []string
+	values   []interface{}
+	features []mvtFeature
+}
+
+func TestEncodeMVTPoints(t *testing.T) {
+	tests := []struct {
+		name       string
+		z, x, y    int
+		points     []MVTPoint
+		wantKeys   []string
+		wantValues []interface{}
+		want       []mvtFeature
+	}{
+		{
+			name:       "empty layer",
+			want:       []mvtFeature{},
+			wantKeys:   []string{},
+			wantValues: []interface{}{},
+		},
+		{
+			name: "point in the middle of the world tile",
+			points: []MVTPoint{
+				{Latitude: 0, Longitude: 0, Properties: map[string]interface{}{"status": "pending"}},
+			},
+			wantKeys:   []string{"status"},
+			wantValues: []interface{}{"pending"},
+			want: []mvtFeature{
+				{id: 1, geomType: 1, geometry: []uint64{9, 4096, 4096}, properties: map[string]interface{}{"status": "pending"}},
+			},
+		},
+		{
+			name: "shared values and unsupported types",
+			z:    1, x: 1, y: 1,
+			points: []MVTPoint{
+				{Latitude: -maxMercatorLatitude, Longitude: 180, Properties: map[string]interface{}{
+					"count": 3, "score": 0.5, "urgent": true, "skip": []string{"x"},
+				}},
+				{Latitude: 0, Longitude: 0, Properties: map[string]interface{}{
+					"count": 3, "total": int64(-2),
+				}},
+			},
+			wantKeys:   []string{"count", "score", "urgent", "total"},
+			wantValues: []interface{}{int64(3), 0.5, true, int64(-2)},
+			want: []mvtFeature{
+				{id: 1, geomType: 1, geometry: []uint64{9, 8192, 8192}, properties: map[string]interface{}{
+					"count": int64(3), "score": 0.5, "urgent": true,
+				}},
+				{id: 2, geomType: 1, geometry: []uint64{9, 0, 0}, properties: map[string]interface{}{
+					"count": int64(3), "total": int64(-2),
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layer := decodeMVTTile(t, EncodeMVTPoints("reports", tt.z, tt.x, tt.y, tt.points))
+
+			if layer.name != "reports" || layer.version != 2 || layer.extent != MVTExtent {
+				t.Errorf("layer = %q v%d extent %d, want \"reports\" v2 extent %d", layer.name, layer.version, layer.extent, MVTExtent)
+			}
+			if !reflect.DeepEqual(layer.keys, tt.wantKeys) {
+				t.Errorf("keys = %v, want %v", layer.keys, tt.wantKeys)
+			}
+			if !reflect.DeepEqual(layer.values, tt.wantValues) {
+				t.Errorf("values = %v, want %v", layer.values, tt.wantValues)
+			}
+			if !reflect.DeepEqual(layer.features, tt.want) {
+				t.Errorf("features = %+v, want %+v", layer.features, tt.want)
+			}
+		})
+	}
+}
+
+func decodeMVTTile(t *testing.T, tile []byte) mvtLayer {
+	t.Helper()
+
+	var layers [][]byte
+	eachField(t, tile, func(num protowire.Number, typ protowire.Type, raw []byte, _ uint64) {
+		if num == 3 && typ == protowire.BytesType {
+			layers = append(layers, raw)
+		}
+	})
+	if len(layers) != 1 {
+		t.Fatalf("tile has %d layers, want 1", len(layers))
+	}
+
+	layer := mvtLayer{keys: []string{}, values: []interface{}{}}
+	var features [][]byte
+	eachField(t, layers[0], func(num protowire.Number, _ protowire.Type, raw []byte, v uint64) {
+		switch num {
+		case 1:
+			layer.name = string(raw)
+		case 2:
+			features = append(features, raw)
+		case 3:
+			layer.keys = append(layer.keys, string(raw))
+		case 4:
+			layer.values = append(layer.values, decodeMVTValue(t, raw))
+		case 5:
+			layer.extent = v
+		case 15:
+			layer.version = v
+		}
+	})
+
+	layer.features = []mvtFeature{}
+	for _, raw := range features {
+		feature := mvtFeature{properties: map[string]interface{}{}}
+		var tags []uint64
+		eachField(t, raw, func(num protowire.Number, _ protowire.Type, raw []byte, v uint64) {
+			switch num {
+			case 1:
+				feature.id = v
+			case 2:
+				tags = packedVarints(t, raw)
+			case 3:
+				feature.geomType = v
+			case 4:
+				feature.geometry = packedVarints(t, raw)
+			}
+		})
+		for i := 0; i+1 < len(tags); i += 2 {
+			feature.properties[layer.keys[tags[i]]] = layer.values[tags[i+1]]
+		}
+		layer.features = append(layer.features, feature)
+	}
+	return layer
+}
+
+func decodeMVTValue(t *testing.T, raw []byte) interface{} {
+	var value interface{}
+	eachField(t, raw, func(num protowire.Number, _ protowire.Type, raw []byte, v uint64) {
+		switch num {
+		case 1:
+			value = string(raw)
+		case 3:
+			value = math.Float64frombits(v)
+		case 6:
+			value = protowire.DecodeZigZag(v)
+		case 7:
+			value = protowire.DecodeBool(v)
+		}
+	})
+	return value
+}
+
+// eachField walks a protobuf message, handing over the bytes of length
+// delimited fields and the number of the others.
+func eachField(t *testing.T, b []byte, fn func(num protowire.Number, typ protowire.Type, raw []byte, v uint64)) {
+	t.Helper()
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			t.Fatalf("bad tag: %v", protowire.ParseError(n))
+		}
+		b = b[n:]
+
+		switch typ {
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				t.Fatalf("bad varint: %v", protowire.ParseError(n))
+			}
+			fn(num, typ, nil, v)
+			b = b[n:]
+		case protowire.Fixed64Type:
+			v, n := protowire.ConsumeFixed64(b)
+			if n < 0 {
+				t.Fatalf("bad fixed64: %v", protowire.ParseError(n))
+			}
+			fn(num, typ, nil, v)
+			b = b[n:]
+		case protowire.BytesType:
+			raw, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				t.Fatalf("bad bytes: %v", protowire.ParseError(n))
+			}
+			fn(num, typ, raw, 0)
+			b = b[n:]
+		default:
+			t.Fatalf("unexpected wire type %d", typ)
+		}
+	}
+}
+
+func packedVarints(t *testing.T, b []byte) []uint64 {
+	t.Helper()
+	values := []uint64{}
+	for len(b) > 0 {
+		v, n := protowire.ConsumeVarint(b)
+		if n < 0 {
+			t.Fatalf("bad packed varint: %v", protowire.ParseError(n))
+		}
+		values = append(values, v)
+		b = b[n:]
+	}
+	return values
+}
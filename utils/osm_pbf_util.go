@@ -0,0 +1,380 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// Limits from the OSM PBF specification.
+	maxOSMBlobHeaderSize = 64 << 10
+	maxOSMBlobSize       = 32 << 20
+)
+
+var errInvalidOSMPBF = errors.New("invalid OSM PBF file")
+
+// OSMWay is a way read from an OSM PBF extract with its tags and the
+// positions of its nodes, longitude first.
+type OSMWay struct {
+	ID   int64
+	Tags map[string]string
+	Line [][2]float64
+}
+
+// osmBlock is the part of a PrimitiveBlock needed to read ways and nodes.
+type osmBlock struct {
+	strings     []string
+	granularity int64
+	latOffset   int64
+	lonOffset   int64
+	groups      [][]byte
+}
+
+type osmWayRefs struct {
+	way  OSMWay
+	refs []int64
+}
+
+// ReadOSMWays reads the ways of an OSM PBF extract that keep accepts. The
+// file is read twice, first for the ways and then for the positions of just
+// the nodes they use, so whole country extracts fit in memory. Nodes missing
+// from the extract are left out of the line.
+func ReadOSMWays(path string, keep func(tags map[string]string) bool) ([]OSMWay, error) {
+	var ways []osmWayRefs
+	needed := map[int64][2]float64{}
+	err := forEachOSMBlock(path, func(block *osmBlock) error {
+		for _, group := range block.groups {
+			err := eachProtoField(group, func(num protowire.Number, _ protowire.Type, value []byte, _ uint64) error {
+				if num != 3 {
+					return nil
+				}
+				way, refs, err := block.parseWay(value)
+				if err != nil || !keep(way.Tags) {
+					return err
+				}
+				ways = append(ways, osmWayRefs{way: way, refs: refs})
+				for _, ref := range refs {
+					needed[ref] = [2]float64{}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil || len(ways) == 0 {
+		return nil, err
+	}
+
+	found := make(map[int64]bool, len(needed))
+	store := func(block *osmBlock, id, lat, lon int64) {
+		if _, ok := needed[id]; ok {
+			needed[id] = [2]float64{block.degrees(block.lonOffset, lon), block.degrees(block.latOffset, lat)}
+			found[id] = true
+		}
+	}
+	err = forEachOSMBlock(path, func(block *osmBlock) error {
+		for _, group := range block.groups {
+			err := eachProtoField(group, func(num protowire.Number, _ protowire.Type, value []byte, _ uint64) error {
+				switch num {
+				case 1:
+					return block.parseNode(value, store)
+				case 2:
+					return block.parseDenseNodes(value, store)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]OSMWay, 0, len(ways))
+	for _, entry := range ways {
+		for _, ref := range entry.refs {
+			if found[ref] {
+				entry.way.Line = append(entry.way.Line, needed[ref])
+			}
+		}
+		if len(entry.way.Line) >= 2 {
+			result = append(result, entry.way)
+		}
+	}
+	return result, nil
+}
+
+// forEachOSMBlock decodes the OSMData blobs of the file in order.
+func forEachOSMBlock(path string, fn func(block *osmBlock) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var size [4]byte
+	for {
+		if _, err := io.ReadFull(reader, size[:]); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		headerSize := binary.BigEndian.Uint32(size[:])
+		if headerSize > maxOSMBlobHeaderSize {
+			return errInvalidOSMPBF
+		}
+		header := make([]byte, headerSize)
+		if _, err := io.ReadFull(reader, header); err != nil {
+			return err
+		}
+
+		var blobType string
+		var dataSize uint64
+		err := eachProtoField(header, func(num protowire.Number, _ protowire.Type, value []byte, varint uint64) error {
+			switch num {
+			case 1:
+				blobType = string(value)
+			case 3:
+				dataSize = varint
+			}
+			return nil
+		})
+		if err != nil || dataSize > maxOSMBlobSize {
+			return errInvalidOSMPBF
+		}
+
+		blob := make([]byte, dataSize)
+		if _, err := io.ReadFull(reader, blob); err != nil {
+			return err
+		}
+		if blobType != "OSMData" {
+			continue
+		}
+
+		data, err := decodeOSMBlob(blob)
+		if err != nil {
+			return err
+		}
+		block, err := parseOSMBlock(data)
+		if err != nil {
+			return err
+		}
+		if err := fn(block); err != nil {
+			return err
+		}
+	}
+}
+
+// decodeOSMBlob returns the contents of a raw or zlib compressed blob.
+func decodeOSMBlob(blob []byte) ([]byte, error) {
+	var data []byte
+	var compressed bool
+	err := eachProtoField(blob, func(num protowire.Number, _ protowire.Type, value []byte, _ uint64) error {
+		switch num {
+		case 1:
+			data = value
+		case 3:
+			data, compressed = value, true
+		case 4, 5, 6, 7:
+			return fmt.Errorf("OSM PBF blob compression %d is not supported, use zlib", num)
+		}
+		return nil
+	})
+	if err != nil || !compressed {
+		return data, err
+	}
+
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(io.LimitReader(reader, maxOSMBlobSize))
+}
+
+func parseOSMBlock(data []byte) (*osmBlock, error) {
+	block := &osmBlock{granularity: 100}
+	err := eachProtoField(data, func(num protowire.Number, _ protowire.Type, value []byte, varint uint64) error {
+		switch num {
+		case 1:
+			return eachProtoField(value, func(num protowire.Number, _ protowire.Type, value []byte, _ uint64) error {
+				if num == 1 {
+					block.strings = append(block.strings, string(value))
+				}
+				return nil
+			})
+		case 2:
+			block.groups = append(block.groups, value)
+		case 17:
+			block.granularity = int64(varint)
+		case 19:
+			block.latOffset = int64(varint)
+		case 20:
+			block.lonOffset = int64(varint)
+		}
+		return nil
+	})
+	return block, err
+}
+
+func (b *osmBlock) degrees(offset, value int64) float64 {
+	return 1e-9 * float64(offset+b.granularity*value)
+}
+
+func (b *osmBlock) parseWay(data []byte) (OSMWay, []int64, error) {
+	var way OSMWay
+	var keys, values, refs []uint64
+	err := eachProtoField(data, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		var err error
+		switch num {
+		case 1:
+			way.ID = int64(varint)
+		case 2:
+			keys, err = appendPackedVarints(keys, typ, value, varint)
+		case 3:
+			values, err = appendPackedVarints(values, typ, value, varint)
+		case 8:
+			refs, err = appendPackedVarints(refs, typ, value, varint)
+		}
+		return err
+	})
+	if err != nil || len(keys) != len(values) {
+		return way, nil, errInvalidOSMPBF
+	}
+
+	way.Tags = make(map[string]string, len(keys))
+	for i := range keys {
+		if keys[i] >= uint64(len(b.strings)) || values[i] >= uint64(len(b.strings)) {
+			return way, nil, errInvalidOSMPBF
+		}
+		way.Tags[b.strings[keys[i]]] = b.strings[values[i]]
+	}
+
+	ids := make([]int64, len(refs))
+	var id int64
+	for i, ref := range refs {
+		id += protowire.DecodeZigZag(ref)
+		ids[i] = id
+	}
+	return way, ids, nil
+}
+
+func (b *osmBlock) parseNode(data []byte, store func(block *osmBlock, id, lat, lon int64)) error {
+	var id, lat, lon int64
+	err := eachProtoField(data, func(num protowire.Number, _ protowire.Type, _ []byte, varint uint64) error {
+		switch num {
+		case 1:
+			id = protowire.DecodeZigZag(varint)
+		case 8:
+			lat = protowire.DecodeZigZag(varint)
+		case 9:
+			lon = protowire.DecodeZigZag(varint)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	store(b, id, lat, lon)
+	return nil
+}
+
+// parseDenseNodes reads the delta coded IDs and coordinates of DenseNodes.
+func (b *osmBlock) parseDenseNodes(data []byte, store func(block *osmBlock, id, lat, lon int64)) error {
+	var ids, lats, lons []uint64
+	err := eachProtoField(data, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		var err error
+		switch num {
+		case 1:
+			ids, err = appendPackedVarints(ids, typ, value, varint)
+		case 8:
+			lats, err = appendPackedVarints(lats, typ, value, varint)
+		case 9:
+			lons, err = appendPackedVarints(lons, typ, value, varint)
+		}
+		return err
+	})
+	if err != nil || len(ids) != len(lats) || len(ids) != len(lons) {
+		return errInvalidOSMPBF
+	}
+
+	var id, lat, lon int64
+	for i := range ids {
+		id += protowire.DecodeZigZag(ids[i])
+		lat += protowire.DecodeZigZag(lats[i])
+		lon += protowire.DecodeZigZag(lons[i])
+		store(b, id, lat, lon)
+	}
+	return nil
+}
+
+// eachProtoField calls fn with every field of a protobuf message, passing
+// length delimited values as bytes and varints as numbers.
+func eachProtoField(data []byte, fn func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return errInvalidOSMPBF
+		}
+		data = data[n:]
+
+		var err error
+		switch typ {
+		case protowire.VarintType:
+			var varint uint64
+			varint, n = protowire.ConsumeVarint(data)
+			if n >= 0 {
+				err = fn(num, typ, nil, varint)
+			}
+		case protowire.BytesType:
+			var value []byte
+			value, n = protowire.ConsumeBytes(data)
+			if n >= 0 {
+				err = fn(num, typ, value, 0)
+			}
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return errInvalidOSMPBF
+		}
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// appendPackedVarints appends a repeated varint field, which encoders write
+// packed but may also write one value at a time.
+func appendPackedVarints(values []uint64, typ protowire.Type, packed []byte, varint uint64) ([]uint64, error) {
+	if typ == protowire.VarintType {
+		return append(values, varint), nil
+	}
+	for len(packed) > 0 {
+		value, n := protowire.ConsumeVarint(packed)
+		if n < 0 {
+			return nil, errInvalidOSMPBF
+		}
+		values = append(values, value)
+		packed = packed[n:]
+	}
+	return values, nil
+}
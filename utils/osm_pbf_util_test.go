@@ -0,0 +1,223 @@
+package utils
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The fixture's string table.
+const (
+	osmStrHighway = iota + 1
+	osmStrResidential
+	osmStrName
+	osmStrSudirman
+	osmStrBuilding
+	osmStrYes
+)
+
+var osmTestStrings = []string{"", "highway", "residential", "name", "Jalan Sudirman", "building", "yes"}
+
+func TestReadOSMWays(t *testing.T) {
+	fixture := writeOSMFixture(t, osmFixture())
+
+	highways := func(tags map[string]string) bool { return tags["highway"] != "" }
+	tests := []struct {
+		name string
+		keep func(tags map[string]string) bool
+		want []OSMWay
+	}{
+		{
+			name: "highways only",
+			keep: highways,
+			want: []OSMWay{
+				{ID: 10, Tags: map[string]string{"highway": "residential", "name": "Jalan Sudirman"},
+					Line: [][2]float64{{106.8, -6.2}, {106.801, -6.201}, {106.804, -6.204}}},
+				{ID: 13, Tags: map[string]string{"highway": "residential"},
+					Line: [][2]float64{{106.8, -6.2}, {106.802, -6.202}}},
+			},
+		},
+		{
+			name: "every way",
+			keep: func(map[string]string) bool { return true },
+			want: []OSMWay{
+				{ID: 10, Tags: map[string]string{"highway": "residential", "name": "Jalan Sudirman"},
+					Line: [][2]float64{{106.8, -6.2}, {106.801, -6.201}, {106.804, -6.204}}},
+				{ID: 11, Tags: map[string]string{"building": "yes"},
+					Line: [][2]float64{{106.801, -6.201}, {106.802, -6.202}}},
+				{ID: 13, Tags: map[string]string{"highway": "residential"},
+					Line: [][2]float64{{106.8, -6.2}, {106.802, -6.202}}},
+			},
+		},
+		{
+			name: "nothing kept",
+			keep: func(map[string]string) bool { return false },
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ways, err := ReadOSMWays(fixture, tt.keep)
+			if err != nil {
+				t.Fatalf("ReadOSMWays() error = %v", err)
+			}
+			sort.Slice(ways, func(i, j int) bool { return ways[i].ID < ways[j].ID })
+			for i := range ways {
+				for j := range ways[i].Line {
+					for k := range ways[i].Line[j] {
+						ways[i].Line[j][k] = math.Round(ways[i].Line[j][k]*1e7) / 1e7
+					}
+				}
+			}
+			if !reflect.DeepEqual(ways, tt.want) {
+				t.Errorf("ReadOSMWays() = %+v, want %+v", ways, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadOSMWaysErrors(t *testing.T) {
+	lzma := protowire.AppendTag(nil, 4, protowire.BytesType)
+	lzma = protowire.AppendBytes(lzma, []byte{0})
+	full := osmFixture()
+
+	tests := []struct {
+		name string
+		file []byte
+	}{
+		{"unsupported compression", appendOSMBlob(nil, "OSMData", lzma)},
+		{"truncated blob", full[:len(full)-3]},
+		{"oversized header", []byte{0, 1, 0, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadOSMWays(writeOSMFixture(t, tt.file), func(map[string]string) bool { return true }); err == nil {
+				t.Error("ReadOSMWays() error = nil, want an error")
+			}
+		})
+	}
+
+	if _, err := ReadOSMWays(filepath.Join(t.TempDir(), "missing.pbf"), nil); err == nil {
+		t.Error("ReadOSMWays() on a missing file error = nil, want an error")
+	}
+}
+
+// osmFixture builds an extract of a header blob, a zlib compressed block
+// with dense nodes, a plain node and ways, and a raw block with a way using
+// nodes of the first block.
+func osmFixture() []byte {
+	var header []byte
+	header = protowire.AppendTag(header, 4, protowire.BytesType)
+	header = protowire.AppendString(header, "OsmSchema-V0.6")
+
+	dense := osmMessage(
+		osmPacked(1, 1, 1, 1),                   // IDs 1, 2, 3
+		osmPacked(8, -62000000, -10000, -10000), // latitudes -6.2, -6.201, -6.202
+		osmPacked(9, 1068000000, 10000, 10000),  // longitudes 106.8, 106.801, 106.802
+	)
+	node := osmMessage(
+		osmVarint(1, protowire.EncodeZigZag(4)),
+		osmVarint(8, protowire.EncodeZigZag(-62040000)),
+		osmVarint(9, protowire.EncodeZigZag(1068040000)),
+	)
+	first := osmTestBlock(
+		osmMessage(osmBytes(2, dense)),
+		osmMessage(osmBytes(1, node)),
+		osmMessage(
+			osmBytes(3, osmWay(10, []uint64{osmStrHighway, osmStrName}, []uint64{osmStrResidential, osmStrSudirman}, 1, 2, 4)),
+			osmBytes(3, osmWay(11, []uint64{osmStrBuilding}, []uint64{osmStrYes}, 2, 3)),
+			osmBytes(3, osmWay(12, []uint64{osmStrHighway}, []uint64{osmStrResidential}, 3, 99)),
+		),
+	)
+	second := osmTestBlock(
+		osmMessage(osmBytes(3, osmWay(13, []uint64{osmStrHighway}, []uint64{osmStrResidential}, 1, 3))),
+	)
+
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	writer.Write(first)
+	writer.Close()
+
+	var file []byte
+	file = appendOSMBlob(file, "OSMHeader", osmMessage(osmBytes(1, header)))
+	file = appendOSMBlob(file, "OSMData", osmMessage(osmVarint(2, uint64(len(first))), osmBytes(3, compressed.Bytes())))
+	file = appendOSMBlob(file, "OSMData", osmMessage(osmBytes(1, second)))
+	return file
+}
+
+func osmTestBlock(groups ...[]byte) []byte {
+	var table [][]byte
+	for _, s := range osmTestStrings {
+		table = append(table, osmBytes(1, []byte(s)))
+	}
+	fields := [][]byte{osmBytes(1, osmMessage(table...))}
+	for _, group := range groups {
+		fields = append(fields, osmBytes(2, group))
+	}
+	return osmMessage(fields...)
+}
+
+// osmWay encodes a way with its node references delta coded.
+func osmWay(id uint64, keys, values []uint64, refs ...int64) []byte {
+	deltas := make([]int64, len(refs))
+	var previous int64
+	for i, ref := range refs {
+		deltas[i], previous = ref-previous, ref
+	}
+
+	var packedKeys, packedValues []byte
+	for i := range keys {
+		packedKeys = protowire.AppendVarint(packedKeys, keys[i])
+		packedValues = protowire.AppendVarint(packedValues, values[i])
+	}
+	return osmMessage(osmVarint(1, id), osmBytes(2, packedKeys), osmBytes(3, packedValues), osmPacked(8, deltas...))
+}
+
+func appendOSMBlob(file []byte, blobType string, blob []byte) []byte {
+	header := osmMessage(osmBytes(1, []byte(blobType)), osmVarint(3, uint64(len(blob))))
+	file = binary.BigEndian.AppendUint32(file, uint32(len(header)))
+	file = append(file, header...)
+	return append(file, blob...)
+}
+
+func osmMessage(fields ...[]byte) []byte {
+	return bytes.Join(fields, nil)
+}
+
+func osmBytes(num protowire.Number, value []byte) []byte {
+	field := protowire.AppendTag(nil, num, protowire.BytesType)
+	return protowire.AppendBytes(field, value)
+}
+
+func osmVarint(num protowire.Number, value uint64) []byte {
+	field := protowire.AppendTag(nil, num, protowire.VarintType)
+	return protowire.AppendVarint(field, value)
+}
+
+// osmPacked encodes signed values as a packed zigzag varint field.
+func osmPacked(num protowire.Number, values ...int64) []byte {
+	var packed []byte
+	for _, value := range values {
+		packed = protowire.AppendVarint(packed, protowire.EncodeZigZag(value))
+	}
+	return osmBytes(num, packed)
+}
+
+func writeOSMFixture(t *testing.T, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "extract.osm.pbf")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
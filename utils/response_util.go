@@ -52,6 +52,14 @@ func ResponseFAILED[TMetaData any](c *gin.Context, metaData TMetaData, err error
 			MetaData: metaData,
 		})
 		return
+	} else if errors.Is(err, http_error.REPORT_VERSION_CONFLICT) {
+		c.JSON(409, dto.ErrorResponse{
+			Status:   "error",
+			Error:    err,
+			Message:  err.Error(),
+			MetaData: metaData,
+		})
+		return
 	} else if errors.Is(err, http_error.TIMEOUT) {
 		c.JSON(504, dto.ErrorResponse{
 			Status:   "error",